@@ -0,0 +1,57 @@
+//go:build go1.19
+
+package iterator
+
+import "sync/atomic"
+
+// Atomic sinks
+// The atomic sinks aggregate the values of an Iterable into atomic values. They are safe to use from multiple
+// goroutines that consume their own Iterables concurrently, so no mutex-protected closures need to be written.
+
+// CountInto accepts an Iterable and adds the amount of values in the Iterable to the provided counter.
+// The values that were counted before an error occurred remain added to the counter.
+// An error is returned when an error during iteration has occurred.
+func CountInto[T any](iter Iterable[T], counter *atomic.Int64) error {
+	for _, ok := iter.Next(); ok; _, ok = iter.Next() {
+		counter.Add(1)
+	}
+	return iter.Error()
+}
+
+// SumInto accepts an Iterable and adds each value in the Iterable to the provided total.
+// The values that were added before an error occurred remain added to the total.
+// An error is returned when an error during iteration has occurred.
+func SumInto[T Integers](iter Iterable[T], total *atomic.Int64) error {
+	for v, ok := iter.Next(); ok; v, ok = iter.Next() {
+		total.Add(int64(v))
+	}
+	return iter.Error()
+}
+
+// MaxInto accepts an Iterable and stores each value in the Iterable that is greater than the current value of
+// the provided maximum. The maximum should be initialised with math.MinInt64 or the lowest expected value.
+// An error is returned when an error during iteration has occurred.
+func MaxInto[T Integers](iter Iterable[T], maximum *atomic.Int64) error {
+	for v, ok := iter.Next(); ok; v, ok = iter.Next() {
+		for cur := maximum.Load(); int64(v) > cur; cur = maximum.Load() {
+			if maximum.CompareAndSwap(cur, int64(v)) {
+				break
+			}
+		}
+	}
+	return iter.Error()
+}
+
+// MinInto accepts an Iterable and stores each value in the Iterable that is less than the current value of
+// the provided minimum. The minimum should be initialised with math.MaxInt64 or the highest expected value.
+// An error is returned when an error during iteration has occurred.
+func MinInto[T Integers](iter Iterable[T], minimum *atomic.Int64) error {
+	for v, ok := iter.Next(); ok; v, ok = iter.Next() {
+		for cur := minimum.Load(); int64(v) < cur; cur = minimum.Load() {
+			if minimum.CompareAndSwap(cur, int64(v)) {
+				break
+			}
+		}
+	}
+	return iter.Error()
+}
@@ -0,0 +1,76 @@
+//go:build go1.19
+
+package iterator
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func ExampleCountInto() {
+	var count, total, maximum, minimum atomic.Int64
+	maximum.Store(math.MinInt64)
+	minimum.Store(math.MaxInt64)
+
+	// Each goroutine consumes its own Iterable and aggregates into the shared atomic values.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom
+			// iterator that reads data from the database, but the connection is terminated while the iteration was not
+			// completed.
+			_ = CountInto[int](Sequence(start, start+24), &count)
+			_ = SumInto[int](Sequence(start, start+24), &total)
+			_ = MaxInto[int](Sequence(start, start+24), &maximum)
+			_ = MinInto[int](Sequence(start, start+24), &minimum)
+		}(i*25 + 1)
+	}
+	wg.Wait()
+
+	fmt.Println(count.Load(), total.Load(), minimum.Load(), maximum.Load())
+
+	// Output:
+	// 100 5050 1 100
+}
+
+func TestAtomicSinksConcurrently(tt *testing.T) {
+	var count, total, maximum, minimum atomic.Int64
+	maximum.Store(math.MinInt64)
+	minimum.Store(math.MaxInt64)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values, _ := ToSlice[int](Sequence(i*100, i*100+99))
+			if err := CountInto[int](FromSlice(values), &count); err != nil {
+				tt.Error(err)
+			}
+			if err := SumInto[int](FromSlice(values), &total); err != nil {
+				tt.Error(err)
+			}
+			if err := MaxInto[int](FromSlice(values), &maximum); err != nil {
+				tt.Error(err)
+			}
+			if err := MinInto[int](FromSlice(values), &minimum); err != nil {
+				tt.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if count.Load() != 800 || total.Load() != 319600 || minimum.Load() != 0 || maximum.Load() != 799 {
+		tt.Errorf("unexpected result: %d %d %d %d", count.Load(), total.Load(), minimum.Load(), maximum.Load())
+	}
+}
+
+func TestCountIntoReturnsError(tt *testing.T) {
+	var count atomic.Int64
+	if err := CountInto[int](&ErrorIterator[int]{}, &count); err == nil {
+		tt.Error("expected an error but got nil")
+	}
+}
@@ -0,0 +1,43 @@
+Feature: Any, All and None check a predicate against the values of an Iterable
+
+  Scenario Outline: <function> returns <result> for <values>
+    Given an Iterable with the values "<values>"
+    And a predicate that only selects even numbers
+    When <function> is called
+    Then no error is returned
+    And the returned answer is <result>
+
+    Examples:
+      | function | values | result |
+      | Any      | 1,3,5  | false  |
+      | Any      | 1,2,3  | true   |
+      | All      | 2,4,6  | true   |
+      | All      | 1,2,3  | false  |
+      | None     | 1,3    | true   |
+      | None     | 2,3    | false  |
+
+  Scenario: Any stops at the first matching value
+    Given an Iterable with the values "1,2,3"
+    And a predicate that only selects even numbers
+    When Any is called
+    Then calling Next() until false is returned should return the following integers:
+      | 3 |
+
+  Scenario: All stops at the first value that does not match
+    Given an Iterable with the values "2,3,4"
+    And a predicate that only selects even numbers
+    When All is called
+    Then calling Next() until false is returned should return the following integers:
+      | 4 |
+
+  Scenario: All of an empty Iterable is true
+    Given an empty Iterable
+    And a predicate that only selects even numbers
+    When All is called
+    Then the returned answer is true
+
+  Scenario: Any returns the error of the Iterable
+    Given an Iterable in an error state
+    And a predicate that only selects even numbers
+    When Any is called
+    Then an error is returned
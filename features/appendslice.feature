@@ -0,0 +1,30 @@
+Feature: AppendSlice appends the values of an Iterable to an existing slice
+
+  Scenario: The values are appended after the existing values
+    Given a buffer with the values "1,2" and a capacity of 8
+    And an Iterable with the values "3,4"
+    When AppendSlice is called
+    Then no error is returned
+    And the resulting slice is "1,2,3,4"
+    And the resulting slice reuses the buffer
+
+  Scenario: A reset buffer is reused for new values
+    Given a buffer with the values "1,2" and a capacity of 8
+    And an Iterable with the values "5"
+    When AppendSlice is called with the buffer reset to zero length
+    Then the resulting slice is "5"
+    And the resulting slice reuses the buffer
+
+  Scenario: The buffer is grown once when the size of the Iterable is known
+    Given a buffer with the values "1" and a capacity of 1
+    And an Iterable with the values "2,3,4,5,6,7,8,9,10"
+    When AppendSlice is called
+    Then the resulting slice is "1,2,3,4,5,6,7,8,9,10"
+    And the resulting slice has a capacity of 10
+
+  Scenario: The appended values are returned with the error
+    Given a buffer with the values "1" and a capacity of 4
+    And an Iterable with the values "2,3" that fails afterwards
+    When AppendSlice is called
+    Then an error is returned
+    And the resulting slice is "1,2,3"
@@ -0,0 +1,22 @@
+Feature: Average returns the mean of an Iterable of numbers
+
+  Scenario: Average returns the mean of all values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+    When Average is called
+    Then no error is returned
+    And the returned average is 2.5
+
+  Scenario: Average of an empty Iterable is zero
+    Given an empty Iterable
+    When Average is called
+    Then no error is returned
+    And the returned average is 0
+
+  Scenario: Average returns the error of the Iterable
+    Given an Iterable in an error state
+    When Average is called
+    Then an error is returned
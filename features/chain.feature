@@ -0,0 +1,29 @@
+Feature: Chain combines Stages into a pipeline
+
+  Scenario: A filter and a map Stage are chained
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    And a predicate that only selects odd numbers
+    When the filter Stage and a map Stage that formats the values are chained with Chain2
+    Then calling Next() until false is returned should return the following strings:
+      | #1 |
+      | #3 |
+      | #5 |
+
+  Scenario: Three Stages are chained
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    And a predicate that only selects odd numbers
+    When the filter Stage, a map Stage that doubles the values and a map Stage that formats the values are chained with Chain3
+    Then calling Next() until false is returned should return the following strings:
+      | #2  |
+      | #6  |
+      | #10 |
@@ -0,0 +1,26 @@
+Feature: Channel and ChannelCtx send the values of an Iterable to a channel they own
+
+  Scenario: Channel sends all values and closes the channel
+    Given an Iterable with the values "1,2,3"
+    When Channel is called with a buffer of 1
+    Then the channel receives "1,2,3" and is closed
+    And the Iterable reports no error
+
+  Scenario: The error of the Iterable can be checked after the channel was closed
+    Given an Iterable with the values "1,2" that fails afterwards
+    When Channel is called with a buffer of 0
+    Then the channel receives "1,2" and is closed
+    And the Iterable reports an error
+
+  Scenario: ChannelCtx sends all values with an active context
+    Given an active context
+    And an Iterable with the values "1,2,3"
+    When ChannelCtx is called with a buffer of 0
+    Then the channel receives "1,2,3" and is closed
+
+  Scenario: ChannelCtx closes the channel when the context is cancelled
+    Given a cancellable context
+    And an infinite Iterable
+    When ChannelCtx is called with a buffer of 0
+    And 2 values are received and the context is cancelled
+    Then the channel is closed by the goroutine
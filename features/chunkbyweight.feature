@@ -0,0 +1,30 @@
+Feature: ChunkByWeight packs values into chunks with a limited total weight
+
+  Scenario: Values are packed into chunks that do not exceed the maximum weight
+    Given an Iterable with the values "3,4,2,5,1,1,6"
+    When ChunkByWeight is called with the value as weight and a maximum weight of 7
+    Then calling Next() until false is returned should return the following groups:
+      | 3,4   |
+      | 2,5   |
+      | 1,1   |
+      | 6     |
+    And Error() of group iterator returns nil
+
+  Scenario: A value that is heavier than the maximum weight is returned on its own
+    Given an Iterable with the values "2,9,3"
+    When ChunkByWeight is called with the value as weight and a maximum weight of 5
+    Then calling Next() until false is returned should return the following groups:
+      | 2 |
+      | 9 |
+      | 3 |
+
+  Scenario: An empty Iterable returns no chunks
+    Given an empty Iterable
+    When ChunkByWeight is called with the value as weight and a maximum weight of 5
+    Then Next() of group iterator returns false
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When ChunkByWeight is called with the value as weight and a maximum weight of 5
+    Then Next() of group iterator returns false
+    And Error() of group iterator returns an error
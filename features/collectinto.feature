@@ -0,0 +1,23 @@
+Feature: CollectInto streams the values of an Iterable into a Collector
+
+  Scenario: Each value is added to the Collector
+    Given an Iterable with the values "1,2,3"
+    And a Collector that accepts at most 5 values
+    When CollectInto is called
+    Then no error is returned
+    And the Collector contains "1,2,3"
+
+  Scenario: The iteration stops at the first error of the Collector
+    Given an Iterable with the values "1,2,3,4"
+    And a Collector that accepts at most 2 values
+    When CollectInto is called
+    Then an error for the value at index 2 is returned
+    And the Collector contains "1,2"
+    And calling Next() until false is returned should return the following integers:
+      | 4 |
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    And a Collector that accepts at most 2 values
+    When CollectInto is called
+    Then an error is returned
@@ -0,0 +1,22 @@
+Feature: Compare orders two Iterables lexicographically
+
+  Scenario Outline: Compare returns <result> for <first> and <second>
+    Given an Iterable with the values "<first>"
+    And a second Iterable with the values "<second>"
+    When Compare is called
+    Then no error is returned
+    And the returned comparison is <result>
+
+    Examples:
+      | first | second | result |
+      | 1,2,3 | 1,2,3  | 0      |
+      | 1,2,3 | 1,3    | -1     |
+      | 1,3   | 1,2,3  | 1      |
+      | 1,2   | 1,2,3  | -1     |
+      | 1,2,3 | 1,2    | 1      |
+
+  Scenario: Compare returns the error of the Iterable
+    Given an Iterable in an error state
+    And a second Iterable with the values "1,2"
+    When Compare is called
+    Then an error is returned
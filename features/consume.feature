@@ -0,0 +1,40 @@
+Feature: Consume makes sure an Iterable is only consumed once
+
+  Scenario: The first consumer receives all values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+    When Consume is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+    And Error() of int iterator returns nil
+
+  Scenario: A second consumer receives ErrConsumed
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+    When Consume is called
+    And the Iterable is consumed with ToSlice
+    And the Iterable is consumed with ToSlice
+    Then ErrConsumed is returned
+
+  Scenario: The error of the source Iterable is returned
+    Given an Iterable in an error state
+    When Consume is called
+    And the Iterable is consumed with ToSlice
+    Then an error is returned
+
+  Scenario: Calling Next again after it returned false does not report ErrConsumed
+    Given an Iterable with the values "1,2"
+    When Consume is called
+    Then Next() returns true 2 times and then returns false
+    And Next() of int iterator is called again
+    And Error() of int iterator returns nil
+
+  Scenario: The error of the source Iterable is kept for a second consumer
+    Given an Iterable with the values "1,2" that fails afterwards
+    When Consume is called
+    And the Iterable is consumed with ToSlice
+    And the Iterable is consumed with ToSlice
+    Then Error() of int iterator returns "connection lost"
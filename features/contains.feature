@@ -0,0 +1,35 @@
+Feature: Contains and IndexOf search an Iterable for a value
+
+  Scenario Outline: Contains returns <result> for <value>
+    Given an Iterable with the values "4,5,6"
+    When Contains is called with <value>
+    Then no error is returned
+    And the returned answer is <result>
+
+    Examples:
+      | value | result |
+      | 5     | true   |
+      | 7     | false  |
+
+  Scenario: Contains stops at the first occurrence
+    Given an Iterable with the values "4,5,6"
+    When Contains is called with 5
+    Then calling Next() until false is returned should return the following integers:
+      | 6 |
+
+  Scenario Outline: IndexOf returns <index> for <value>
+    Given an Iterable with the values "4,5,6,5"
+    When IndexOf is called with <value>
+    Then no error is returned
+    And the returned index is <index>
+
+    Examples:
+      | value | index |
+      | 4     | 0     |
+      | 5     | 1     |
+      | 7     | -1    |
+
+  Scenario: IndexOf returns the error of the Iterable
+    Given an Iterable in an error state
+    When IndexOf is called with 1
+    Then an error is returned
@@ -0,0 +1,30 @@
+Feature: FromCSV and ToCSV stream records from and to CSV
+
+  Scenario: The records of a CSV document are returned
+    Given a reader with the contents "id,name\n1,alice\n2,bob\n"
+    When FromCSV is called
+    Then calling Next() until false is returned should return the following records:
+      | id | name  |
+      | 1  | alice |
+      | 2  | bob   |
+    And Error() of record iterator returns nil
+
+  Scenario: A parse error is returned
+    Given a reader with the contents "id,name\n1\n"
+    When FromCSV is called
+    Then calling Next() until false is returned should return the following records:
+      | id | name |
+    And Error() of record iterator returns an error
+
+  Scenario: Records are written with ToCSV
+    Given a reader with the contents "id,name\n1,alice\n2,bob\n"
+    When FromCSV is called
+    And the records with an id are written with ToCSV
+    Then no error is returned
+    And the written CSV is "1,alice\n2,bob\n"
+
+  Scenario: ToCSV returns the error of the Iterable
+    Given a reader with the contents "id,name\n1\n"
+    When FromCSV is called
+    And the records with an id are written with ToCSV
+    Then an error is returned
@@ -0,0 +1,20 @@
+Feature: Debounce only returns an item after no newer item arrived within a quiet period
+
+  Scenario: Only the last value of each burst is returned
+    Given a channel that receives the following bursts with a pause of 100 milliseconds between them:
+      | 1,2,3 |
+      | 4     |
+      | 5,6   |
+    And FromChannel is called
+    When Debounce is called with a quiet period of 30 milliseconds
+    Then calling Next() until false is returned should return the following integers:
+      | 3 |
+      | 4 |
+      | 6 |
+    And Error() of int iterator returns nil
+
+  Scenario: DebounceIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When Debounce is called with a quiet period of 30 milliseconds
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
@@ -0,0 +1,24 @@
+Feature: Delta returns the difference between each item from the iteration and its predecessor
+
+  Scenario: An Iterable with int 1,4,9,16 items returns 3,5,7
+    Given an Iterable with the following values:
+      | 1  |
+      | 4  |
+      | 9  |
+      | 16 |
+    When Delta is called
+    Then calling Next() until false is returned should return the following integers:
+      | 3 |
+      | 5 |
+      | 7 |
+
+  Scenario: An Iterable with a single item returns no values
+    Given an Iterable with the following values:
+      | 1 |
+    When Delta is called
+    Then Next() returns true 0 times and then returns false
+
+  Scenario: DeltaIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When Delta is called
+    Then Error() of int iterator returns an error
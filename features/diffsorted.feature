@@ -0,0 +1,34 @@
+Feature: DiffSorted compares two sorted Iterables and returns the changes
+
+  Scenario: Two sorted Iterables are compared and the changes are returned
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 4 |
+      | 5 |
+    And a second Iterable with the following values:
+      | 2 |
+      | 3 |
+      | 5 |
+      | 6 |
+    When DiffSorted is called
+    Then calling Next() until false is returned should return the following changes:
+      | Removed   | 1 |
+      | Unchanged | 2 |
+      | Added     | 3 |
+      | Removed   | 4 |
+      | Unchanged | 5 |
+      | Added     | 6 |
+
+  Scenario: DiffIterator handles errors in source iterators
+    Given an Iterable in an error state
+    And a second Iterable with the following values:
+      | 1 |
+    When DiffSorted is called
+    Then Next() returns no change and Error() of change iterator returns an error
+
+    Given an Iterable with the following values:
+      | 1 |
+    And a second Iterable in an error state
+    When DiffSorted is called
+    Then Next() returns no change and Error() of change iterator returns an error
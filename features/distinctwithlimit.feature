@@ -0,0 +1,35 @@
+Feature: DistinctWithLimit drops duplicate items from the iteration while tracking a bounded amount of items
+
+  Scenario: Duplicates are dropped when all values can be tracked
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 1 |
+      | 3 |
+      | 2 |
+    When DistinctWithLimit is called with a limit of 3
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And 0 values have been evicted
+
+  Scenario: A value is returned again after it has been evicted
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 1 |
+      | 3 |
+    When DistinctWithLimit is called with a limit of 2
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 1 |
+    And 2 values have been evicted
+
+  Scenario: DistinctIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When DistinctWithLimit is called with a limit of 2
+    Then Error() of int iterator returns an error
@@ -0,0 +1,28 @@
+Feature: Drain passes the values of an Iterable to a Sink and flushes it
+
+  Scenario: All values are accepted and flushed
+    Given an Iterable with the values "1,2,3,4,5"
+    And a sink that flushes every 2 values
+    When Drain is called
+    Then no error is returned
+    And the sink wrote the batches "1,2;3,4;5"
+
+  Scenario: The accepted values are flushed when the iteration fails
+    Given an Iterable with the values "1,2,3" that fails afterwards
+    And a sink that flushes every 2 values
+    When Drain is called
+    Then an error is returned
+    And the sink wrote the batches "1,2;3"
+
+  Scenario: Drain stops at the error of Accept without flushing
+    Given an Iterable with the values "1,2,3,4,5"
+    And a sink that flushes every 2 values and rejects the value 4
+    When Drain is called
+    Then an error for the value at index 3 is returned
+    And the sink wrote the batches "1,2"
+
+  Scenario: The error of the final Flush is returned
+    Given an Iterable with the values "1"
+    And a sink that flushes every 2 values and fails to flush
+    When Drain is called
+    Then an error is returned
@@ -0,0 +1,18 @@
+Feature: DrainWithin processes as many values as possible before a deadline
+
+  Scenario: All values are processed before the deadline
+    Given an Iterable with the values "1,2,3"
+    When DrainWithin is called with a deadline of 1000 milliseconds and a closure that takes 0 milliseconds
+    Then no error is returned
+    And 3 values are processed
+
+  Scenario: The deadline passes before all values are processed
+    Given an Iterable with the values "1,2,3,4,5,6,7,8,9,10"
+    When DrainWithin is called with a deadline of 150 milliseconds and a closure that takes 60 milliseconds
+    Then a deadline error with 7 remaining values is returned
+    And 3 values are processed
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When DrainWithin is called with a deadline of 1000 milliseconds and a closure that takes 0 milliseconds
+    Then an error is returned
@@ -0,0 +1,12 @@
+Feature: Empty and Once create Iterables with zero and one values
+
+  Scenario: Empty does not return any values
+    When Empty is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns nil
+
+  Scenario: Once returns exactly one value
+    When Once is called with 7
+    Then calling Next() until false is returned should return the following integers:
+      | 7 |
+    And Error() of int iterator returns nil
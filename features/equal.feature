@@ -0,0 +1,35 @@
+Feature: Equal compares the values of two Iterables
+
+  Scenario Outline: Equal returns <result> for <first> and <second>
+    Given an Iterable with the values "<first>"
+    And a second Iterable with the values "<second>"
+    When Equal is called
+    Then no error is returned
+    And the returned answer is <result>
+
+    Examples:
+      | first | second  | result |
+      | 1,2,3 | 1,2,3   | true   |
+      | 1,2,3 | 1,4,3   | false  |
+      | 1,2,3 | 1,2     | false  |
+      | 1,2   | 1,2,3   | false  |
+
+  Scenario: EqualBy compares with a custom comparator
+    Given an Iterable with the values "11,22,33"
+    And a second Iterable with the values "1,2,3"
+    When EqualBy is called with a comparator of the last digit
+    Then no error is returned
+    And the returned answer is true
+
+  Scenario: Equal stops at the first mismatch
+    Given an Iterable with the values "1,2,3"
+    And a second Iterable with the values "1,4,5"
+    When Equal is called
+    Then calling Next() until false is returned should return the following integers:
+      | 3 |
+
+  Scenario: Equal returns the error of the second Iterable
+    Given an Iterable with the values "1,2"
+    And a second Iterable in an error state
+    When Equal is called
+    Then an error is returned
@@ -0,0 +1,21 @@
+Feature: ExecuteTemplatePerElement renders a template once for each value
+
+  Scenario: The template is rendered for each value
+    Given an Iterable with the values "1,2,3"
+    And the text template "[{{.}}]"
+    When ExecuteTemplatePerElement is called
+    Then no error is returned
+    And the rendered output is "[1][2][3]"
+
+  Scenario: The iteration stops at the first template error
+    Given an Iterable with the values "1,2,3"
+    And the text template "{{if eq . 2}}{{.Missing}}{{end}}[{{.}}]"
+    When ExecuteTemplatePerElement is called
+    Then an error is returned
+    And the rendered output is "[1]"
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    And the text template "[{{.}}]"
+    When ExecuteTemplatePerElement is called
+    Then an error is returned
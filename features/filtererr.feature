@@ -0,0 +1,22 @@
+Feature: FilterErr performs a filter operation that can fail
+
+  Scenario: The values that match the predicate are returned
+    Given an Iterable with the values "1,2,3,4"
+    When FilterErr is called with a predicate that selects even numbers and fails on 0
+    Then calling Next() until false is returned should return the following integers:
+      | 2 |
+      | 4 |
+    And Error() of int iterator returns nil
+
+  Scenario: The iteration stops at the error of the predicate
+    Given an Iterable with the values "2,3,0,4"
+    When FilterErr is called with a predicate that selects even numbers and fails on 0
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns an error
+    And the source Iterable returns the value 4 next
+
+  Scenario: FilterErrIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When FilterErr is called with a predicate that selects even numbers and fails on 0
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
@@ -0,0 +1,36 @@
+Feature: Find and FindIndex return the first value that matches a predicate
+
+  Scenario: Find returns the first matching value and stops
+    Given an Iterable with the values "1,3,4,6"
+    And a predicate that only selects even numbers
+    When Find is called
+    Then no error is returned
+    And the value 4 is found
+    And calling Next() until false is returned should return the following integers:
+      | 6 |
+
+  Scenario: Find finds no value when nothing matches
+    Given an Iterable with the values "1,3,5"
+    And a predicate that only selects even numbers
+    When Find is called
+    Then no error is returned
+    And no value is found
+
+  Scenario Outline: FindIndex returns the index of the first matching value
+    Given an Iterable with the values "<values>"
+    And a predicate that only selects even numbers
+    When FindIndex is called
+    Then no error is returned
+    And the returned index is <index>
+
+    Examples:
+      | values  | index |
+      | 2,3,5   | 0     |
+      | 1,3,4,6 | 2     |
+      | 1,3,5   | -1    |
+
+  Scenario: Find returns the error of the Iterable
+    Given an Iterable in an error state
+    And a predicate that only selects even numbers
+    When Find is called
+    Then an error is returned
@@ -0,0 +1,62 @@
+Feature: First, Last and Nth return a value at a position of an Iterable
+
+  Scenario: First returns the first value and stops
+    Given an Iterable with the values "4,5,6"
+    When First is called
+    Then no error is returned
+    And the value 4 is found
+    And calling Next() until false is returned should return the following integers:
+      | 5 |
+      | 6 |
+
+  Scenario: Last returns the last value
+    Given an Iterable with the values "4,5,6"
+    When Last is called
+    Then no error is returned
+    And the value 6 is found
+
+  Scenario Outline: Nth returns the value at index <n>
+    Given an Iterable with the values "4,5,6"
+    When Nth is called with <n>
+    Then no error is returned
+    And the value <value> is found
+
+    Examples:
+      | n | value |
+      | 0 | 4     |
+      | 2 | 6     |
+
+  Scenario Outline: Nth finds no value at index <n>
+    Given an Iterable with the values "4,5,6"
+    When Nth is called with <n>
+    Then no error is returned
+    And no value is found
+
+    Examples:
+      | n  |
+      | 3  |
+      | -1 |
+
+  Scenario: Nth stops at index n
+    Given an Iterable with the values "4,5,6,7"
+    When Nth is called with 1
+    Then calling Next() until false is returned should return the following integers:
+      | 6 |
+      | 7 |
+
+  Scenario: First of an empty Iterable finds no value
+    Given an empty Iterable
+    When First is called
+    Then no error is returned
+    And no value is found
+
+  Scenario: Last of an empty Iterable finds no value
+    Given an empty Iterable
+    When Last is called
+    Then no error is returned
+    And no value is found
+
+  Scenario: Last returns the error of the Iterable
+    Given an Iterable in an error state
+    When Last is called
+    Then an error is returned
@@ -8,4 +8,56 @@ Feature: Foreach takes an Iterable and calls a function with each element
     And a foreach function that sums and counts the calls
     When Foreach is called
     Then The returned sum is 6
-    Then The returned count is 3
\ No newline at end of file
+    Then The returned count is 3
+  Scenario: ForEachIndexed passes the index of each value
+    Given an Iterable with the values "5,6,7"
+    When ForEachIndexed is called
+    Then no error is returned
+    And the following index and value pairs are received "0:5,1:6,2:7"
+
+  Scenario: ForEachIndexed returns the error of the Iterable
+    Given an Iterable in an error state
+    When ForEachIndexed is called
+    Then an error is returned
+
+  Scenario: ForEachErr calls the closure for each value
+    Given an Iterable with the values "1,2,3"
+    When ForEachErr is called with a closure that fails on 0
+    Then no error is returned
+    And the closure received "1,2,3"
+
+  Scenario: ForEachErr stops at the error of the closure
+    Given an Iterable with the values "1,0,3"
+    When ForEachErr is called with a closure that fails on 0
+    Then an error for the value at index 1 is returned
+    And the closure received "1"
+    And the source Iterable returns the value 3 next
+
+  Scenario: ForEachErr returns the error of the Iterable unwrapped
+    Given an Iterable in an error state
+    When ForEachErr is called with a closure that fails on 0
+    Then an error is returned that is not an IndexError
+
+  Scenario: TryForEach passes the index of each value
+    Given an Iterable with the values "5,6,7"
+    When TryForEach is called with a closure that stops at 0 and fails on 1
+    Then no error is returned
+    And the closure received "0:5,1:6,2:7"
+
+  Scenario: TryForEach stops without an error when ErrStop is returned
+    Given an Iterable with the values "5,0,7"
+    When TryForEach is called with a closure that stops at 0 and fails on 1
+    Then no error is returned
+    And the closure received "0:5"
+    And the source Iterable returns the value 7 next
+
+  Scenario: TryForEach returns the error of the closure with its index
+    Given an Iterable with the values "5,6,1,7"
+    When TryForEach is called with a closure that stops at 0 and fails on 1
+    Then an error for the value at index 2 is returned
+    And the closure received "0:5,1:6"
+
+  Scenario: TryForEach returns the error of the Iterable
+    Given an Iterable in an error state
+    When TryForEach is called with a closure that stops at 0 and fails on 1
+    Then an error is returned that is not an IndexError
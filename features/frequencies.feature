@@ -0,0 +1,18 @@
+Feature: Frequencies and CountBy count the values of an Iterable
+
+  Scenario: Frequencies counts the occurrences of each value
+    Given an Iterable with the values "1,2,2,3,3,3"
+    When Frequencies is called
+    Then no error is returned
+    And the returned map is "1:1,2:2,3:3"
+
+  Scenario: CountBy counts the values for each key
+    Given an Iterable with the values "11,21,32,41"
+    When CountBy is called with the last digit as key
+    Then no error is returned
+    And the returned map is "1:3,2:1"
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When Frequencies is called
+    Then an error is returned
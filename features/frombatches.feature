@@ -0,0 +1,27 @@
+Feature: FromBatches flattens an Iterable of slices
+
+  Scenario: FromBatches returns the values of all batches
+    Given an Iterable with the batches "1,2;;3;4,5"
+    When FromBatches is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    And Error() of int iterator returns nil
+
+  Scenario: FromBatches supports a source that reuses its buffer
+    Given an Iterable with the batches "1,2;3,4" that reuses its buffer
+    When FromBatches is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+
+  Scenario: FromBatches returns the error of the source
+    Given an Iterable of batches in an error state
+    When FromBatches is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
@@ -0,0 +1,51 @@
+Feature: FromList and FromRing iterate standard library containers
+
+  Scenario: FromList returns the values from front to back
+    Given a list with the values "1,2,3"
+    When FromList is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: FromList returns no values for an empty list
+    Given an empty list
+    When FromList is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns nil
+
+  Scenario: FromList returns no values for a nil list
+    Given a nil list
+    When FromList is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns nil
+
+  Scenario: FromList stops with an error at a value of another type
+    Given a list with the values "1,2,3" and a string at index 2
+    When FromList is called
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns an error
+
+  Scenario: FromRing goes around the ring the given amount of laps
+    Given a ring with the values "1,2,3"
+    When FromRing is called with 2 laps
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: FromRing returns no values for zero laps
+    Given a ring with the values "1,2,3"
+    When FromRing is called with 0 laps
+    Then Next() returns true 0 times and then returns false
+
+  Scenario: FromRing stops with an error at a value of another type
+    Given a ring with the values "1,2,3" and a string at index 1
+    When FromRing is called with 1 laps
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns an error
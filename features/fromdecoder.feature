@@ -0,0 +1,22 @@
+Feature: FromDecoder iterates the values of a stream
+
+  Scenario: A stream of documents is decoded one at a time
+    Given a stream with the documents "1 2 3"
+    When FromDecoder is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: An empty stream returns no values
+    Given a stream with the documents ""
+    When FromDecoder is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns nil
+
+  Scenario: A decode error stops the iteration
+    Given a stream with the documents "1 two 3"
+    When FromDecoder is called
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns an error
@@ -0,0 +1,28 @@
+Feature: FromFunc and FromFuncErr create Iterables from pull functions
+
+  Scenario: FromFunc returns values until the function returns false
+    When FromFunc is called with a function that counts down from 3
+    Then calling Next() until false is returned should return the following integers:
+      | 3 |
+      | 2 |
+      | 1 |
+    And Error() of int iterator returns nil
+
+  Scenario: FromFunc does not call the function anymore after it returned false
+    When FromFunc is called with a function that counts down from 1
+    Then Next() returns true 1 times and then returns false
+    And Next() returns true 0 times and then returns false
+    And the function was called 2 times
+
+  Scenario: FromFuncErr returns values until the function returns false
+    When FromFuncErr is called with a function that counts down from 2
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns nil
+
+  Scenario: FromFuncErr stops at the error of the function
+    When FromFuncErr is called with a function that counts down from 3 and fails at 1
+    Then calling Next() until false is returned should return the following integers:
+      | 3 |
+      | 2 |
+    And Error() of int iterator returns an error
+    And the function was called 3 times
@@ -0,0 +1,40 @@
+Feature: FromHTTPBodyLines and FromHTTPBodyChunks lazily read the body of an HTTP response
+
+  Scenario: The lines of the body are returned and the body is closed
+    Given an HTTP response with status 200 and body "alpha\nbeta\ngamma\n"
+    When FromHTTPBodyLines is called
+    Then calling Next() until false is returned should return the following strings:
+      | alpha |
+      | beta  |
+      | gamma |
+    And Error() of string iterator returns nil
+    And the body is closed
+
+  Scenario: The body is returned in chunks
+    Given an HTTP response with status 200 and body "abcdefgh"
+    When FromHTTPBodyChunks is called with a size of 3
+    Then calling Next() until false is returned should return the following strings:
+      | abc |
+      | def |
+      | gh  |
+    And the body is closed
+
+  Scenario: A non 2xx status is returned as error
+    Given an HTTP response with status 404 and body "not found"
+    When FromHTTPBodyLines is called
+    Then Next() of string iterator returns true 0 times and then returns false
+    And Error() of string iterator returns an error
+    And the body is closed
+
+  Scenario: A read error is returned as error
+    Given an HTTP response with status 200 and a body that fails after "alpha\n"
+    When FromHTTPBodyLines is called
+    Then Next() of string iterator returns true 1 times and then returns false
+    And Error() of string iterator returns an error
+    And the body is closed
+
+  Scenario: Close closes the body before the iteration is exhausted
+    Given an HTTP response with status 200 and body "alpha\nbeta\n"
+    When FromHTTPBodyLines is called
+    And Close is called on the body iterator
+    Then the body is closed
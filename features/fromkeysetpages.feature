@@ -0,0 +1,43 @@
+Feature: FromKeysetPages returns an iterator over pages fetched with keyset pagination
+
+  Scenario: Values of all pages are returned in order
+    Given a table with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+      | 6 |
+      | 7 |
+    When FromKeysetPages is called with a limit of 3
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+      | 6 |
+      | 7 |
+    And 3 pages have been fetched
+
+  Scenario: Values of all pages are returned in order when the next page is prefetched
+    Given a table with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    When FromKeysetPagesPrefetch is called with a limit of 2
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    And 3 pages have been fetched
+
+  Scenario: KeysetPageIterator returns the error of the fetch closure
+    Given a table that fails to fetch a page
+    When FromKeysetPages is called with a limit of 3
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
@@ -0,0 +1,43 @@
+Feature: FromMap iterates the keys and values of a map
+
+  Scenario: FromMapKeys returns all keys
+    Given a map "3:30,1:10,2:20"
+    When FromMapKeys is called
+    Then the following integers are returned in any order:
+      | 1 |
+      | 2 |
+      | 3 |
+
+  Scenario: FromMapValues returns all values
+    Given a map "3:30,1:10,2:20"
+    When FromMapValues is called
+    Then the following integers are returned in any order:
+      | 10 |
+      | 20 |
+      | 30 |
+
+  Scenario: FromMapKeysSorted returns the keys in ascending order
+    Given a map "3:30,1:10,2:20"
+    When FromMapKeysSorted is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+
+  Scenario: FromMapValuesSorted returns the values in ascending order of their keys
+    Given a map "3:10,1:30,2:20"
+    When FromMapValuesSorted is called
+    Then calling Next() until false is returned should return the following integers:
+      | 30 |
+      | 20 |
+      | 10 |
+
+  Scenario: FromMapSorted returns the pairs in ascending order of their keys
+    Given a map "3:30,1:10,2:20"
+    When FromMapSorted is called
+    Then the following pairs are returned "1:10,2:20,3:30"
+
+  Scenario: FromMap returns all pairs
+    Given a map "3:30,1:10,2:20"
+    When FromMap is called
+    Then the following pairs are returned in any order "1:10,2:20,3:30"
@@ -0,0 +1,27 @@
+Feature: FromPages lazily fetches pages and returns their values one by one
+
+  Scenario: FromPages returns the values of all pages
+    Given an API with the pages "1,2;;3;4,5"
+    When FromPages is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    And Error() of int iterator returns nil
+    And the pages were fetched with the cursors "0,1,2,3"
+
+  Scenario: FromPages only fetches the pages that are needed
+    Given an API with the pages "1,2;3;4,5"
+    When FromPages is called
+    And Take is called with 2
+    Then Next() returns true 2 times and then returns false
+    And the pages were fetched with the cursors "0"
+
+  Scenario: FromPages stops at the error of the fetch closure
+    Given an API with the pages "1,2;3;4,5" that fails at page 2
+    When FromPages is called
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns an error
+    And the pages were fetched with the cursors "0,1"
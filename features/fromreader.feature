@@ -0,0 +1,25 @@
+Feature: FromReader reads successive chunks from an io.Reader
+
+  Scenario: The contents are returned in chunks
+    Given a reader with the contents "abcdefgh"
+    When FromReader is called with a chunk size of 3
+    Then calling Next() until false is returned should return the following strings:
+      | abc |
+      | def |
+      | gh  |
+    And Error() of string iterator returns nil
+
+  Scenario: Contents that fit the chunks exactly do not result in an empty chunk
+    Given a reader with the contents "abcdef"
+    When FromReader is called with a chunk size of 3
+    Then calling Next() until false is returned should return the following strings:
+      | abc |
+      | def |
+
+  Scenario: A read error is returned after the bytes read before it
+    Given a reader with the contents "abcd" that fails afterwards
+    When FromReader is called with a chunk size of 3
+    Then calling Next() until false is returned should return the following strings:
+      | abc |
+      | d   |
+    And Error() of string iterator returns an error
@@ -0,0 +1,49 @@
+Feature: FromRegexp lazily finds the successive matches of a regular expression
+
+  Scenario: FromRegexp returns the matches
+    When FromRegexp is called with the pattern "[0-9]+" on "a1 b22 c333"
+    Then the matches are "1,22,333"
+
+  Scenario: FromRegexp returns no matches when the text does not match
+    When FromRegexp is called with the pattern "[0-9]+" on "abc"
+    Then the matches are ""
+
+  Scenario Outline: FromRegexp returns the same matches as FindAllString for <pattern> on <text>
+    When FromRegexp is called with the pattern "<pattern>" on "<text>"
+    Then the matches are the same as the matches of FindAllString
+
+    Examples:
+      | pattern   | text        |
+      | [0-9]+    | a1 b22 c333 |
+      | x*        | axxbxc      |
+      | a*        | baaab       |
+      |           | héllo       |
+      | ^a        | aaa         |
+      | \Aa       | aaa         |
+      | (?m)^a    | a\na\nb     |
+      | (?m)^a    | aaa         |
+      | \bw       | ww w        |
+      | \Bb       | abb         |
+      | \b        | ab cd       |
+      | a$        | aaa         |
+      | \bw\w*    | one two     |
+      | (a)(x)?   | aaxa        |
+      | é+        | éé e é      |
+
+  Scenario: FromRegexpSubmatch returns the matches of the subexpressions
+    When FromRegexpSubmatch is called with the pattern "(\w+)=(\w*)(;)?" on "a=1;b=;c=3"
+    Then the submatches are "a=1;/a/1/;,b=;/b//;,c=3/c/3/"
+    And the submatches are the same as the matches of FindAllStringSubmatch
+
+  Scenario: FromRegexp does not match ^ again after the previous match
+    When FromRegexp is called with the pattern "(?m)^a" on "aaa"
+    Then the matches are "a"
+
+  Scenario: FromRegexp does not match ^ again in an alternation
+    When FromRegexp is called with the pattern "^a|b" on "aab"
+    Then the matches are "a,b"
+
+  Scenario: FromRegexpSubmatch does not match \b again after the previous match
+    When FromRegexpSubmatch is called with the pattern "\b(w)" on "ww w"
+    Then the submatches are "w/w,w/w"
+    And the submatches are the same as the matches of FindAllStringSubmatch
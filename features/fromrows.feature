@@ -0,0 +1,39 @@
+Feature: FromRows iterates the rows of a database query
+
+  Scenario: All rows are scanned and the rows are closed
+    Given a query returning the rows "1,2,3"
+    When FromRows is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+    And the rows are closed
+
+  Scenario: A query without rows returns no values
+    Given a query returning the rows ""
+    When FromRows is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns nil
+    And the rows are closed
+
+  Scenario: An iteration error of the rows is returned by Error()
+    Given a query returning the rows "1,2" that fails afterwards
+    When FromRows is called
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns an error
+    And the rows are closed
+
+  Scenario: A scan error stops the iteration and closes the rows
+    Given a query returning the rows "1,2,3"
+    When FromRows is called with a scan function that fails on the value 2
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns an error
+    And the rows are closed
+
+  Scenario: Close closes the rows when the iteration is stopped early
+    Given a query returning the rows "1,2,3"
+    When FromRows is called
+    And Next() is called once and the rows iterator is closed
+    Then the rows are closed
+    And Next() returns true 0 times and then returns false
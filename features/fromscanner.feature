@@ -0,0 +1,17 @@
+Feature: FromScanner and FromScannerBytes iterate the tokens of a bufio.Scanner
+
+  Scenario: The configuration of the scanner is kept
+    Given a reader with the contents "one two  three"
+    And a scanner that splits the reader into words
+    When FromScanner is called
+    Then calling Next() until false is returned should return the following strings:
+      | one   |
+      | two   |
+      | three |
+    And Error() of string iterator returns nil
+
+  Scenario: The byte slices remain valid after the following tokens are scanned
+    Given a reader with the contents "one two three"
+    And a scanner that splits the reader into words
+    When FromScannerBytes is called and all tokens are collected
+    Then the collected tokens are "one,two,three"
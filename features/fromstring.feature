@@ -0,0 +1,22 @@
+Feature: FromStringRunes and FromStringBytes iterate the contents of a string
+
+  Scenario: FromStringRunes returns each rune
+    Given the string "añ€"
+    When FromStringRunes is called
+    Then calling Next() until false is returned should return the following strings:
+      | a |
+      | ñ |
+      | € |
+
+  Scenario: FromStringBytes returns each byte
+    Given the string "añ"
+    When FromStringBytes is called
+    Then calling Next() until false is returned should return the following integers:
+      | 97  |
+      | 195 |
+      | 177 |
+
+  Scenario: An empty string returns no runes
+    Given the string ""
+    When FromStringRunes is called
+    Then Next() of string iterator returns true 0 times and then returns false
@@ -0,0 +1,52 @@
+Feature: Iterables keep returning false once they are exhausted
+
+  Scenario Outline: The <iterator> iterator keeps returning false after it is exhausted
+    Given a <iterator> iterator that returns 2 values
+    Then Next() returns true 2 times and then returns false
+    And Next() keeps returning false
+
+    Examples:
+      | iterator         |
+      | FromSlice        |
+      | FromReverseSlice |
+      | FromChannel      |
+      | Map              |
+      | Filter           |
+      | Take             |
+      | Sequence         |
+      | WithContext      |
+      | Fuse             |
+
+  Scenario: Fuse tracks the state of a successful iteration
+    Given an Iterable with the values "1,2"
+    When Fuse is called
+    Then the state is Active
+    And Next() returns true 2 times and then returns false
+    And the state is Exhausted
+
+  Scenario: Fuse tracks the state of a failed iteration
+    Given an Iterable in an error state
+    When Fuse is called
+    Then Next() returns true 0 times and then returns false
+    And the state is Failed
+
+  Scenario: State of an Iterable in an error state is Failed
+    Given an Iterable in an error state
+    Then the state is Failed
+
+  Scenario: NextErr returns ErrExhausted after the last value
+    Given an Iterable with the values "1"
+    Then NextErr returns 1
+    And NextErr returns ErrExhausted
+
+  Scenario: NextErr returns the error of the Iterable
+    Given an Iterable in an error state
+    Then NextErr returns an error
+
+  Scenario: Fuse keeps returning false when the source is revived by Restart
+    Given an Iterable with the values "1,2"
+    When Replayable is called
+    And Fuse is called
+    And Next() returns true 2 times and then returns false
+    And the replayable iterator is restarted
+    Then Next() keeps returning false
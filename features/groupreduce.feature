@@ -0,0 +1,14 @@
+Feature: GroupReduce reduces the values of each group in one pass
+
+  Scenario: The values are summed per group
+    Given an Iterable with the values "11,21,32,42,53"
+    And a reduce function that sums all values
+    When GroupReduce is called with the last digit as key
+    Then no error is returned
+    And the returned map is "1:32,2:74,3:53"
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    And a reduce function that sums all values
+    When GroupReduce is called with the last digit as key
+    Then an error is returned
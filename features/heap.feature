@@ -0,0 +1,38 @@
+Feature: FromHeap pops values in priority order and ToHeap pushes values on a heap
+
+  Scenario: FromHeap returns the values in priority order
+    Given a heap with the values "5,1,4,2,3"
+    When FromHeap is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    And Error() of int iterator returns nil
+    And the heap contains 0 values
+
+  Scenario: FromHeap only pops the values that are requested
+    Given a heap with the values "5,1,4,2,3"
+    When FromHeap is called
+    And Take is called with 2
+    Then Next() returns true 2 times and then returns false
+    And the heap contains 3 values
+
+  Scenario: ToHeap pushes all values on the heap
+    Given a heap with the values "2,4"
+    And an Iterable with the values "5,1,3"
+    When ToHeap is called
+    And FromHeap is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+
+  Scenario: ToHeap returns the error of the Iterable
+    Given a heap with the values "2,4"
+    And an Iterable in an error state
+    When ToHeap is called
+    Then an error is returned
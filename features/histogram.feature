@@ -0,0 +1,23 @@
+Feature: Histogram counts the values of an Iterable into buckets
+
+  Scenario: The values are counted into buckets with inclusive upper bounds
+    Given an Iterable with the values "1,5,6,10,11,50,120"
+    When Histogram is called with the buckets "5,10,100"
+    Then no error is returned
+    And the returned counts are "2,2,2,1"
+
+  Scenario: Without buckets every value is counted in the overflow bucket
+    Given an Iterable with the values "1,2,3"
+    When Histogram is called without buckets
+    Then no error is returned
+    And the returned counts are "3"
+
+  Scenario: Buckets that are not in ascending order are rejected
+    Given an Iterable with the values "1,2,3"
+    When Histogram is called with the buckets "10,5"
+    Then an error is returned
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When Histogram is called with the buckets "5,10"
+    Then an error is returned
@@ -0,0 +1,18 @@
+Feature: IDs returns an infinite iterator of identifiers
+
+  Scenario: The monotonic generator returns sorted identifiers with a prefix
+    Given IDs is called with a monotonic generator with prefix "user-"
+    When Take is called on the identifiers with 3
+    Then calling Next() until false is returned should return the following strings:
+      | user-00000000000000000001 |
+      | user-00000000000000000002 |
+      | user-00000000000000000003 |
+
+  Scenario: The ULID generator returns sorted unique identifiers of 26 characters
+    Given IDs is called with a ULID generator
+    When Take is called on the identifiers with 1000
+    Then the identifiers are unique, sorted and 26 characters long
+
+  Scenario: The ULID generator stops with an error when the random bits overflow
+    Given IDs is called with a ULID generator whose random bits are all set
+    Then the identifiers stop with ErrULIDOverflow
@@ -0,0 +1,36 @@
+Feature: IsSorted checks that the values of an Iterable are sorted
+
+  Scenario Outline: IsSorted returns <result> for <values>
+    Given an Iterable with the values "<values>"
+    When IsSorted is called
+    Then no error is returned
+    And the returned answer is <result>
+
+    Examples:
+      | values  | result |
+      | 1,2,2,5 | true   |
+      | 1       | true   |
+      | 1,3,2,4 | false  |
+
+  Scenario: IsSorted of an empty Iterable is true
+    Given an empty Iterable
+    When IsSorted is called
+    Then the returned answer is true
+
+  Scenario: IsSorted stops at the first violation
+    Given an Iterable with the values "1,3,2,4,5"
+    When IsSorted is called
+    Then calling Next() until false is returned should return the following integers:
+      | 4 |
+      | 5 |
+
+  Scenario: IsSorted returns the error of the Iterable
+    Given an Iterable in an error state
+    When IsSorted is called
+    Then an error is returned
+
+  Scenario: IsSorted does not call Next again after the Iterable is exhausted
+    Given an Iterable with the values "" that panics when Next is called after it returned false
+    When IsSorted is called
+    Then no error is returned
+    And the returned answer is true
@@ -0,0 +1,17 @@
+Feature: JoinString concatenates an Iterable of strings with a separator
+
+  Scenario: The values are joined with the separator
+    Given an Iterable of strings "a,b,c"
+    When JoinString is called with the separator " - "
+    Then no error is returned
+    And the returned string is "a - b - c"
+
+  Scenario: A single value is returned without separator
+    Given an Iterable of strings "a"
+    When JoinString is called with the separator ", "
+    Then the returned string is "a"
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable of strings in an error state
+    When JoinString is called with the separator ", "
+    Then an error is returned
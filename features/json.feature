@@ -0,0 +1,38 @@
+Feature: FromJSONArray and FromJSONLines decode JSON values one at a time
+
+  Scenario: The elements of a JSON array are decoded
+    Given a reader with the contents "[1, 2, 3]"
+    When FromJSONArray is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: An empty JSON array returns no values
+    Given a reader with the contents "[]"
+    When FromJSONArray is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns nil
+
+  Scenario Outline: Invalid JSON array <contents> is an error after <count> values
+    Given a reader with the contents "<contents>"
+    When FromJSONArray is called
+    Then Next() returns true <count> times and then returns false
+    And Error() of int iterator returns an error
+
+    Examples:
+      | contents  | count |
+      | {}        | 0     |
+      | [1, true] | 1     |
+      | [1, 2     | 2     |
+      |           | 0     |
+
+  Scenario: The values of JSON lines are decoded
+    Given a reader with the contents "1\n2\n\n3\n"
+    When FromJSONLines is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
@@ -0,0 +1,30 @@
+Feature: Lines iterates the lines of an io.Reader
+
+  Scenario: The lines are returned without line endings
+    Given a reader with the contents "alpha\r\nbeta\ngamma"
+    When Lines is called
+    Then calling Next() until false is returned should return the following strings:
+      | alpha |
+      | beta  |
+      | gamma |
+    And Error() of string iterator returns nil
+
+  Scenario: A custom split function is used
+    Given a reader with the contents "one two  three"
+    When Lines is called with a split function for words
+    Then calling Next() until false is returned should return the following strings:
+      | one   |
+      | two   |
+      | three |
+
+  Scenario: A line longer than the maximum token size is an error
+    Given a reader with the contents "short\nmuch too long\n"
+    When Lines is called with a maximum token size of 8
+    Then Next() of string iterator returns true 1 times and then returns false
+    And Error() of string iterator returns an error
+
+  Scenario: A read error is returned
+    Given a reader with the contents "alpha\n" that fails afterwards
+    When Lines is called
+    Then Next() of string iterator returns true 1 times and then returns false
+    And Error() of string iterator returns an error
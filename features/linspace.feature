@@ -0,0 +1,60 @@
+Feature: Linspace and FloatStepSequence generate floating point ranges
+
+  Scenario: Linspace returns n evenly spaced values including both endpoints
+    When Linspace is called with 0, 1 and 5
+    Then calling Next() until false is returned should return the following floats:
+      | 0    |
+      | 0.25 |
+      | 0.5  |
+      | 0.75 |
+      | 1    |
+
+  Scenario: Linspace returns decreasing values when start is larger than end
+    When Linspace is called with 1, -1 and 3
+    Then calling Next() until false is returned should return the following floats:
+      | 1  |
+      | 0  |
+      | -1 |
+
+  Scenario: Linspace returns exactly end as the last value
+    When Linspace is called with 0, 0.3 and 4
+    Then the last float value is 0.3 after 4 values
+
+  Scenario: Linspace returns only start when n is 1
+    When Linspace is called with 2, 5 and 1
+    Then calling Next() until false is returned should return the following floats:
+      | 2 |
+
+  Scenario: Linspace returns no values when n is 0
+    When Linspace is called with 2, 5 and 0
+    Then no values are received from the float iterator
+
+  Scenario: FloatStepSequence includes end despite rounding errors
+    When FloatStepSequence is called with 0, 1 and 0.1
+    Then calling Next() until false is returned should return approximately the following floats:
+      | 0   |
+      | 0.1 |
+      | 0.2 |
+      | 0.3 |
+      | 0.4 |
+      | 0.5 |
+      | 0.6 |
+      | 0.7 |
+      | 0.8 |
+      | 0.9 |
+      | 1   |
+
+  Scenario: FloatStepSequence does not pass end
+    When FloatStepSequence is called with 0, 1 and 0.3
+    Then calling Next() until false is returned should return approximately the following floats:
+      | 0   |
+      | 0.3 |
+      | 0.6 |
+      | 0.9 |
+
+  Scenario: FloatStepSequence corrects the sign of step
+    When FloatStepSequence is called with 1, 0 and 0.5
+    Then calling Next() until false is returned should return the following floats:
+      | 1   |
+      | 0.5 |
+      | 0   |
@@ -0,0 +1,23 @@
+Feature: MapErr performs a map operation that can fail
+
+  Scenario: All values are mapped when the closure does not fail
+    Given an Iterable of strings "1,2,3"
+    When MapErr is called with strconv.Atoi
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: The iteration stops at the error of the closure
+    Given an Iterable of strings "1,x,3"
+    When MapErr is called with strconv.Atoi
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns an error
+    And the remaining strings are "3"
+
+  Scenario: MapErrIterator handles errors in source iterator
+    Given an Iterable of strings in an error state
+    When MapErr is called with strconv.Atoi
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
@@ -0,0 +1,41 @@
+Feature: Fibonacci and Primes generate infinite mathematical sequences
+
+  Scenario: Fibonacci generates the Fibonacci numbers
+    When Fibonacci is called
+    And Take is called with 10
+    Then calling Next() until false is returned should return the following integers:
+      | 0  |
+      | 1  |
+      | 1  |
+      | 2  |
+      | 3  |
+      | 5  |
+      | 8  |
+      | 13 |
+      | 21 |
+      | 34 |
+
+  Scenario: Fibonacci stops before the numbers overflow an uint64
+    When Fibonacci is called
+    Then the last generated Fibonacci number is 12200160415121876738 after 94 numbers
+
+  Scenario: Primes generates the prime numbers
+    When Primes is called
+    And Take is called with 10
+    Then calling Next() until false is returned should return the following integers:
+      | 2  |
+      | 3  |
+      | 5  |
+      | 7  |
+      | 11 |
+      | 13 |
+      | 17 |
+      | 19 |
+      | 23 |
+      | 29 |
+
+  Scenario: Primes can be limited with TakeWhile
+    When Primes is called
+    And TakeWhile is called with a predicate that selects values below 1000
+    Then Next() returns true 168 times and then returns false
+    And Error() of int iterator returns nil
@@ -0,0 +1,32 @@
+Feature: MinBy and MaxBy return the value with the smallest and largest key
+
+  Scenario: MinBy returns the value with the smallest key
+    Given an Iterable with the following values:
+      | 12 |
+      | 21 |
+      | 30 |
+      | 43 |
+    When MinBy is called with the last digit as key
+    Then no error is returned
+    And the value 30 is found
+
+  Scenario: MaxBy returns the first value with the largest key
+    Given an Iterable with the following values:
+      | 12 |
+      | 23 |
+      | 33 |
+      | 20 |
+    When MaxBy is called with the last digit as key
+    Then no error is returned
+    And the value 23 is found
+
+  Scenario: MinBy of an empty Iterable finds no value
+    Given an empty Iterable
+    When MinBy is called with the last digit as key
+    Then no error is returned
+    And no value is found
+
+  Scenario: MaxBy returns the error of the Iterable
+    Given an Iterable in an error state
+    When MaxBy is called with the last digit as key
+    Then an error is returned
@@ -0,0 +1,80 @@
+Feature: Min and Max return the smallest and largest value of an Iterable
+
+  Scenario: Min returns the smallest value
+    Given an Iterable with the following values:
+      | 3 |
+      | -1 |
+      | 7 |
+      | 0 |
+    When Min is called
+    Then no error is returned
+    And the value -1 is found
+
+  Scenario: Max returns the largest value
+    Given an Iterable with the following values:
+      | 3 |
+      | -1 |
+      | 7 |
+      | 0 |
+    When Max is called
+    Then no error is returned
+    And the value 7 is found
+
+  Scenario: Min of an empty Iterable finds no value
+    Given an empty Iterable
+    When Min is called
+    Then no error is returned
+    And no value is found
+
+  Scenario: Max of an empty Iterable finds no value
+    Given an empty Iterable
+    When Max is called
+    Then no error is returned
+    And no value is found
+
+  Scenario: Min returns the error of the Iterable
+    Given an Iterable in an error state
+    When Min is called
+    Then an error is returned
+
+  Scenario Outline: MinMax returns the smallest and largest value of <values>
+    Given an Iterable with the values "<values>"
+    When MinMax is called
+    Then no error is returned
+    And the returned range is <min> to <max>
+
+    Examples:
+      | values        | min | max |
+      | 5             | 5   | 5   |
+      | 3,-1,7,0      | -1  | 7   |
+      | 3,-1,7,0,-4   | -4  | 7   |
+      | 1,2,3,4,5,9,0 | 0   | 9   |
+
+  Scenario: MinMax of an empty Iterable finds no value
+    Given an empty Iterable
+    When MinMax is called
+    Then no error is returned
+    And no value is found
+
+  Scenario: MinMax returns the error of the Iterable
+    Given an Iterable in an error state
+    When MinMax is called
+    Then an error is returned
+
+  Scenario: Min does not call Next again after the Iterable is exhausted
+    Given an Iterable with the values "" that panics when Next is called after it returned false
+    When Min is called
+    Then no error is returned
+    And no value is found
+
+  Scenario: Max does not call Next again after the Iterable is exhausted
+    Given an Iterable with the values "" that panics when Next is called after it returned false
+    When Max is called
+    Then no error is returned
+    And no value is found
+
+  Scenario: Max of a single value does not call Next again after the Iterable is exhausted
+    Given an Iterable with the values "4" that panics when Next is called after it returned false
+    When Max is called
+    Then no error is returned
+    And the value 4 is found
@@ -0,0 +1,30 @@
+Feature: MovingAggregate returns a rolling aggregate over a window of items from the iteration
+
+  Scenario: A moving sum over a window of 3 values is returned for each value
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+    When MovingAggregate is called with a sum over a window of 3
+    Then calling Next() until false is returned should return the following integers:
+      | 1  |
+      | 3  |
+      | 6  |
+      | 9  |
+      | 12 |
+
+  Scenario: A window of 1 returns the values themselves
+    Given an Iterable with the following values:
+      | 4 |
+      | 8 |
+    When MovingAggregate is called with a sum over a window of 1
+    Then calling Next() until false is returned should return the following integers:
+      | 4 |
+      | 8 |
+
+  Scenario: MovingAggregateIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When MovingAggregate is called with a sum over a window of 3
+    Then Error() of int iterator returns an error
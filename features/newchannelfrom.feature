@@ -0,0 +1,16 @@
+Feature: NewChannelFrom sends the values of an Iterable to a channel from a goroutine
+
+  Scenario: All values are received and no error is delivered
+    Given an Iterable with the values "1,2,3"
+    When NewChannelFrom is called with a buffer of 2
+    Then the following values are received from the value channel:
+      | 1 |
+      | 2 |
+      | 3 |
+    And the error channel delivers no error
+
+  Scenario: The error of the Iterable is delivered on the error channel
+    Given an Iterable in an error state
+    When NewChannelFrom is called with a buffer of 0
+    Then no values are received from the value channel
+    And the error channel delivers an error
@@ -0,0 +1,29 @@
+Feature: NormalizeMinMax scales items from the iteration to the range 0 to 1
+
+  Scenario: An Iterable with int 2,4,6,10 items is scaled to 0, 0.25, 0.5 and 1
+    Given an Iterable with the following values:
+      | 2  |
+      | 4  |
+      | 6  |
+      | 10 |
+    When NormalizeMinMax is called
+    Then no error is returned
+    And calling Next() until false is returned should return the following floats:
+      | 0    |
+      | 0.25 |
+      | 0.5  |
+      | 1    |
+
+  Scenario: Equal values are scaled to 0
+    Given an Iterable with the following values:
+      | 3 |
+      | 3 |
+    When NormalizeMinMax is called
+    Then calling Next() until false is returned should return the following floats:
+      | 0 |
+      | 0 |
+
+  Scenario: NormalizeMinMax handles errors in source iterator
+    Given an Iterable in an error state
+    When NormalizeMinMax is called
+    Then an error is returned
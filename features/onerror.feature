@@ -0,0 +1,41 @@
+Feature: OnError calls a handler for the errors of an Iterable, which can skip past the failure or stop the iteration
+
+  Scenario: Values that can not be parsed are skipped
+    Given an Iterable of strings "1,x,3,y,5"
+    When MapErr is called with strconv.Atoi
+    And OnError is called with a handler that resumes
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 3 |
+      | 5 |
+    And Error() of int iterator returns nil
+    And the handler received errors for the elements "1,3"
+
+  Scenario: The iteration stops when the handler returns false
+    Given an Iterable of strings "1,x,3"
+    When MapErr is called with strconv.Atoi
+    And OnError is called with a handler that stops
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns an error
+    And the handler received errors for the elements "1"
+    And the remaining strings are "3"
+
+  Scenario: Failures are skipped through Map and Filter
+    Given an Iterable of strings "1,x,2,3,y,4"
+    When MapErr is called with strconv.Atoi
+    And Map is called with a function that doubles the values
+    And Filter is called with a predicate that selects values below 7
+    And OnError is called with a handler that resumes
+    Then calling Next() until false is returned should return the following integers:
+      | 2 |
+      | 4 |
+      | 6 |
+    And Error() of int iterator returns nil
+    And the handler received errors for the elements "1,4"
+
+  Scenario: A source that can not be resumed stops at its first error
+    Given an Iterable with the values "1,2" that fails afterwards
+    When OnError is called with a handler that resumes
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns "connection lost"
+    And the function was called 1 times
@@ -0,0 +1,36 @@
+Feature: PadTo appends a fill value until the iteration has a minimum length
+
+  Scenario: An Iterable with int 1 & 2 items is padded to 4 values with 0
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+    When PadTo is called with length 4 and fill value 0
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 0 |
+      | 0 |
+
+  Scenario: An Iterable with more values than length is not truncated by PadTo
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When PadTo is called with length 2 and fill value 0
+    Then Next() returns true 3 times and then returns false
+
+  Scenario: An Iterable with more values than length is truncated by PadOrTruncate
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When PadOrTruncate is called with length 2 and fill value 0
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+
+  Scenario: PadIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When PadTo is called with length 2 and fill value 0
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
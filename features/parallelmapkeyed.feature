@@ -0,0 +1,51 @@
+Feature: ParallelMapKeyed maps items concurrently with a limit per key
+
+  Scenario: Values are mapped concurrently without exceeding the limits
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+      | 6 |
+      | 7 |
+      | 8 |
+    When ParallelMapKeyed is called keyed by odd and even with 1 per key and 4 in total
+    Then the following integers are returned in any order:
+      | 2  |
+      | 4  |
+      | 6  |
+      | 8  |
+      | 10 |
+      | 12 |
+      | 14 |
+      | 16 |
+    And at most 1 operations per key and 2 operations in total ran concurrently
+
+  Scenario: Values are mapped concurrently up to the total limit
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+      | 5 |
+      | 6 |
+    When ParallelMapKeyed is called keyed by odd and even with 3 per key and 3 in total
+    Then Next() returns true 6 times and then returns false
+    And at most 3 operations per key and 3 operations in total ran concurrently
+
+  Scenario: The iteration stops at the first error of the mapping closure
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | -1 |
+      | 4 |
+    When ParallelMapKeyed is called keyed by odd and even with 1 per key and 1 in total
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns an error
+
+  Scenario: ParallelMapKeyedIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When ParallelMapKeyed is called keyed by odd and even with 1 per key and 1 in total
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
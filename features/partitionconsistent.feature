@@ -0,0 +1,42 @@
+Feature: PartitionConsistent annotates values with a consistent hash partition index
+
+  Scenario: Equal keys are assigned to the same partition within range
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 1 |
+      | 2 |
+      | 3 |
+    When PartitionConsistent is called with 4 partitions
+    Then every value is assigned to the same partition as equal values, within 4 partitions
+
+  Scenario: All values are assigned to partition 0 when there is a single partition
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When PartitionConsistent is called with 1 partitions
+    Then every value is assigned to the same partition as equal values, within 1 partitions
+
+  Scenario: PartitionConsistent handles errors in source iterator
+    Given an Iterable in an error state
+    When PartitionConsistent is called with 4 partitions
+    Then Error() of partitioned iterator returns an error
+
+  Scenario: FanOutConsistent sends each value to the channel of its partition
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 1 |
+    When FanOutConsistent is called with 3 channels
+    Then 4 values are received on the channels and equal values are received on the same channel
+
+  Scenario: FanOutConsistent returns an error without channels
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+    When FanOutConsistent is called without channels
+    Then ErrNoChannels is returned
+    And Next() returns true 2 times and then returns false
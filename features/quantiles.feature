@@ -0,0 +1,35 @@
+Feature: Quantiles returns quantiles of an Iterable of numbers
+
+  Scenario: Quantiles returns exact interpolated quantiles
+    Given an Iterable with the values "1,2,3,4,5,6,7,8,9,10"
+    When Quantiles is called with "0,0.25,0.5,0.9,1"
+    Then no error is returned
+    And the returned quantiles are "1,3.25,5.5,9.1,10"
+
+  Scenario: QuantilesApprox is exact for less than five values
+    Given an Iterable with the values "4,1,3,2"
+    When QuantilesApprox is called with "0,0.5,1"
+    Then no error is returned
+    And the returned quantiles are "1,2.5,4"
+
+  Scenario: QuantilesApprox estimates the quantiles of a large stream
+    Given an Iterable with the values 1 to 10000 in a shuffled order
+    When QuantilesApprox is called with "0.5,0.9,0.99"
+    Then no error is returned
+    And the returned quantiles are within 1 percent of "5000.5,9000.1,9900.01"
+
+  Scenario: Quantiles of an empty Iterable are NaN
+    Given an empty Iterable
+    When Quantiles is called with "0.5"
+    Then no error is returned
+    And the returned quantiles are "NaN"
+
+  Scenario: A quantile outside of the range 0 to 1 is rejected
+    Given an Iterable with the values "1,2,3"
+    When Quantiles is called with "1.5"
+    Then an error is returned
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When QuantilesApprox is called with "0.5"
+    Then an error is returned
@@ -0,0 +1,22 @@
+Feature: Random generates uniformly distributed random numbers
+
+  Scenario: RandomN generates n values within the half-open range
+    When RandomN is called with the seed 1, the range 10 to 20 and 1000 values
+    Then all 1000 generated values are within the range 10 to 20
+    And all values within the range 10 to 20 are generated
+
+  Scenario: Random generates the same values for the same seed
+    When RandomN is called with the seed 42, the range -1000 to 1000 and 10 values
+    Then the values are equal to the values of RandomN called with the seed 42
+
+  Scenario: Random generates different values for different seeds
+    When RandomN is called with the seed 42, the range -1000 to 1000 and 10 values
+    Then the values differ from the values of RandomN called with the seed 43
+
+  Scenario: Random handles a range that overflows a signed type
+    When RandomN is called with the seed 7 for int8 values from -128 to 127 and 10000 values
+    Then all int8 values from -128 to 126 are generated
+
+  Scenario: Random generates floating point values within the half-open range
+    When RandomN is called with the seed 3 for float64 values from -1 to 1 and 1000 values
+    Then all float64 values are at least -1 and less than 1
@@ -0,0 +1,32 @@
+Feature: Rank returns sorted items from the iteration annotated with their rank
+
+  Scenario: Equal values share a rank and leave a gap
+    Given an Iterable with the following values:
+      | 30 |
+      | 10 |
+      | 20 |
+      | 10 |
+    When Rank is called
+    Then calling Next() until false is returned should return the following ranked values:
+      | 1 | 10 |
+      | 1 | 10 |
+      | 3 | 20 |
+      | 4 | 30 |
+
+  Scenario: Equal values share a dense rank without a gap
+    Given an Iterable with the following values:
+      | 30 |
+      | 10 |
+      | 20 |
+      | 10 |
+    When DenseRank is called
+    Then calling Next() until false is returned should return the following ranked values:
+      | 1 | 10 |
+      | 1 | 10 |
+      | 2 | 20 |
+      | 3 | 30 |
+
+  Scenario: RankIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When Rank is called
+    Then Next() returns no ranked value and Error() of ranked iterator returns an error
@@ -0,0 +1,22 @@
+Feature: ReduceWhile reduces an Iterable until the reducer stops it
+
+  Scenario: The reduction stops when the reducer returns false
+    Given an Iterable with the values "1,2,3,4,5,6"
+    When ReduceWhile is called with a reducer that sums until the sum exceeds 5
+    Then no error is returned
+    And The returned sum is 6
+    And calling Next() until false is returned should return the following integers:
+      | 4 |
+      | 5 |
+      | 6 |
+
+  Scenario: All values are reduced when the reducer does not stop
+    Given an Iterable with the values "1,2"
+    When ReduceWhile is called with a reducer that sums until the sum exceeds 5
+    Then no error is returned
+    And The returned sum is 3
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When ReduceWhile is called with a reducer that sums until the sum exceeds 5
+    Then an error is returned
@@ -0,0 +1,22 @@
+Feature: Registry builds pipelines from named factories
+
+  Scenario: A pipeline is built and run from a specification
+    Given a registry with a sequence source, a filter stage and a sum sink
+    When the pipeline "sequence(1..10) | filter(odd) | sum" is run
+    Then no error is returned
+    And The returned sum is 25
+
+  Scenario: A pipeline with an unknown stage is rejected
+    Given a registry with a sequence source, a filter stage and a sum sink
+    When the pipeline "sequence(1..10) | unknown | sum" is run
+    Then ErrUnknownName is returned
+
+  Scenario: A pipeline with parameters of the wrong type is rejected
+    Given a registry with a sequence source, a filter stage and a sum sink
+    When the pipeline "sequence(wrong) | filter(odd) | sum" is run
+    Then an error is returned
+
+  Scenario: A factory can not be registered twice
+    Given a registry with a sequence source, a filter stage and a sum sink
+    When the sequence source is registered again
+    Then ErrDuplicateName is returned
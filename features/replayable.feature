@@ -0,0 +1,29 @@
+Feature: Replayable records values so they can be iterated again
+
+  Scenario: Restart replays all values after the iteration is exhausted
+    Given an Iterable with the values "1,2,3"
+    When Replayable is called
+    And Next() returns true 3 times and then returns false
+    And the replayable iterator is restarted
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And the source Iterable was consumed once
+
+  Scenario: Restart replays the recorded values and continues with the source
+    Given an Iterable with the values "1,2,3"
+    When Replayable is called
+    And Take is called on the replayable iterator with 2
+    And the replayable iterator is restarted
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And the source Iterable was consumed once
+
+  Scenario: The error of the source is returned
+    Given an Iterable in an error state
+    When Replayable is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
@@ -0,0 +1,27 @@
+Feature: RoundRobin interleaves the items of multiple iterations
+
+  Scenario: Values of Iterables with different lengths are interleaved
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And a second Iterable with the following values:
+      | 10 |
+    When RoundRobin is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1  |
+      | 10 |
+      | 2  |
+      | 3  |
+    And Error() of int iterator returns nil
+
+  Scenario: RoundRobinIterator continues with the other Iterables when an Iterable fails
+    Given an Iterable in an error state
+    And a second Iterable with the following values:
+      | 10 |
+      | 20 |
+    When RoundRobin is called
+    Then calling Next() until false is returned should return the following integers:
+      | 10 |
+      | 20 |
+    And Error() of int iterator returns an error
@@ -0,0 +1,26 @@
+Feature: Safe recovers panics raised in the closures of a pipeline and converts them into an error
+
+  Scenario: The values are returned when no panic is raised
+    Given an Iterable with the values "1,2,3"
+    When Map is called with a function that panics on 0
+    And Safe is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: A panic in a closure stops the iteration with a PanicError
+    Given an Iterable with the values "1,0,3"
+    When Map is called with a function that panics on 0
+    And Safe is called
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns "iterator: panic: bad value 0"
+    And the unwrapped error of the int iterator is "bad value 0"
+    And the stack trace of the PanicError contains "mapIsCalledWithAFunctionThatPanicsOn"
+
+  Scenario: The error of the source Iterable is returned
+    Given an Iterable with the values "1,2" that fails afterwards
+    When Safe is called
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns "connection lost"
@@ -0,0 +1,25 @@
+Feature: Sample forwards each item from the iteration with a given probability
+
+  Scenario: A probability of 1 forwards all values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When Sample is called with a probability of 1
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+
+  Scenario: A probability of 0 forwards no values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When Sample is called with a probability of 0
+    Then Next() returns true 0 times and then returns false
+
+  Scenario: Sample handles errors in source iterator
+    Given an Iterable in an error state
+    When Sample is called with a probability of 1
+    Then Error() of int iterator returns an error
@@ -0,0 +1,31 @@
+Feature: SampleReservoir returns a uniform random sample of k values from an Iterable
+
+  Scenario: A sample of 3 values is taken from an Iterable with 10 values
+    Given an Iterable with the following values:
+      | 1  |
+      | 2  |
+      | 3  |
+      | 4  |
+      | 5  |
+      | 6  |
+      | 7  |
+      | 8  |
+      | 9  |
+      | 10 |
+    When SampleReservoir is called with k of 3
+    Then the returned slice contains 3 distinct values between 1 and 10
+    And no error is returned
+
+  Scenario: All values are returned when the Iterable returns fewer than k values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+    When SampleReservoir is called with k of 3
+    Then a slice is returned with the following values:
+      | 1 |
+      | 2 |
+
+  Scenario: SampleReservoir handles errors in source iterator
+    Given an Iterable in an error state
+    When SampleReservoir is called with k of 3
+    Then an error is returned
@@ -0,0 +1,23 @@
+Feature: SequenceCtx and GenerateCtx stop generating when the context is cancelled
+
+  Scenario: SequenceCtx returns all values with an active context
+    Given an active context
+    When SequenceCtx is called with 1 and 3
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: SequenceCtx stops when the context is cancelled
+    Given a cancellable context
+    When SequenceCtx is called with 1 and 1000000000000
+    And 2 values are taken and the context is cancelled
+    Then Next() returns true 0 times and then returns false
+    And the error of the context is returned
+
+  Scenario: GenerateCtx stops when the context is cancelled
+    Given a cancelled context
+    When GenerateCtx is called with a repeat count of 10
+    Then Next() returns true 0 times and then returns false
+    And the error of the context is returned
@@ -0,0 +1,23 @@
+Feature: SequenceFrom counts upward without an end
+
+  Scenario: SequenceFrom counts upward from the start value
+    When SequenceFrom is called with 5
+    And Take is called with 3
+    Then calling Next() until false is returned should return the following integers:
+      | 5 |
+      | 6 |
+      | 7 |
+
+  Scenario: SequenceFrom counts upward from a negative start value
+    When SequenceFrom is called with -1
+    And Take is called with 3
+    Then calling Next() until false is returned should return the following integers:
+      | -1 |
+      | 0  |
+      | 1  |
+
+  Scenario: SequenceFrom numbers the values of a stream
+    When SequenceFrom is called with 1
+    And TakeWhile is called with a predicate that selects values below 1001
+    Then Next() returns true 1000 times and then returns false
+    And Error() of int iterator returns nil
@@ -0,0 +1,72 @@
+Feature: Set collects the distinct values of an Iterable
+
+  Scenario: A Set contains the distinct values of an Iterable
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 2 |
+      | 3 |
+    When SetFromIterator is called
+    Then no error is returned
+    And the set contains 3 values
+    And the set contains 2
+    And the set does not contain 4
+
+  Scenario: SetFromIterator returns the error of the Iterable
+    Given an Iterable in an error state
+    When SetFromIterator is called
+    Then an error is returned
+
+  Scenario: Union of two Sets
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And a second Iterable with the following values:
+      | 2 |
+      | 3 |
+      | 4 |
+    When both Iterables are collected into Sets and combined with Union
+    And Iter is called on the Set
+    Then the following integers are returned in any order:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+
+  Scenario: Intersect of two Sets
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And a second Iterable with the following values:
+      | 2 |
+      | 3 |
+      | 4 |
+    When both Iterables are collected into Sets and combined with Intersect
+    And Iter is called on the Set
+    Then the following integers are returned in any order:
+      | 2 |
+      | 3 |
+
+  Scenario: Diff of two Sets
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And a second Iterable with the following values:
+      | 2 |
+      | 3 |
+      | 4 |
+    When both Iterables are collected into Sets and combined with Diff
+    And Iter is called on the Set
+    Then the following integers are returned in any order:
+      | 1 |
+
+  Scenario: ToSet collects the distinct values of an Iterable
+    Given an Iterable with the values "3,1,3,2,1"
+    When ToSet is called
+    Then no error is returned
+    And the set contains 3 values
+    And the set contains 1
+    And the set does not contain 4
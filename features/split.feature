@@ -0,0 +1,52 @@
+Feature: Split groups items from the iteration into slices separated by delimiters
+
+  Scenario: An Iterable with int 1,2,0,3,0,0,4 items is split on 0 into 4 groups
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 0 |
+      | 3 |
+      | 0 |
+      | 0 |
+      | 4 |
+    And a predicate that only selects zero
+    When Split is called
+    Then calling Next() until false is returned should return the following groups:
+      | 1,2 |
+      | 3   |
+      |     |
+      | 4   |
+
+  Scenario: A delimiter at the end of the iteration results in a trailing empty group
+    Given an Iterable with the following values:
+      | 1 |
+      | 0 |
+    And a predicate that only selects zero
+    When Split is called
+    Then calling Next() until false is returned should return the following groups:
+      | 1 |
+      |   |
+
+  Scenario: SplitIterator handles errors in source iterator
+    Given an Iterable in an error state
+    And a predicate that only selects zero
+    When Split is called
+    Then Next() of group iterator returns false
+    And Error() of group iterator returns an error
+
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And a predicate that only selects zero
+    When Split is called
+    Then calling Next() until false is returned should return the following groups:
+      | 1,2,3 |
+    And Error() of group iterator returns nil
+
+  Scenario: An empty Iterable results in no groups
+    Given an empty Iterable
+    And a predicate that only selects zero
+    When Split is called
+    Then Next() of group iterator returns false
+    And Error() of group iterator returns nil
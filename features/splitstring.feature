@@ -0,0 +1,31 @@
+Feature: SplitString and FieldsString lazily split strings
+
+  Scenario: SplitString returns the substrings between the separators
+    When SplitString is called on "a,b,,c" with the separator ","
+    Then the substrings are "a/b//c"
+
+  Scenario Outline: SplitString returns the same substrings as strings.Split for <text> and <separator>
+    When SplitString is called on "<text>" with the separator "<separator>"
+    Then the substrings are the same as the substrings of strings.Split
+
+    Examples:
+      | text     | separator |
+      | a,b,c    | ,         |
+      | ,a,      | ,         |
+      |          | ,         |
+      | abc      | ,         |
+      | a--b---c | --        |
+      | héllo    |           |
+      |          |           |
+
+  Scenario: FieldsString returns the fields between whitespace
+    When FieldsString is called on "  alpha beta\t\tgamma\n delta  "
+    Then the substrings are "alpha/beta/gamma/delta"
+
+  Scenario: FieldsString returns no fields for whitespace only
+    When FieldsString is called on " \t\n "
+    Then the substrings are ""
+
+  Scenario: SplitString keeps invalid UTF-8 bytes with an empty separator
+    When SplitString is called on the escaped string `a\xffb` with an empty separator
+    Then the substrings formatted with %q are `["a" "\xff" "b"]`
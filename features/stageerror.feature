@@ -0,0 +1,52 @@
+Feature: Errors of the Map and Filter stages contain the stage and element index
+
+  Scenario: Map wraps the error of the source with the index of the element
+    Given an Iterable with the values "1,2,3" that fails afterwards
+    When Map is called with a function that doubles the values
+    Then Next() returns true 3 times and then returns false
+    And Error() of int iterator returns "iterator: map stage, element 3: connection lost"
+    And the unwrapped error of the int iterator is "connection lost"
+
+  Scenario: Filter wraps the error of the source with the index of the element
+    Given an Iterable with the values "1,2,3,4" that fails afterwards
+    And a predicate that only selects even numbers
+    When Filter is called
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns "iterator: filter stage, element 4: connection lost"
+
+  Scenario: Nested stages each add their own context
+    Given an Iterable with the values "1,2,3" that fails afterwards
+    When Map is called with a function that doubles the values
+    And Filter is called with a predicate that selects values below 5
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns "iterator: filter stage, element 3: iterator: map stage, element 3: connection lost"
+
+  Scenario: MapErr wraps the error of the closure with the index of the element
+    Given an Iterable of strings "1,2,x,4"
+    When MapErr is called with strconv.Atoi
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns a StageError for the map stage and element 2
+
+  Scenario: FilterErr wraps the error of the closure with the index of the element
+    Given an Iterable with the values "2,3,0,4"
+    When FilterErr is called with a predicate that selects even numbers and fails on 0
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns a StageError for the filter stage and element 2
+
+  Scenario: No error is returned when the iteration completed successfully
+    Given an Iterable with the values "1,2,3"
+    When Map is called with a function that doubles the values
+    Then Next() returns true 3 times and then returns false
+    And Error() of int iterator returns nil
+
+  Scenario: Functions built on Filter use their own stage name
+    Given an Iterable with the values "1,2" that fails afterwards
+    When Sample is called with a probability of 1
+    Then Next() returns true 2 times and then returns false
+    And Error() of int iterator returns "iterator: sample stage, element 2: connection lost"
+
+  Scenario: Functions built on Map use their own stage name
+    Given an Iterable with the values "1,2" that fails afterwards
+    When MovingAverage is called with a window of 2 on the int iterator
+    Then Next() of float iterator returns true 2 times and then returns false
+    And Error() of float iterator returns "iterator: moving average stage, element 2: connection lost"
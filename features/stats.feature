@@ -0,0 +1,30 @@
+Feature: Stats summarizes an Iterable of numbers
+
+  Scenario: Stats returns the statistics of all values
+    Given an Iterable with the following values:
+      | 2 |
+      | 4 |
+      | 4 |
+      | 4 |
+      | 5 |
+      | 5 |
+      | 7 |
+      | 9 |
+    When Stats is called
+    Then no error is returned
+    And the following statistics are returned:
+      | count | sum | min | max | mean | variance | stddev |
+      | 8     | 40  | 2   | 9   | 5    | 4        | 2      |
+
+  Scenario: Stats of an empty Iterable returns zero statistics
+    Given an empty Iterable
+    When Stats is called
+    Then no error is returned
+    And the following statistics are returned:
+      | count | sum | min | max | mean | variance | stddev |
+      | 0     | 0   | 0   | 0   | 0    | 0        | 0      |
+
+  Scenario: Stats returns the error of the Iterable
+    Given an Iterable in an error state
+    When Stats is called
+    Then an error is returned
@@ -0,0 +1,36 @@
+Feature: Sum and Product aggregate an Iterable of numbers
+
+  Scenario: Sum adds all values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+    When Sum is called
+    Then no error is returned
+    And The returned sum is 10
+
+  Scenario: Sum of an empty Iterable is zero
+    Given an empty Iterable
+    When Sum is called
+    Then The returned sum is 0
+
+  Scenario: Product multiplies all values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+      | 4 |
+    When Product is called
+    Then no error is returned
+    And The returned sum is 24
+
+  Scenario: Product of an empty Iterable is one
+    Given an empty Iterable
+    When Product is called
+    Then The returned sum is 1
+
+  Scenario: Sum returns the error of the Iterable
+    Given an Iterable in an error state
+    When Sum is called
+    Then an error is returned
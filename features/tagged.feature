@@ -0,0 +1,29 @@
+Feature: Tag wraps values in an envelope with metadata that is kept through the pipeline
+
+  Scenario: Values are tagged with their index and the index is kept by MapTagged
+    Given an Iterable with the following values:
+      | 5 |
+      | 6 |
+      | 7 |
+    When Tag is called with the index as metadata
+    And MapTagged is called with a map function that doubles the values
+    Then calling Next() until false is returned should return the following tagged values:
+      | 10 | 0 |
+      | 12 | 1 |
+      | 14 | 2 |
+
+  Scenario: Untag returns the original values
+    Given an Iterable with the following values:
+      | 5 |
+      | 6 |
+    When Tag is called with the index as metadata
+    And Untag is called
+    Then calling Next() until false is returned should return the following integers:
+      | 5 |
+      | 6 |
+
+  Scenario: Tagged iterators handle errors in source iterator
+    Given an Iterable in an error state
+    When Tag is called with the index as metadata
+    And Untag is called
+    Then Error() of int iterator returns an error
@@ -0,0 +1,23 @@
+Feature: Take returns at most the first n items from the iteration
+
+  Scenario: An Iterable with int 1,2, & 3 items returns 1 and 2 when Take is called with 2
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When Take is called with 2
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+
+  Scenario: Take returns all values when the Iterable returns fewer than n values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+    When Take is called with 5
+    Then Next() returns true 2 times and then returns false
+
+  Scenario: TakeIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When Take is called with 2
+    Then Error() of int iterator returns an error
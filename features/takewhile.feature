@@ -0,0 +1,27 @@
+Feature: TakeWhile returns items from the iteration as long as they match a predicate
+
+  Scenario: TakeWhile stops at the first value that does not match
+    Given an Iterable with the values "2,4,5,6,8"
+    And a predicate that only selects even numbers
+    When TakeWhile is called
+    Then calling Next() until false is returned should return the following integers:
+      | 2 |
+      | 4 |
+
+  Scenario: TakeWhile returns all values when all values match
+    Given an Iterable with the values "2,4,6"
+    And a predicate that only selects even numbers
+    When TakeWhile is called
+    Then Next() returns true 3 times and then returns false
+
+  Scenario: TakeWhile does not pull more values after a value did not match
+    Given an Iterable with the values "1,2,3"
+    When TakeWhile is called with a predicate that selects values below 2
+    Then Next() returns true 1 times and then returns false
+    And the source Iterable returns the value 3 next
+
+  Scenario: TakeWhileIterator handles errors in source iterator
+    Given an Iterable in an error state
+    And a predicate that only selects even numbers
+    When TakeWhile is called
+    Then Error() of int iterator returns an error
@@ -0,0 +1,29 @@
+Feature: Throttle returns items from the iteration at most once per interval
+
+  Scenario: An Iterable with int 1,2, & 3 items takes at least 2 intervals to iterate
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When Throttle is called with an interval of 20 milliseconds
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And the iteration took at least 40 milliseconds
+
+  Scenario: A cancelled context interrupts a throttled Next
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+    And a context that is cancelled after 10 milliseconds
+    When Throttle is called with an interval of 1000 milliseconds
+    And WithContext is called
+    Then Next() returns true 1 times and then returns false
+    And Error() of int iterator returns an error
+    And the iteration took less than 500 milliseconds
+
+  Scenario: ThrottleIterator handles errors in source iterator
+    Given an Iterable in an error state
+    When Throttle is called with an interval of 20 milliseconds
+    Then Error() of int iterator returns an error
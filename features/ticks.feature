@@ -0,0 +1,31 @@
+Feature: Ticks returns the time of each tick of a ticker
+
+  Scenario: Ticks returns increasing times
+    When Ticks is called with 5 milliseconds
+    Then 3 ticks are returned in increasing order
+    And Error() of tick iterator returns nil
+
+  Scenario: Stop ends the iteration
+    When Ticks is called with 5 milliseconds
+    And the tick iterator is stopped
+    Then no tick is returned
+    And Error() of tick iterator returns nil
+
+  Scenario: Stop ends a Next call that is waiting for a tick
+    When Ticks is called with 1000 milliseconds
+    And the tick iterator is stopped after 10 milliseconds
+    Then no tick is returned
+
+  Scenario: WithContext interrupts waiting for a tick
+    Given a cancelled context
+    When Ticks is called with 1000 milliseconds
+    And WithContext is called on the tick iterator
+    Then no tick is returned
+    And Error() of tick iterator returns the error of the context
+
+  Scenario: The tick iterator stays stopped after NextCtx was interrupted
+    Given a cancelled context
+    When Ticks is called with 5 milliseconds
+    And NextCtx of the tick iterator is called with the context
+    Then no tick is returned
+    And Error() of tick iterator returns the error of the context
@@ -0,0 +1,44 @@
+Feature: ToJSONArray and ToJSONLines stream the values of an Iterable as JSON
+
+  Scenario: ToJSONArray writes a JSON array
+    Given an Iterable with the values "1,2,3"
+    When ToJSONArray is called
+    Then no error is returned
+    And the written output is "[1,2,3]"
+
+  Scenario: ToJSONArray writes an empty JSON array for an empty Iterable
+    Given an empty Iterable
+    When ToJSONArray is called
+    Then no error is returned
+    And the written output is "[]"
+
+  Scenario: ToJSONArray does not close the array when an error occurred
+    Given an Iterable with the values "1,2" that fails afterwards
+    When ToJSONArray is called
+    Then an error is returned
+    And the written output is "[1,2"
+
+  Scenario: ToJSONArray returns the error of the writer
+    Given an Iterable with the values "1,2,3"
+    When ToJSONArray is called with a failing writer
+    Then an error is returned
+
+  Scenario: ToJSONLines writes one JSON value per line
+    Given an Iterable with the values "1,2,3"
+    When ToJSONLines is called
+    Then no error is returned
+    And the written output is "1\n2\n3\n"
+
+  Scenario: ToJSONLines returns the error of the Iterable
+    Given an Iterable in an error state
+    When ToJSONLines is called
+    Then an error is returned
+
+  Scenario: The output of ToJSONLines can be read by FromJSONLines
+    Given an Iterable with the values "4,5,6"
+    When ToJSONLines is called
+    And FromJSONLines is called on the written output
+    Then calling Next() until false is returned should return the following integers:
+      | 4 |
+      | 5 |
+      | 6 |
@@ -0,0 +1,41 @@
+Feature: ToMap collects the values of an Iterable into a map
+
+  Scenario: The values are stored under their keys
+    Given an Iterable with the values "1,2,3"
+    When ToMap is called with the value as key and the square as value
+    Then no error is returned
+    And the returned map is "1:1,2:4,3:9"
+
+  Scenario: The last value is kept when keys collide
+    Given an Iterable with the values "12,23,32"
+    When ToMapKeyed is called with the last digit as key
+    Then no error is returned
+    And the returned map is "2:32,3:23"
+
+  Scenario: An empty Iterable results in an empty map
+    Given an empty Iterable
+    When ToMapKeyed is called with the last digit as key
+    Then no error is returned
+    And the returned map is ""
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When ToMapKeyed is called with the last digit as key
+    Then an error is returned
+
+  Scenario: ToMapMerge merges the values of colliding keys
+    Given an Iterable with the values "12,23,32,42"
+    When ToMapMerge is called with the last digit as key and the sum as merge
+    Then no error is returned
+    And the returned map is "2:86,3:23"
+
+  Scenario: ToMapMerge can keep the first value of colliding keys
+    Given an Iterable with the values "12,23,32"
+    When ToMapMerge is called with the last digit as key keeping the first value
+    Then no error is returned
+    And the returned map is "2:12,3:23"
+
+  Scenario: ToMapMerge returns the error of the Iterable
+    Given an Iterable in an error state
+    When ToMapMerge is called with the last digit as key and the sum as merge
+    Then an error is returned
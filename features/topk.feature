@@ -0,0 +1,45 @@
+Feature: TopK and BottomK return the k largest or smallest values of an Iterable
+
+  Scenario: TopK returns the 3 largest values from largest to smallest
+    Given an Iterable with the following values:
+      | 5 |
+      | 1 |
+      | 9 |
+      | 3 |
+      | 7 |
+      | 2 |
+    When TopK is called with k of 3
+    Then a slice is returned with the following values:
+      | 9 |
+      | 7 |
+      | 5 |
+    And no error is returned
+
+  Scenario: BottomK returns the 3 smallest values from smallest to largest
+    Given an Iterable with the following values:
+      | 5 |
+      | 1 |
+      | 9 |
+      | 3 |
+      | 7 |
+      | 2 |
+    When BottomK is called with k of 3
+    Then a slice is returned with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And no error is returned
+
+  Scenario: TopK returns all values when the Iterable returns less than k values
+    Given an Iterable with the following values:
+      | 2 |
+      | 1 |
+    When TopK is called with k of 3
+    Then a slice is returned with the following values:
+      | 2 |
+      | 1 |
+
+  Scenario: TopK handles errors in source iterator
+    Given an Iterable in an error state
+    When TopK is called with k of 3
+    Then an error is returned
@@ -0,0 +1,29 @@
+Feature: ToSliceMax renders an Iterable to a slice, but fails when the Iterable returns too many values
+
+  Scenario: An Iterable with int 1,2, & 3 items returns a slice with 1, 2 and 3 when the maximum is 3
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When ToSliceMax is called with a maximum of 3
+    Then a slice is returned with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And no error is returned
+
+  Scenario: An Iterable with int 1,2, & 3 items returns ErrTooMany when the maximum is 2
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    When ToSliceMax is called with a maximum of 2
+    Then a slice is returned with the following values:
+      | 1 |
+      | 2 |
+    And ErrTooMany is returned
+
+  Scenario: ToSliceMax handles errors in source iterator
+    Given an Iterable in an error state
+    When ToSliceMax is called with a maximum of 2
+    Then an error is returned
@@ -0,0 +1,25 @@
+Feature: ToSortedSlice renders an Iterable to a sorted slice
+
+  Scenario: ToSortedSlice sorts the values in ascending order
+    Given an Iterable with the values "3,1,2,-5"
+    When ToSortedSlice is called
+    Then no error is returned
+    And the resulting slice is "-5,1,2,3"
+
+  Scenario: ToSortedSlice returns an empty slice for an empty Iterable
+    Given an empty Iterable
+    When ToSortedSlice is called
+    Then no error is returned
+    And the resulting slice is ""
+
+  Scenario: ToSortedSliceFunc sorts stable with the LessFunc closure
+    Given an Iterable with the values "21,12,31,11,22"
+    When ToSortedSliceFunc is called with the last digit as order
+    Then no error is returned
+    And the resulting slice is "21,31,11,12,22"
+
+  Scenario: ToSortedSlice returns the error of the Iterable
+    Given an Iterable with the values "3,1" that fails afterwards
+    When ToSortedSlice is called
+    Then an error is returned
+    And the resulting slice is "3,1"
@@ -0,0 +1,20 @@
+Feature: TryFold reduces an Iterable with a reducer that can fail
+
+  Scenario: All values are reduced when the reducer does not fail
+    Given an Iterable with the values "1,2,3"
+    When TryFold is called with a reducer that sums and rejects negative values
+    Then no error is returned
+    And The returned sum is 6
+
+  Scenario: The reduction stops at the first error of the reducer
+    Given an Iterable with the values "1,2,-3,4"
+    When TryFold is called with a reducer that sums and rejects negative values
+    Then an error for the value at index 2 is returned
+    And The returned sum is 3
+    And calling Next() until false is returned should return the following integers:
+      | 4 |
+
+  Scenario: The error of the Iterable is returned
+    Given an Iterable in an error state
+    When TryFold is called with a reducer that sums and rejects negative values
+    Then an error is returned
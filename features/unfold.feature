@@ -0,0 +1,21 @@
+Feature: Unfold generates values by evolving a state
+
+  Scenario: Unfold returns values until the closure signals completion
+    When Unfold is called with the seed 1 and a closure that doubles the state below 20
+    Then calling Next() until false is returned should return the following integers:
+      | 1  |
+      | 2  |
+      | 4  |
+      | 8  |
+      | 16 |
+    And Error() of int iterator returns nil
+
+  Scenario: Unfold returns no values when the closure signals completion immediately
+    When Unfold is called with the seed 32 and a closure that doubles the state below 20
+    Then Next() returns true 0 times and then returns false
+
+  Scenario: Unfold does not call the closure after it signalled completion
+    When Unfold is called with the seed 8 and a closure that doubles the state below 20
+    Then Next() returns true 2 times and then returns false
+    And Next() returns true 0 times and then returns false
+    And the function was called 3 times
@@ -0,0 +1,30 @@
+Feature: WalkDir lazily walks a file tree
+
+  Scenario: WalkDir returns all files and directories in lexical order
+    Given a file system with the files "b.txt,a/y.txt,a/x/z.txt,c/d.txt"
+    When WalkDir is called with the root "."
+    Then the walked paths are ".,a,a/x,a/x/z.txt,a/y.txt,b.txt,c,c/d.txt"
+    And the walked paths are in the same order as fs.WalkDir
+    And Error() of walk iterator returns nil
+
+  Scenario: WalkDir walks a subdirectory
+    Given a file system with the files "b.txt,a/y.txt,a/x/z.txt"
+    When WalkDir is called with the root "a"
+    Then the walked paths are "a,a/x,a/x/z.txt,a/y.txt"
+
+  Scenario: WalkDir skips the directories that match the predicate
+    Given a file system with the files "b.txt,a/y.txt,a/x/z.txt,c/d.txt"
+    When WalkDir is called with the root "." skipping directories named "x"
+    Then the walked paths are ".,a,a/y.txt,b.txt,c,c/d.txt"
+
+  Scenario: WalkDir returns an error when the root does not exist
+    Given a file system with the files "b.txt"
+    When WalkDir is called with the root "missing"
+    Then the walked paths are ""
+    And Error() of walk iterator returns an error
+
+  Scenario: WalkDir stops at an error while reading a directory
+    Given a file system with the files "b.txt,a/y.txt,c/d.txt" where directory "c" cannot be read
+    When WalkDir is called with the root "."
+    Then the walked paths are ".,a,a/y.txt,b.txt,c"
+    And Error() of walk iterator returns an error
@@ -0,0 +1,103 @@
+Feature: WithContext stops the iteration when the context is cancelled
+
+  Scenario: A ContextIterator with an active context returns all values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And an active context
+    When WithContext is called
+    Then calling Next() until false is returned should return the following integers:
+      | 1 |
+      | 2 |
+      | 3 |
+    And Error() of int iterator returns nil
+
+  Scenario: A ContextIterator with a cancelled context returns no values
+    Given an Iterable with the following values:
+      | 1 |
+      | 2 |
+      | 3 |
+    And a cancelled context
+    When WithContext is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
+
+  Scenario: A blocking Next of a channel is interrupted when the context is cancelled
+    Given a channel
+    And FromChannel is called
+    And a context that is cancelled after 10 milliseconds
+    When WithContext is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
+
+  Scenario: ForEachCtx returns the error of a cancelled context
+    Given a channel
+    And FromChannel is called
+    And a context that is cancelled after 10 milliseconds
+    And a foreach function that sums and counts the calls
+    When ForEachCtx is called
+    Then an error is returned
+    And The returned count is 0
+
+  Scenario: ContextIterator handles errors in source iterator
+    Given an Iterable in an error state
+    And an active context
+    When WithContext is called
+    Then Error() of int iterator returns an error
+
+  Scenario: A channel iterator stays stopped after NextCtx was interrupted
+    Given a channel
+    And FromChannel is called
+    And a context that is cancelled after 10 milliseconds
+    When NextCtx of the int iterator is interrupted by the context
+    And the value 7 is sent on the channel in the background
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
+
+  Scenario: Map passes the context to the source, so a blocking Next is interrupted
+    Given a channel
+    And FromChannel is called
+    And Map is called with a function that doubles the values
+    And Filter is called with a predicate that selects values below 10
+    And a context that is cancelled after 10 milliseconds
+    And a foreach function that sums and counts the calls
+    When ForEachCtx is called
+    Then an error is returned
+    And The returned count is 0
+
+  Scenario: MapErr and FilterErr pass the context to the source
+    Given a channel
+    And FromChannel is called
+    And MapErr is called with a function that doubles the values
+    And FilterErr is called with a predicate that selects even numbers and fails on 3
+    And a context that is cancelled after 10 milliseconds
+    When WithContext is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
+
+  Scenario: Waiting for a page is interrupted when the context is cancelled
+    Given a table that blocks while fetching a page
+    And FromKeysetPages is called with a limit of 3
+    And a context that is cancelled after 10 milliseconds
+    When WithContext is called
+    Then Next() returns true 0 times and then returns false
+    And Error() of int iterator returns an error
+    And the blocked fetch is released
+
+  Scenario: A blocking read of an HTTP body is interrupted when the context is cancelled
+    Given an HTTP response with status 200 and a body that blocks after "alpha\n"
+    And FromHTTPBodyLines is called
+    And a context that is cancelled after 10 milliseconds
+    When WithContext is called on the string iterator
+    Then Next() of string iterator returns true 1 times and then returns false
+    And Error() of string iterator returns an error
+    And the body is closed
+
+  Scenario: A value read from an HTTP body is returned when the context is cancelled during the read
+    Given an HTTP response with status 200 and a body "alpha\nbeta\n" that cancels the context when it is read
+    And FromHTTPBodyLines is called
+    When WithContext is called on the string iterator
+    Then calling Next() until false is returned should return the following strings:
+      | alpha |
+    And Error() of string iterator returns an error
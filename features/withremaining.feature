@@ -0,0 +1,33 @@
+Feature: WithRemaining annotates items from the iteration with the remaining amount of items
+
+  Scenario: Values of a slice are annotated with the remaining and total amount of values
+    Given an Iterable with the following values:
+      | 7 |
+      | 8 |
+      | 9 |
+    When WithRemaining is called
+    Then calling Next() until false is returned should return the following counted values:
+      | 7 | 0 | 2 | 3 |
+      | 8 | 1 | 1 | 3 |
+      | 9 | 2 | 0 | 3 |
+
+  Scenario: Values of a channel are annotated with an unknown total
+    Given a closed channel with the following values:
+      | 7 |
+      | 8 |
+    And FromChannel is called
+    When WithRemaining is called
+    Then calling Next() until false is returned should return the following counted values:
+      | 7 | 0 | ? | ? |
+      | 8 | 1 | ? | ? |
+
+  Scenario: The remaining amount is known through Map and Take
+    Given a start value of 1
+    And an end value of 100
+    When Sequence is called
+    And Take is called with 3
+    And WithRemaining is called
+    Then calling Next() until false is returned should return the following counted values:
+      | 1 | 0 | 2 | 3 |
+      | 2 | 1 | 1 | 3 |
+      | 3 | 2 | 0 | 3 |
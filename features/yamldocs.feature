@@ -0,0 +1,29 @@
+Feature: FromYAMLDocs iterates the documents of a multi-document YAML stream
+
+  Scenario: The documents are decoded one at a time
+    Given a stream with the documents "kind: Service\nname: web\n---\nkind: Deployment\nname: web\n---\nkind: Deployment\nname: worker\n"
+    When FromYAMLDocs is called
+    Then the remaining strings are "Service/web,Deployment/web,Deployment/worker"
+
+  Scenario: A stream that starts with a separator is decoded
+    Given a stream with the documents "---\nkind: Service\nname: web\n...\n---\nkind: Job\nname: migrate\n"
+    When FromYAMLDocs is called
+    Then the remaining strings are "Service/web,Job/migrate"
+
+  Scenario: An empty stream returns no documents
+    Given a stream with the documents ""
+    When FromYAMLDocs is called
+    Then Next() of string iterator returns true 0 times and then returns false
+    And Error() of string iterator returns nil
+
+  Scenario: A document that can not be decoded stops the iteration
+    Given a stream with the documents "kind: Service\nname: web\n---\nkind: [Deployment\n---\nkind: Job\nname: migrate\n"
+    When FromYAMLDocs is called
+    Then Next() of string iterator returns true 1 times and then returns false
+    And Error() of string iterator returns an error
+
+  Scenario: A document that does not match the type stops the iteration
+    Given a stream with the documents "kind: Service\nname: web\n---\nkind:\n  nested: value\n"
+    When FromYAMLDocs is called
+    Then Next() of string iterator returns true 1 times and then returns false
+    And Error() of string iterator returns an error
@@ -2,7 +2,10 @@ module github.com/crosscode-nl/iterator
 
 go 1.18
 
-require github.com/cucumber/godog v0.12.5
+require (
+	github.com/cucumber/godog v0.12.5
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/cucumber/gherkin-go/v19 v19.0.3 // indirect
@@ -1,16 +1,198 @@
 // Package iterator contains an implementation of the map, filter, reduce pattern for Go.
 package iterator
 
+import (
+	"bufio"
+	"container/heap"
+	"container/list"
+	"container/ring"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/fs"
+	"math"
+	"math/rand"
+	"net/http"
+	"path"
+	"regexp"
+	"regexp/syntax"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Iterable is a generic interface for all iterables.
 type Iterable[T any] interface {
 	// Next returns the first or next value of T and true if a value is available.
 	// If no more values are available or an error has occurred then a zero value of T and false is returned.
+	// Once Next returned false, the following calls should return a zero value of T and false as well. Sources like
+	// FromSlice, FromReverseSlice, FromChannel and Sequence guarantee this, stages like Map, Filter and Take only
+	// keep the guarantee when their source does. ReplayIterator.Restart and Resumer revive an iterator on purpose.
+	// Fuse adds the guarantee to any iterator.
 	Next() (T, bool)
 	// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
 	// an error is returned.
 	Error() error
 }
 
+// CtxIterable is an optional interface implemented by iterables that can block while waiting for the next value,
+// such as the ChannelIterator, TickIterator, KeysetPageIterator and HTTPBodyIterator. Map, MapErr, Filter and
+// FilterErr pass the context on to their source. WithContext and ForEachCtx use it to interrupt a blocking Next when
+// the context is cancelled, instead of only noticing the cancellation between values.
+type CtxIterable[T any] interface {
+	Iterable[T]
+	// NextCtx works like Next, but returns a zero value of T and false as soon as the context is cancelled.
+	NextCtx(ctx context.Context) (T, bool)
+}
+
+// SizeHinter is an optional interface implemented by iterables that know how many values remain.
+type SizeHinter interface {
+	// SizeHint returns the amount of values that remain to be returned and true, or false when the amount is unknown.
+	SizeHint() (int, bool)
+}
+
+// sizeHint returns the SizeHint of the Iterable when it implements SizeHinter.
+func sizeHint[T any](iter Iterable[T]) (int, bool) {
+	if h, ok := iter.(SizeHinter); ok {
+		return h.SizeHint()
+	}
+	return 0, false
+}
+
+// ErrExhausted is returned by NextErr when the iteration has completed successfully.
+var ErrExhausted = errors.New("iterator: exhausted")
+
+// IterationState describes the lifecycle state of an Iterable.
+type IterationState int
+
+const (
+	// Active is used when Next may still return values.
+	Active IterationState = iota
+	// Exhausted is used when Next returned false and the iteration has completed successfully.
+	Exhausted
+	// Failed is used when the iteration was stopped by an error.
+	Failed
+)
+
+// String returns the name of the IterationState.
+func (s IterationState) String() string {
+	switch s {
+	case Active:
+		return "Active"
+	case Exhausted:
+		return "Exhausted"
+	case Failed:
+		return "Failed"
+	}
+	return fmt.Sprintf("IterationState(%d)", int(s))
+}
+
+// Stater is an optional interface implemented by iterables that track their IterationState, like the FusedIterator.
+type Stater interface {
+	// State returns the IterationState of the iterable.
+	State() IterationState
+}
+
+// State returns the IterationState of the Iterable. When the Iterable does not implement Stater, Failed is returned
+// when Error returns an error, otherwise Active is returned, because exhaustion can not be observed. Wrap an
+// Iterable with Fuse to track its state.
+func State[T any](iter Iterable[T]) IterationState {
+	if s, ok := iter.(Stater); ok {
+		return s.State()
+	}
+	if iter.Error() != nil {
+		return Failed
+	}
+	return Active
+}
+
+// Resumer is an optional interface implemented by iterables that can continue after an error, like MapErr and
+// FilterErr, which can skip the value for which the closure failed. Map and Filter delegate to their source.
+type Resumer interface {
+	// Resume clears the error and returns true when the iteration can continue with the next value, otherwise false
+	// is returned and the error remains.
+	Resume() bool
+}
+
+// resume calls Resume of the Iterable when it implements Resumer, otherwise false is returned.
+func resume[T any](iter Iterable[T]) bool {
+	if r, ok := iter.(Resumer); ok {
+		return r.Resume()
+	}
+	return false
+}
+
+// NextErr returns the first or next value of T and nil if a value is available. When no more values are available
+// ErrExhausted is returned, or the error of the Iterable when an error has occurred.
+func NextErr[T any](iter Iterable[T]) (T, error) {
+	v, b := iter.Next()
+	if b {
+		return v, nil
+	}
+	if err := iter.Error(); err != nil {
+		return v, err
+	}
+	return v, ErrExhausted
+}
+
+// FusedIterator is a struct the implements an Iterable that keeps returning false once Next returned false.
+type FusedIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// state is the IterationState of the iterator.
+	state IterationState
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// The source Iterable is not called anymore after it returned false.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *FusedIterator[T]) Next() (T, bool) {
+	if iter.state != Active {
+		var v T
+		return v, false
+	}
+	v, b := iter.srcItr.Next()
+	if !b {
+		iter.state = Exhausted
+		if iter.srcItr.Error() != nil {
+			iter.state = Failed
+		}
+		var zero T
+		return zero, false
+	}
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *FusedIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// State returns Active until Next returned false, and then Exhausted or Failed.
+func (iter *FusedIterator[T]) State() IterationState {
+	return iter.state
+}
+
+// Fuse accepts an Iterable and creates a FusedIterator that guarantees that Next keeps returning false once it
+// returned false, and that tracks the IterationState of the Iterable.
+func Fuse[T any](iter Iterable[T]) *FusedIterator[T] {
+	return &FusedIterator[T]{
+		srcItr: iter,
+	}
+}
+
 // SliceIterator is a generic struct implementing an iterator that iterates over slices.
 type SliceIterator[T any] struct {
 	// idx has the position in the slice
@@ -37,6 +219,14 @@ func (iter *SliceIterator[T]) Next() (T, bool) {
 	return iter.values[iter.idx], true
 }
 
+// SizeHint returns the amount of values that remain to be returned and true.
+func (iter *SliceIterator[T]) SizeHint() (int, bool) {
+	if iter.idx >= len(iter.values) {
+		return 0, true
+	}
+	return len(iter.values) - iter.idx - 1, true
+}
+
 // Error returns nil after Next returned false when the iteration has completed successfully, otherwise
 // an error is returned. The SliceIterator never returns an error.
 func (iter *SliceIterator[T]) Error() error {
@@ -61,166 +251,5068 @@ func FromReverseSlice[T any](values []T) *SliceIterator[T] {
 	}
 }
 
+// Empty creates a SliceIterator that does not return any values. Empty is useful as the identity element when
+// Iterables are combined, for example as the result for a skipped value.
+func Empty[T any]() *SliceIterator[T] {
+	return FromSlice[T](nil)
+}
+
+// Once creates a SliceIterator that returns exactly one value.
+func Once[T any](v T) *SliceIterator[T] {
+	return FromSlice([]T{v})
+}
+
+// FuncIterator is a generic struct implementing an iterator that returns the values of a pull function.
+type FuncIterator[T any] struct {
+	// f returns the next value, true when a value is available and an error when the iteration failed.
+	f func() (T, bool, error)
+	// done is set when f returned false or an error.
+	done bool
+	// err contains the error returned by f.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// The pull function is not called anymore after it returned false or an error.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (fi *FuncIterator[T]) Next() (T, bool) {
+	var zero T
+	if fi.done {
+		return zero, false
+	}
+	v, ok, err := fi.f()
+	if err != nil || !ok {
+		fi.done = true
+		fi.err = err
+		return zero, false
+	}
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error returned by the pull function is returned.
+func (fi *FuncIterator[T]) Error() error {
+	return fi.err
+}
+
+// FromFunc creates a FuncIterator that calls f until it returns false.
+func FromFunc[T any](f func() (T, bool)) *FuncIterator[T] {
+	return FromFuncErr(func() (T, bool, error) {
+		v, ok := f()
+		return v, ok, nil
+	})
+}
+
+// FromFuncErr creates a FuncIterator that calls f until it returns false or an error. The error is returned by Error.
+func FromFuncErr[T any](f func() (T, bool, error)) *FuncIterator[T] {
+	return &FuncIterator[T]{
+		f: f,
+	}
+}
+
+// Pair is a key and value pair of a map.
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// mapKeys returns the keys of the map in an undefined order.
+func mapKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// sortedMapKeys returns the keys of the map in ascending order.
+func sortedMapKeys[K Ordered, V any](m map[K]V) []K {
+	keys := mapKeys(m)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// mapPairs returns the Pairs of the map in the order of the provided keys.
+func mapPairs[K comparable, V any](m map[K]V, keys []K) []Pair[K, V] {
+	pairs := make([]Pair[K, V], len(keys))
+	for i, k := range keys {
+		pairs[i] = Pair[K, V]{Key: k, Value: m[k]}
+	}
+	return pairs
+}
+
+// mapValues returns the values of the map in the order of the provided keys.
+func mapValues[K comparable, V any](m map[K]V, keys []K) []V {
+	values := make([]V, len(keys))
+	for i, k := range keys {
+		values[i] = m[k]
+	}
+	return values
+}
+
+// FromMap creates a SliceIterator that iterates the key and value Pairs of the provided map in an undefined order.
+// The Pairs are collected when FromMap is called, so later changes to the map are not visible.
+func FromMap[K comparable, V any](m map[K]V) *SliceIterator[Pair[K, V]] {
+	return FromSlice(mapPairs(m, mapKeys(m)))
+}
+
+// FromMapSorted works like FromMap, but iterates the Pairs in ascending order of the keys, for deterministic
+// pipelines.
+func FromMapSorted[K Ordered, V any](m map[K]V) *SliceIterator[Pair[K, V]] {
+	return FromSlice(mapPairs(m, sortedMapKeys(m)))
+}
+
+// FromMapKeys creates a SliceIterator that iterates the keys of the provided map in an undefined order.
+func FromMapKeys[K comparable, V any](m map[K]V) *SliceIterator[K] {
+	return FromSlice(mapKeys(m))
+}
+
+// FromMapKeysSorted works like FromMapKeys, but iterates the keys in ascending order.
+func FromMapKeysSorted[K Ordered, V any](m map[K]V) *SliceIterator[K] {
+	return FromSlice(sortedMapKeys(m))
+}
+
+// FromMapValues creates a SliceIterator that iterates the values of the provided map in an undefined order.
+func FromMapValues[K comparable, V any](m map[K]V) *SliceIterator[V] {
+	return FromSlice(mapValues(m, mapKeys(m)))
+}
+
+// FromMapValuesSorted works like FromMapValues, but iterates the values in ascending order of their keys.
+func FromMapValuesSorted[K Ordered, V any](m map[K]V) *SliceIterator[V] {
+	return FromSlice(mapValues(m, sortedMapKeys(m)))
+}
+
+// StringRuneIterator is a struct implementing an iterator that iterates over the runes of a string.
+type StringRuneIterator struct {
+	// s is the string that is iterated.
+	s string
+	// pos is the byte offset of the next rune.
+	pos int
+}
+
+// Next returns the first or next rune and true if a rune is available.
+// Invalid UTF-8 is returned as utf8.RuneError, one byte at a time, like a range loop over a string does.
+// If no more runes are available then 0 and false is returned.
+func (iter *StringRuneIterator) Next() (rune, bool) {
+	if iter.pos >= len(iter.s) {
+		return 0, false
+	}
+	r, size := utf8.DecodeRuneInString(iter.s[iter.pos:])
+	iter.pos += size
+	return r, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The StringRuneIterator never returns an error.
+func (iter *StringRuneIterator) Error() error {
+	return nil
+}
+
+// FromStringRunes creates a StringRuneIterator that lazily iterates the runes of the provided string, without
+// converting the string to a []rune.
+func FromStringRunes(s string) *StringRuneIterator {
+	return &StringRuneIterator{
+		s: s,
+	}
+}
+
+// StringByteIterator is a struct implementing an iterator that iterates over the bytes of a string.
+type StringByteIterator struct {
+	// s is the string that is iterated.
+	s string
+	// pos is the offset of the next byte.
+	pos int
+}
+
+// Next returns the first or next byte and true if a byte is available.
+// If no more bytes are available then 0 and false is returned.
+func (iter *StringByteIterator) Next() (byte, bool) {
+	if iter.pos >= len(iter.s) {
+		return 0, false
+	}
+	iter.pos++
+	return iter.s[iter.pos-1], true
+}
+
+// SizeHint returns the amount of bytes that remain to be returned and true.
+func (iter *StringByteIterator) SizeHint() (int, bool) {
+	return len(iter.s) - iter.pos, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The StringByteIterator never returns an error.
+func (iter *StringByteIterator) Error() error {
+	return nil
+}
+
+// FromStringBytes creates a StringByteIterator that lazily iterates the bytes of the provided string, without
+// converting the string to a []byte.
+func FromStringBytes(s string) *StringByteIterator {
+	return &StringByteIterator{
+		s: s,
+	}
+}
+
+// SplitStringIterator is a struct the implements an Iterable that lazily splits a string around a separator.
+type SplitStringIterator struct {
+	// s contains the remainder of the string that has not been split yet.
+	s string
+	// sep is the separator.
+	sep string
+	// done is set when the last substring has been returned.
+	done bool
+}
+
+// Next returns the first or next substring and true if a substring is available.
+// If no more values are available then an empty string and false is returned.
+func (si *SplitStringIterator) Next() (string, bool) {
+	if si.done {
+		return "", false
+	}
+	if si.sep == "" {
+		if si.s == "" {
+			si.done = true
+			return "", false
+		}
+		_, width := utf8.DecodeRuneInString(si.s)
+		v := si.s[:width]
+		si.s = si.s[width:]
+		return v, true
+	}
+	i := strings.Index(si.s, si.sep)
+	if i < 0 {
+		si.done = true
+		return si.s, true
+	}
+	v := si.s[:i]
+	si.s = si.s[i+len(si.sep):]
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The SplitStringIterator never returns an error.
+func (si *SplitStringIterator) Error() error {
+	return nil
+}
+
+// SplitString creates a SplitStringIterator that returns the same substrings as strings.Split, but finds each
+// substring when it is requested, so no slice with all substrings is allocated.
+func SplitString(s, sep string) *SplitStringIterator {
+	return &SplitStringIterator{
+		s:   s,
+		sep: sep,
+	}
+}
+
+// FieldsStringIterator is a struct the implements an Iterable that lazily splits a string around whitespace.
+type FieldsStringIterator struct {
+	// s contains the remainder of the string that has not been split yet.
+	s string
+}
+
+// Next returns the first or next field and true if a field is available.
+// If no more values are available then an empty string and false is returned.
+func (fi *FieldsStringIterator) Next() (string, bool) {
+	fi.s = strings.TrimLeftFunc(fi.s, unicode.IsSpace)
+	if fi.s == "" {
+		return "", false
+	}
+	i := strings.IndexFunc(fi.s, unicode.IsSpace)
+	if i < 0 {
+		i = len(fi.s)
+	}
+	v := fi.s[:i]
+	fi.s = fi.s[i:]
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The FieldsStringIterator never returns an error.
+func (fi *FieldsStringIterator) Error() error {
+	return nil
+}
+
+// FieldsString creates a FieldsStringIterator that returns the same fields as strings.Fields, which are separated by
+// one or more whitespace characters as defined by unicode.IsSpace, but finds each field when it is requested.
+func FieldsString(s string) *FieldsStringIterator {
+	return &FieldsStringIterator{
+		s: s,
+	}
+}
+
+// assertValue returns the value of a container element as a T. A nil value is returned as the zero value of T.
+func assertValue[T any](container string, idx int, value any) (T, error) {
+	var zero T
+	if value == nil {
+		return zero, nil
+	}
+	v, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("iterator: %s element %d has type %T, expected %T", container, idx, value, zero)
+	}
+	return v, nil
+}
+
+// ListIterator is a generic struct implementing an iterator that iterates over the elements of a list.List.
+type ListIterator[T any] struct {
+	// next contains the element to return next.
+	next *list.Element
+	// idx contains the index of the next element.
+	idx int
+	// err contains the error of an element with a value that is not a T.
+	err error
+}
+
+// Next returns the value of the first or next element as a T and true if an element is available.
+// The next element is looked up after the current element is returned, so elements that are inserted after the
+// current element are returned as well.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (li *ListIterator[T]) Next() (T, bool) {
+	var zero T
+	if li.next == nil {
+		return zero, false
+	}
+	v, err := assertValue[T]("list", li.idx, li.next.Value)
+	if err != nil {
+		li.next = nil
+		li.err = err
+		return zero, false
+	}
+	li.next = li.next.Next()
+	li.idx++
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned when an element has a value that is not a T.
+func (li *ListIterator[T]) Error() error {
+	return li.err
+}
+
+// FromList creates a ListIterator that iterates the values of the elements of l from front to back. The values are
+// type asserted to T, and a nil value is returned as the zero value of T. The iteration stops with an error at the
+// first value that is not a T. A nil list is iterated as an empty list.
+func FromList[T any](l *list.List) *ListIterator[T] {
+	if l == nil {
+		return &ListIterator[T]{}
+	}
+	return &ListIterator[T]{
+		next: l.Front(),
+	}
+}
+
+// RingIterator is a generic struct implementing an iterator that iterates over the elements of a ring.Ring.
+type RingIterator[T any] struct {
+	// current contains the element to return next.
+	current *ring.Ring
+	// remaining contains the amount of elements that still need to be returned.
+	remaining int
+	// idx contains the index of the next element.
+	idx int
+	// err contains the error of an element with a value that is not a T.
+	err error
+}
+
+// Next returns the value of the first or next element as a T and true if an element is available.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (ri *RingIterator[T]) Next() (T, bool) {
+	var zero T
+	if ri.remaining <= 0 {
+		return zero, false
+	}
+	v, err := assertValue[T]("ring", ri.idx, ri.current.Value)
+	if err != nil {
+		ri.remaining = 0
+		ri.err = err
+		return zero, false
+	}
+	ri.current = ri.current.Next()
+	ri.remaining--
+	ri.idx++
+	return v, true
+}
+
+// SizeHint returns the amount of values that remain to be returned and true.
+func (ri *RingIterator[T]) SizeHint() (int, bool) {
+	return ri.remaining, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned when an element has a value that is not a T.
+func (ri *RingIterator[T]) Error() error {
+	return ri.err
+}
+
+// FromRing creates a RingIterator that iterates the values of the elements of r, starting at r, and goes around the
+// ring laps times. The values are type asserted like FromList does. FromRing panics when laps is negative.
+func FromRing[T any](r *ring.Ring, laps int) *RingIterator[T] {
+	if laps < 0 {
+		panic("iterator: laps must not be negative")
+	}
+	return &RingIterator[T]{
+		current:   r,
+		remaining: laps * r.Len(),
+	}
+}
+
+// HeapIterator is a generic struct implementing an iterator that pops the values of a heap in priority order.
+type HeapIterator[T any] struct {
+	// h is the heap the values are popped from.
+	h heap.Interface
+	// idx contains the index of the next value.
+	idx int
+	// err contains the error of a popped value that is not a T.
+	err error
+}
+
+// Next pops the value with the highest priority, which is the minimum according to the Less method of the heap, and
+// returns it as a T and true if a value is available. Values pushed on the heap during the iteration are returned as
+// well. If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (hi *HeapIterator[T]) Next() (T, bool) {
+	var zero T
+	if hi.err != nil || hi.h.Len() == 0 {
+		return zero, false
+	}
+	v, err := assertValue[T]("heap", hi.idx, heap.Pop(hi.h))
+	if err != nil {
+		hi.err = err
+		return zero, false
+	}
+	hi.idx++
+	return v, true
+}
+
+// SizeHint returns the amount of values that remain in the heap and true.
+func (hi *HeapIterator[T]) SizeHint() (int, bool) {
+	if hi.err != nil {
+		return 0, true
+	}
+	return hi.h.Len(), true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned when a popped value is not a T.
+func (hi *HeapIterator[T]) Error() error {
+	return hi.err
+}
+
+// FromHeap creates a HeapIterator that lazily pops the values of h in priority order, so the heap is empty when the
+// iteration is exhausted. FromHeap calls heap.Init, so h does not need to be initialized. The popped values are type
+// asserted like FromList does.
+func FromHeap[T any](h heap.Interface) *HeapIterator[T] {
+	heap.Init(h)
+	return &HeapIterator[T]{
+		h: h,
+	}
+}
+
 // ChannelIterator is a generic struct implementing an iterator that iterates over channels.
 type ChannelIterator[T any] struct {
 	c <-chan T
+	// err contains the error of the context that interrupted NextCtx.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *ChannelIterator[T]) Next() (v T, r bool) {
+	if iter.err != nil {
+		return
+	}
+	v, r = <-iter.c
+	return
+}
+
+// NextCtx works like Next, but returns a zero value of T and false when the context is cancelled while waiting
+// for a value. The error of the context is returned by Error afterwards, and the iteration is stopped, so no more
+// values are received from the channel.
+func (iter *ChannelIterator[T]) NextCtx(ctx context.Context) (v T, r bool) {
+	if iter.err != nil {
+		return
+	}
+	if iter.err = ctx.Err(); iter.err != nil {
+		return
+	}
+	select {
+	case v, r = <-iter.c:
+		return
+	case <-ctx.Done():
+		iter.err = ctx.Err()
+		return
+	}
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The ChannelIterator only returns an error when NextCtx was interrupted by a context.
+func (iter *ChannelIterator[T]) Error() error {
+	return iter.err
+}
+
+// FromChannel creates a ChannelIterator that iterates the provided channel.
+func FromChannel[T any](c <-chan T) *ChannelIterator[T] {
+	return &ChannelIterator[T]{
+		c: c,
+	}
+}
+
+// TickIterator is a struct implementing an infinite iterator that returns the times delivered by a time.Ticker.
+type TickIterator struct {
+	// ticker delivers the ticks.
+	ticker *time.Ticker
+	// done is closed when the iterator is stopped.
+	done chan struct{}
+	// stop makes sure done is only closed once.
+	stop sync.Once
+	// err contains the error of the context that interrupted NextCtx.
+	err error
+}
+
+// Next waits for the next tick and returns its time and true. When the iterator is stopped, also while Next is
+// waiting, a zero time.Time and false is returned.
+func (ti *TickIterator) Next() (time.Time, bool) {
+	if ti.err != nil {
+		return time.Time{}, false
+	}
+	select {
+	case v := <-ti.ticker.C:
+		return v, true
+	case <-ti.done:
+		return time.Time{}, false
+	}
+}
+
+// NextCtx works like Next, but returns a zero time.Time and false when the context is cancelled while waiting for a
+// tick. The error of the context is returned by Error afterwards, and the iteration is stopped. Stop still needs to be
+// called to release the ticker.
+func (ti *TickIterator) NextCtx(ctx context.Context) (time.Time, bool) {
+	if ti.err != nil {
+		return time.Time{}, false
+	}
+	if ti.err = ctx.Err(); ti.err != nil {
+		return time.Time{}, false
+	}
+	select {
+	case v := <-ti.ticker.C:
+		return v, true
+	case <-ti.done:
+		return time.Time{}, false
+	case <-ctx.Done():
+		ti.err = ctx.Err()
+		return time.Time{}, false
+	}
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The TickIterator only returns an error when NextCtx was interrupted by a context.
+func (ti *TickIterator) Error() error {
+	return ti.err
+}
+
+// Stop stops the ticker and ends the iteration. Stop may be called more than once and from another goroutine than
+// the one that calls Next.
+func (ti *TickIterator) Stop() {
+	ti.stop.Do(func() {
+		ti.ticker.Stop()
+		close(ti.done)
+	})
+}
+
+// Close calls Stop and returns nil, so the TickIterator can be used as an io.Closer.
+func (ti *TickIterator) Close() error {
+	ti.Stop()
+	return nil
+}
+
+// Ticks creates a TickIterator that returns the time of a tick every d. Ticks are dropped when Next is not called
+// in time, like with a time.Ticker. Stop or Close must be called to release the ticker. Ticks panics when d is not
+// positive.
+func Ticks(d time.Duration) *TickIterator {
+	if d <= 0 {
+		panic("iterator: duration must be positive")
+	}
+	return &TickIterator{
+		ticker: time.NewTicker(d),
+		done:   make(chan struct{}),
+	}
+}
+
+// KeysetPageFunc is the closure type that needs to be provided to FromKeysetPages. It receives the key of the last
+// value of the previous page, which is the zero value of K for the first page, and the maximum amount of values to
+// return. It returns the values of the page, the key of the last value, and true when more pages are available.
+type KeysetPageFunc[T any, K any] func(after K, limit int) ([]T, K, bool, error)
+
+// keysetPage contains the result of a KeysetPageFunc closure.
+type keysetPage[T any, K any] struct {
+	values []T
+	last   K
+	more   bool
+	err    error
+}
+
+// KeysetPageIterator is a generic struct implementing an iterator that iterates over pages fetched with keyset
+// pagination.
+type KeysetPageIterator[T any, K any] struct {
+	// fetch is the closure that fetches a page.
+	fetch KeysetPageFunc[T, K]
+	// limit contains the maximum amount of values per page.
+	limit int
+	// page contains the values of the current page.
+	page []T
+	// idx has the position in page.
+	idx int
+	// after contains the key of the last value of the current page.
+	after K
+	// more is true when more pages are available.
+	more bool
+	// err contains the error returned by the KeysetPageFunc closure or the error of the context that interrupted
+	// NextCtx.
+	err error
+	// prefetch is true when the next page is fetched while the current page is consumed.
+	prefetch bool
+	// pending receives the prefetched page.
+	pending chan keysetPage[T, K]
+}
+
+// fetchAsync fetches the page after the key in a goroutine and returns the channel that receives the page.
+func (iter *KeysetPageIterator[T, K]) fetchAsync(after K) chan keysetPage[T, K] {
+	pending := make(chan keysetPage[T, K], 1)
+	go func() {
+		var p keysetPage[T, K]
+		p.values, p.last, p.more, p.err = iter.fetch(after, iter.limit)
+		pending <- p
+	}()
+	return pending
+}
+
+// load fetches the next page, or receives it when it has been prefetched. When the context can be cancelled, the
+// page is fetched in a goroutine, so waiting for it can be interrupted. The iteration is then stopped with the error
+// of the context.
+func (iter *KeysetPageIterator[T, K]) load(ctx context.Context) {
+	var p keysetPage[T, K]
+	if iter.pending == nil && ctx.Done() == nil {
+		p.values, p.last, p.more, p.err = iter.fetch(iter.after, iter.limit)
+	} else {
+		if iter.pending == nil {
+			iter.pending = iter.fetchAsync(iter.after)
+		}
+		select {
+		case p = <-iter.pending:
+			iter.pending = nil
+		case <-ctx.Done():
+			iter.err = ctx.Err()
+			return
+		}
+	}
+	iter.page, iter.idx, iter.after, iter.more, iter.err = p.values, 0, p.last, p.more, p.err
+	if iter.prefetch && iter.more && iter.err == nil {
+		iter.pending = iter.fetchAsync(iter.after)
+	}
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// A new page is fetched when all values of the current page have been returned.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *KeysetPageIterator[T, K]) Next() (T, bool) {
+	return iter.NextCtx(context.Background())
+}
+
+// NextCtx works like Next, but returns a zero value of T and false when the context is cancelled while waiting for
+// a page. The error of the context is returned by Error afterwards, and the iteration is stopped. The fetch that was
+// interrupted completes in the background and its page is discarded.
+func (iter *KeysetPageIterator[T, K]) NextCtx(ctx context.Context) (T, bool) {
+	for {
+		if iter.idx < len(iter.page) {
+			iter.idx++
+			return iter.page[iter.idx-1], true
+		}
+		if iter.err != nil || !iter.more {
+			var t T
+			return t, false
+		}
+		iter.load(ctx)
+	}
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error returned by the KeysetPageFunc closure, or the error of the context that interrupted NextCtx, is returned.
+func (iter *KeysetPageIterator[T, K]) Error() error {
+	return iter.err
+}
+
+// FromKeysetPages creates a KeysetPageIterator that fetches pages of at most limit values with the provided
+// KeysetPageFunc closure, passing the key of the last value of each page to fetch the next page.
+func FromKeysetPages[T any, K any](fetch KeysetPageFunc[T, K], limit int) *KeysetPageIterator[T, K] {
+	return &KeysetPageIterator[T, K]{
+		fetch: fetch,
+		limit: limit,
+		more:  true,
+	}
+}
+
+// FromKeysetPagesPrefetch works like FromKeysetPages, but fetches the next page in a goroutine while the values of
+// the current page are consumed. The KeysetPageFunc closure is never called concurrently with itself.
+func FromKeysetPagesPrefetch[T any, K any](fetch KeysetPageFunc[T, K], limit int) *KeysetPageIterator[T, K] {
+	iter := FromKeysetPages(fetch, limit)
+	iter.prefetch = true
+	return iter
+}
+
+// FromPages creates a KeysetPageIterator that lazily fetches pages with the provided fetch closure and returns the
+// values of the pages one by one. The first page is fetched with the first cursor, and each next page with the cursor
+// returned for the previous page, until fetch returns true for done. The values of the page that fetch returned
+// together with done or an error are still returned. The error returned by fetch is returned by Error.
+func FromPages[T any, C any](first C, fetch func(cursor C) (items []T, next C, done bool, err error)) *KeysetPageIterator[T, C] {
+	return &KeysetPageIterator[T, C]{
+		fetch: func(cursor C, _ int) ([]T, C, bool, error) {
+			items, next, done, err := fetch(cursor)
+			return items, next, !done, err
+		},
+		after: first,
+		more:  true,
+	}
+}
+
+// Decoder is the interface implemented by stream decoders like json.Decoder, xml.Decoder and the Decoder of
+// gopkg.in/yaml.v3. Decode stores the next value in v and returns io.EOF when the stream has no more values.
+type Decoder interface {
+	Decode(v any) error
+}
+
+// DecoderIterator is a generic struct implementing an iterator that decodes the values of a stream one at a time.
+type DecoderIterator[T any] struct {
+	// dec is the Decoder that decodes the values.
+	dec Decoder
+	// done is set when the stream has been exhausted or an error has occurred.
+	done bool
+	// err contains the decode error.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// Each value is decoded when it is requested, so large streams are not loaded into memory.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (di *DecoderIterator[T]) Next() (T, bool) {
+	var v T
+	if di.done {
+		return v, false
+	}
+	if err := di.dec.Decode(&v); err != nil {
+		di.done = true
+		if !errors.Is(err, io.EOF) {
+			di.err = err
+		}
+		var zero T
+		return zero, false
+	}
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the decode error is returned.
+func (di *DecoderIterator[T]) Error() error {
+	return di.err
+}
+
+// FromDecoder creates a DecoderIterator that decodes each value of the stream into a T.
+// JSON value streams can be iterated with FromDecoder[T](json.NewDecoder(r)), FromYAMLDocs and FromJSONLines are
+// shorthands for the common formats.
+func FromDecoder[T any](dec Decoder) *DecoderIterator[T] {
+	return &DecoderIterator[T]{
+		dec: dec,
+	}
+}
+
+// ReaderIterator is a struct implementing an iterator that reads successive chunks from an io.Reader.
+type ReaderIterator struct {
+	// r is the io.Reader the chunks are read from.
+	r io.Reader
+	// chunkSize is the size of each chunk.
+	chunkSize int
+	// done is set when the io.Reader has been exhausted or an error has occurred.
+	done bool
+	// err contains the read error.
+	err error
+}
+
+// Next returns the first or next chunk and true if a chunk is available.
+// Each chunk has the chunk size, except the last chunk which can be smaller. Each chunk is a new slice that may be
+// retained. When a read error occurs, the bytes read before the error are returned as the last chunk.
+// If no more chunks are available or an error has occurred then nil and false is returned.
+func (iter *ReaderIterator) Next() ([]byte, bool) {
+	if iter.done {
+		return nil, false
+	}
+	chunk := make([]byte, iter.chunkSize)
+	n, err := io.ReadFull(iter.r, chunk)
+	if err != nil {
+		iter.done = true
+		if err != io.EOF && err != io.ErrUnexpectedEOF {
+			iter.err = err
+		}
+	}
+	if n == 0 {
+		return nil, false
+	}
+	return chunk[:n], true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the read error is returned.
+func (iter *ReaderIterator) Error() error {
+	return iter.err
+}
+
+// FromReader creates a ReaderIterator that reads successive chunks of chunkSize bytes from the provided io.Reader.
+// FromReader panics when chunkSize is not positive.
+func FromReader(r io.Reader, chunkSize int) *ReaderIterator {
+	if chunkSize <= 0 {
+		panic("iterator: chunkSize must be positive")
+	}
+	return &ReaderIterator{
+		r:         r,
+		chunkSize: chunkSize,
+	}
+}
+
+// ScannerIterator is a generic struct implementing an iterator that iterates over the tokens of a bufio.Scanner.
+type ScannerIterator[T any] struct {
+	// scanner splits the input into tokens.
+	scanner *bufio.Scanner
+	// convert creates a value from the bytes of a token. The bytes are only valid until the next token is scanned.
+	convert func([]byte) T
+	// done is set when the scanner stopped.
+	done bool
+}
+
+// Next returns the first or next token and true if a token is available.
+// If no more tokens are available or an error has occurred then a zero value of T and false is returned.
+func (iter *ScannerIterator[T]) Next() (T, bool) {
+	var v T
+	if iter.done {
+		return v, false
+	}
+	if !iter.scanner.Scan() {
+		iter.done = true
+		return v, false
+	}
+	return iter.convert(iter.scanner.Bytes()), true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error of the scanner is returned.
+func (iter *ScannerIterator[T]) Error() error {
+	return iter.scanner.Err()
+}
+
+// FromScanner creates a ScannerIterator that iterates the tokens of the provided bufio.Scanner as strings, so any
+// configuration of the scanner, like its split function, is kept.
+func FromScanner(s *bufio.Scanner) *ScannerIterator[string] {
+	return &ScannerIterator[string]{
+		scanner: s,
+		convert: func(b []byte) string {
+			return string(b)
+		},
+	}
+}
+
+// FromScannerBytes works like FromScanner, but returns the tokens as byte slices. Each token is copied, because the
+// scanner reuses its buffer, so a token remains valid after the following call to Next.
+func FromScannerBytes(s *bufio.Scanner) *ScannerIterator[[]byte] {
+	return &ScannerIterator[[]byte]{
+		scanner: s,
+		convert: func(b []byte) []byte {
+			return append([]byte{}, b...)
+		},
+	}
+}
+
+// LinesOption configures the bufio.Scanner used by Lines.
+type LinesOption func(s *bufio.Scanner)
+
+// WithSplitFunc returns a LinesOption that splits the input with the provided bufio.SplitFunc instead of into lines.
+func WithSplitFunc(split bufio.SplitFunc) LinesOption {
+	return func(s *bufio.Scanner) {
+		s.Split(split)
+	}
+}
+
+// WithMaxTokenSize returns a LinesOption that allows tokens up to size bytes, instead of bufio.MaxScanTokenSize.
+func WithMaxTokenSize(size int) LinesOption {
+	return func(s *bufio.Scanner) {
+		s.Buffer(nil, size)
+	}
+}
+
+// Lines creates a ScannerIterator that lazily iterates the lines of the provided io.Reader, without line endings.
+// A line longer than the maximum token size stops the iteration with bufio.ErrTooLong.
+func Lines(r io.Reader, options ...LinesOption) *ScannerIterator[string] {
+	s := bufio.NewScanner(r)
+	for _, o := range options {
+		o(s)
+	}
+	return FromScanner(s)
+}
+
+// CSVIterator is a struct implementing an iterator that lazily reads the records of a csv.Reader.
+type CSVIterator struct {
+	// r is the csv.Reader the records are read from.
+	r *csv.Reader
+	// done is set when the csv.Reader has been exhausted or an error has occurred.
+	done bool
+	// err contains the read or parse error.
+	err error
+}
+
+// Next returns the first or next record and true if a record is available.
+// When the csv.Reader has ReuseRecord set, a record is only valid until the following call to Next.
+// If no more records are available or an error has occurred then nil and false is returned.
+func (iter *CSVIterator) Next() ([]string, bool) {
+	if iter.done {
+		return nil, false
+	}
+	record, err := iter.r.Read()
+	if err != nil {
+		iter.done = true
+		if err != io.EOF {
+			iter.err = err
+		}
+		return nil, false
+	}
+	return record, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the read or parse error is returned.
+func (iter *CSVIterator) Error() error {
+	return iter.err
+}
+
+// FromCSV creates a CSVIterator that lazily reads the records of the provided csv.Reader.
+func FromCSV(r *csv.Reader) *CSVIterator {
+	return &CSVIterator{
+		r: r,
+	}
+}
+
+// HTTPStatusError is the error of an HTTPBodyIterator when the response has a status code outside the 2xx range.
+type HTTPStatusError struct {
+	// StatusCode is the status code of the response.
+	StatusCode int
+	// Status is the status text of the response, like "404 Not Found".
+	Status string
+}
+
+// Error returns a description of the status.
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("iterator: unexpected HTTP status %s", e.Status)
+}
+
+// HTTPBodyIterator is a generic struct implementing an iterator that lazily reads the body of an HTTP response.
+// The body is closed when the iteration is exhausted, an error has occurred or Close is called.
+type HTTPBodyIterator[T any] struct {
+	// body is the body of the response.
+	body io.ReadCloser
+	// scanner splits the body into values.
+	scanner *bufio.Scanner
+	// convert creates a value from the bytes of a token. The bytes are only valid until the next token is scanned.
+	convert func([]byte) T
+	// closed is set when the body has been closed.
+	closed bool
+	// err contains the status, read or close error, or the error of the context that interrupted NextCtx.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// The body is closed when no more values are available.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (hi *HTTPBodyIterator[T]) Next() (T, bool) {
+	var v T
+	if hi.closed {
+		return v, false
+	}
+	if !hi.scanner.Scan() {
+		hi.err = hi.scanner.Err()
+		if err := hi.Close(); hi.err == nil {
+			hi.err = err
+		}
+		return v, false
+	}
+	return hi.convert(hi.scanner.Bytes()), true
+}
+
+// NextCtx works like Next, but returns a zero value of T and false when the context is cancelled while waiting for
+// the body. The body is closed to interrupt the blocked read, the error of the context is returned by Error
+// afterwards and the iteration is stopped. A value that was read before the context was cancelled is still returned.
+func (hi *HTTPBodyIterator[T]) NextCtx(ctx context.Context) (T, bool) {
+	if ctx.Done() == nil || hi.closed {
+		return hi.Next()
+	}
+	if err := ctx.Err(); err != nil {
+		hi.err = err
+		_ = hi.Close()
+		var zero T
+		return zero, false
+	}
+	stop := make(chan struct{})
+	interrupted := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = hi.body.Close()
+			interrupted <- true
+		case <-stop:
+			interrupted <- false
+		}
+	}()
+	v, b := hi.Next()
+	close(stop)
+	if <-interrupted {
+		// The body is closed, so the iteration stops, but a value that was read before is still returned.
+		hi.closed = true
+		hi.err = ctx.Err()
+	}
+	return v, b
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. An HTTPStatusError is returned when the response has a status code outside the 2xx range.
+func (hi *HTTPBodyIterator[T]) Error() error {
+	return hi.err
+}
+
+// Close closes the body of the response when it was not already closed. Close must be called when the iteration is
+// stopped before it was exhausted.
+func (hi *HTTPBodyIterator[T]) Close() error {
+	if hi.closed {
+		return nil
+	}
+	hi.closed = true
+	return hi.body.Close()
+}
+
+// fromHTTPBody creates an HTTPBodyIterator that splits the body with the provided bufio.Scanner.
+func fromHTTPBody[T any](resp *http.Response, scanner *bufio.Scanner, convert func([]byte) T) *HTTPBodyIterator[T] {
+	hi := &HTTPBodyIterator[T]{
+		body:    resp.Body,
+		scanner: scanner,
+		convert: convert,
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		hi.err = &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+		_ = hi.Close()
+	}
+	return hi
+}
+
+// FromHTTPBodyLines creates an HTTPBodyIterator that iterates the lines of the body of the response, without line
+// endings. The body is closed when the iteration has completed, and an HTTPStatusError is returned by Error when
+// the response has a status code outside the 2xx range.
+func FromHTTPBodyLines(resp *http.Response) *HTTPBodyIterator[string] {
+	return fromHTTPBody(resp, bufio.NewScanner(resp.Body), func(b []byte) string {
+		return string(b)
+	})
+}
+
+// FromHTTPBodyChunks works like FromHTTPBodyLines, but iterates chunks of at most size bytes of the body.
+// Only the last chunk can be smaller than size. FromHTTPBodyChunks panics when size is not positive.
+func FromHTTPBodyChunks(resp *http.Response, size int) *HTTPBodyIterator[[]byte] {
+	if size <= 0 {
+		panic("iterator: size must be positive")
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, size), size)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if len(data) >= size {
+			return size, data[:size], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	return fromHTTPBody(resp, scanner, func(b []byte) []byte {
+		return append([]byte(nil), b...)
+	})
+}
+
+// FromYAMLDocs creates a DecoderIterator that decodes the documents of a multi-document YAML stream, separated by
+// ---, one document at a time into a T. This processes Kubernetes manifests and config bundles without loading the
+// whole stream into memory. The iteration stops at the first document that can not be decoded and the error is
+// returned by Error.
+func FromYAMLDocs[T any](r io.Reader) *DecoderIterator[T] {
+	return FromDecoder[T](yaml.NewDecoder(r))
+}
+
+// FromJSONLines creates a DecoderIterator that decodes each value of newline delimited JSON, also known as NDJSON or
+// JSON Lines, into a T.
+func FromJSONLines[T any](r io.Reader) *DecoderIterator[T] {
+	return FromDecoder[T](json.NewDecoder(r))
+}
+
+// JSONArrayIterator is a generic struct implementing an iterator that decodes the elements of a JSON array one at a
+// time.
+type JSONArrayIterator[T any] struct {
+	// dec is the json.Decoder that reads the array.
+	dec *json.Decoder
+	// started is set when the opening bracket of the array has been read.
+	started bool
+	// done is set when the array has been read completely or an error has occurred.
+	done bool
+	// err contains the decode error.
+	err error
+}
+
+// Next returns the first or next element of the array and true if an element is available.
+// Each element is decoded when it is requested, so large arrays are not loaded into memory.
+// If no more elements are available or an error has occurred then a zero value of T and false is returned.
+func (iter *JSONArrayIterator[T]) Next() (T, bool) {
+	var v T
+	if iter.done {
+		return v, false
+	}
+	if !iter.started {
+		iter.started = true
+		if err := iter.expectDelim('['); err != nil {
+			return v, false
+		}
+	}
+	if !iter.dec.More() {
+		_ = iter.expectDelim(']')
+		iter.done = true
+		return v, false
+	}
+	if err := iter.dec.Decode(&v); err != nil {
+		iter.done = true
+		iter.err = err
+		var zero T
+		return zero, false
+	}
+	return v, true
+}
+
+// expectDelim reads the next token and stores an error when it is not the provided delimiter.
+func (iter *JSONArrayIterator[T]) expectDelim(delim json.Delim) error {
+	tok, err := iter.dec.Token()
+	if err == nil && tok != delim {
+		err = fmt.Errorf("iterator: expected %v in JSON array, got %v", delim, tok)
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		iter.done = true
+		iter.err = err
+	}
+	return err
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the decode error is returned.
+func (iter *JSONArrayIterator[T]) Error() error {
+	return iter.err
+}
+
+// FromJSONArray creates a JSONArrayIterator that reads a JSON array from the provided json.Decoder token by token and
+// decodes each element into a T.
+func FromJSONArray[T any](dec *json.Decoder) *JSONArrayIterator[T] {
+	return &JSONArrayIterator[T]{
+		dec: dec,
+	}
+}
+
+// RowsIterator is a generic struct implementing an iterator that scans the rows of a database query.
+// The rows are closed when the iteration is exhausted, an error has occurred or Close is called.
+type RowsIterator[T any] struct {
+	// rows contains the result of the query.
+	rows *sql.Rows
+	// scan creates a value from the current row.
+	scan func(*sql.Rows) (T, error)
+	// closed is set when the rows have been closed.
+	closed bool
+	// err contains the scan, iteration or close error.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// The rows are closed when no more values are available.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (ri *RowsIterator[T]) Next() (T, bool) {
+	var zero T
+	if ri.closed {
+		return zero, false
+	}
+	if !ri.rows.Next() {
+		ri.err = ri.rows.Err()
+		if err := ri.Close(); ri.err == nil {
+			ri.err = err
+		}
+		return zero, false
+	}
+	v, err := ri.scan(ri.rows)
+	if err != nil {
+		ri.err = err
+		_ = ri.Close()
+		return zero, false
+	}
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the scan, iteration or close error is returned.
+func (ri *RowsIterator[T]) Error() error {
+	return ri.err
+}
+
+// Close closes the rows when they were not already closed. Close must be called when the iteration is stopped
+// before it was exhausted, so the database connection is released.
+func (ri *RowsIterator[T]) Close() error {
+	if ri.closed {
+		return nil
+	}
+	ri.closed = true
+	return ri.rows.Close()
+}
+
+// FromRows creates a RowsIterator that calls scan for each row of rows. An error returned by scan stops the
+// iteration and is returned by Error.
+func FromRows[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error)) *RowsIterator[T] {
+	return &RowsIterator[T]{
+		rows: rows,
+		scan: scan,
+	}
+}
+
+// RegexpIterator is a generic struct implementing an iterator that lazily finds the successive matches of a regular
+// expression in a string.
+type RegexpIterator[T any] struct {
+	// s is the string that is searched.
+	s string
+	// find returns the indexes of the first match in a string.
+	find func(string) []int
+	// findAll returns the indexes of all matches in a string.
+	findAll func(string, int) [][]int
+	// convert creates a value from the indexes of a match in s.
+	convert func(s string, loc []int) T
+	// pos contains the position in s where the next search starts.
+	pos int
+	// prevEnd contains the end of the previous match, or -1 when there was no previous match.
+	prevEnd int
+	// lookBehind is set when the regular expression contains an assertion that inspects the text before the position
+	// where a search starts. The matches are then found with findAll.
+	lookBehind bool
+	// matches contains the matches found with findAll that have not been returned yet.
+	matches [][]int
+	// done is set when no more matches are available, or when findAll has been called.
+	done bool
+}
+
+// Next returns the first or next match and true if a match is available.
+// The matches are the same as FindAllString returns. Empty matches that directly follow a previous match are skipped.
+// If no more values are available then a zero value of T and false is returned.
+func (ri *RegexpIterator[T]) Next() (T, bool) {
+	var zero T
+	if ri.lookBehind {
+		if !ri.done {
+			ri.done = true
+			ri.matches = ri.findAll(ri.s, -1)
+		}
+		if len(ri.matches) == 0 {
+			return zero, false
+		}
+		loc := ri.matches[0]
+		ri.matches = ri.matches[1:]
+		return ri.convert(ri.s, loc), true
+	}
+	for !ri.done && ri.pos <= len(ri.s) {
+		loc := ri.find(ri.s[ri.pos:])
+		if loc == nil {
+			break
+		}
+		for i := range loc {
+			if loc[i] >= 0 {
+				loc[i] += ri.pos
+			}
+		}
+		accept := true
+		if loc[1] == ri.pos {
+			// An empty match at the current position. The search continues after the next rune.
+			if loc[0] == ri.prevEnd {
+				accept = false
+			}
+			if ri.pos < len(ri.s) {
+				_, width := utf8.DecodeRuneInString(ri.s[ri.pos:])
+				ri.pos += width
+			} else {
+				ri.pos++
+			}
+		} else {
+			ri.pos = loc[1]
+		}
+		ri.prevEnd = loc[1]
+		if accept {
+			return ri.convert(ri.s, loc), true
+		}
+	}
+	ri.done = true
+	return zero, false
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The RegexpIterator never returns an error.
+func (ri *RegexpIterator[T]) Error() error {
+	return nil
+}
+
+// fromRegexp creates a RegexpIterator that searches s with find, or with findAll when the regular expression inspects
+// the text before a match.
+func fromRegexp[T any](re *regexp.Regexp, s string, find func(string) []int, findAll func(string, int) [][]int,
+	convert func(string, []int) T) *RegexpIterator[T] {
+	lookBehind := true
+	if parsed, err := syntax.Parse(re.String(), syntax.Perl); err == nil {
+		lookBehind = hasLookBehind(parsed)
+	}
+	return &RegexpIterator[T]{
+		s:          s,
+		find:       find,
+		findAll:    findAll,
+		convert:    convert,
+		prevEnd:    -1,
+		lookBehind: lookBehind,
+	}
+}
+
+// hasLookBehind returns true when the regular expression contains an assertion that inspects the text before the
+// current position, like ^, \A, \b and \B. These assertions would match again at the start of the remainder of a
+// string.
+func hasLookBehind(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpBeginLine, syntax.OpBeginText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	}
+	for _, sub := range re.Sub {
+		if hasLookBehind(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromRegexp creates a RegexpIterator that returns the successive matches of re in s. Each match is only searched
+// when it is requested, instead of finding all matches upfront like FindAllString. A regular expression that inspects
+// the text before a match, like ^, (?m)^, \A, \b and \B, can not be searched on the remainder of s, so its matches
+// are found with FindAllStringIndex on the first call of Next and returned one at a time.
+func FromRegexp(re *regexp.Regexp, s string) *RegexpIterator[string] {
+	return fromRegexp(re, s, re.FindStringIndex, re.FindAllStringIndex, func(s string, loc []int) string {
+		return s[loc[0]:loc[1]]
+	})
+}
+
+// FromRegexpSubmatch works like FromRegexp, but returns the match followed by the matches of the subexpressions, like
+// FindStringSubmatch. A subexpression that did not participate in the match is returned as an empty string.
+func FromRegexpSubmatch(re *regexp.Regexp, s string) *RegexpIterator[[]string] {
+	return fromRegexp(re, s, re.FindStringSubmatchIndex, re.FindAllStringSubmatchIndex, func(s string, loc []int) []string {
+		match := make([]string, len(loc)/2)
+		for i := range match {
+			if loc[2*i] >= 0 {
+				match[i] = s[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		return match
+	})
+}
+
+// DirEntryPath is a file or directory found by WalkDir.
+type DirEntryPath struct {
+	// Path is the path of the entry, which contains the root passed to WalkDir as prefix.
+	Path string
+	// Entry is the fs.DirEntry of the file or directory.
+	Entry fs.DirEntry
+}
+
+// WalkDirOption configures the WalkDirIterator created by WalkDir.
+type WalkDirOption func(*WalkDirIterator)
+
+// WithSkipDir makes WalkDir skip the directories that match the predicate. A skipped directory is not returned and
+// its contents are not read.
+func WithSkipDir(predicate PredicateFunc[DirEntryPath]) WalkDirOption {
+	return func(wi *WalkDirIterator) {
+		wi.skipDir = predicate
+	}
+}
+
+// WalkDirIterator is a struct the implements an Iterable that lazily walks a file tree.
+type WalkDirIterator struct {
+	// fsys is the file system that is walked.
+	fsys fs.FS
+	// pending contains the entries that still need to be returned, the next entry is the last one.
+	pending []DirEntryPath
+	// dir contains the path of the last returned directory, which contents are read on the next call of Next.
+	dir string
+	// skipDir is the predicate that determines if a directory is skipped.
+	skipDir PredicateFunc[DirEntryPath]
+	// done is set when an error has occurred.
+	done bool
+	// err contains the walk error.
+	err error
+}
+
+// Next returns the first or next file or directory and true if an entry is available.
+// The entries are returned in the same lexical order as fs.WalkDir visits them. The contents of a directory are only
+// read when the next entry is requested after the directory was returned.
+// If no more entries are available or an error has occurred then a zero value of DirEntryPath and false is returned.
+func (wi *WalkDirIterator) Next() (DirEntryPath, bool) {
+	if wi.done {
+		return DirEntryPath{}, false
+	}
+	if wi.dir != "" {
+		entries, err := fs.ReadDir(wi.fsys, wi.dir)
+		if err != nil {
+			wi.done = true
+			wi.err = err
+			return DirEntryPath{}, false
+		}
+		for i := len(entries) - 1; i >= 0; i-- {
+			wi.pending = append(wi.pending, DirEntryPath{Path: path.Join(wi.dir, entries[i].Name()), Entry: entries[i]})
+		}
+		wi.dir = ""
+	}
+	for len(wi.pending) > 0 {
+		e := wi.pending[len(wi.pending)-1]
+		wi.pending = wi.pending[:len(wi.pending)-1]
+		if !e.Entry.IsDir() {
+			return e, true
+		}
+		if wi.skipDir == nil || !wi.skipDir(e) {
+			wi.dir = e.Path
+			return e, true
+		}
+	}
+	wi.done = true
+	return DirEntryPath{}, false
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the walk error is returned.
+func (wi *WalkDirIterator) Error() error {
+	return wi.err
+}
+
+// WalkDir creates a WalkDirIterator that returns the files and directories of the file tree rooted at root, including
+// root itself, in the same order as fs.WalkDir. The walk stops at the first error, which is returned by Error.
+func WalkDir(fsys fs.FS, root string, options ...WalkDirOption) *WalkDirIterator {
+	wi := &WalkDirIterator{
+		fsys: fsys,
+	}
+	for _, option := range options {
+		option(wi)
+	}
+	info, err := fs.Stat(fsys, root)
+	if err != nil {
+		wi.done = true
+		wi.err = err
+		return wi
+	}
+	wi.pending = []DirEntryPath{{Path: root, Entry: fs.FileInfoToDirEntry(info)}}
+	return wi
+}
+
+// Algorithms
+// Foreach
+
+// ForEachFunc is the closure type that needs to be provided to ForEach
+type ForEachFunc[T any] func(T)
+
+// ForEach accepts an Iterable and calls the provided ForEachFunc closure with each value.
+// An error is returned when an error during iteration has occurred.
+func ForEach[T any](iter Iterable[T], f ForEachFunc[T]) error {
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		f(v)
+	}
+	return iter.Error()
+}
+
+// ForEachCtx works like ForEach, but stops iterating when the context is cancelled and returns the error of the
+// context. When the Iterable implements CtxIterable a blocking Next is interrupted as well.
+func ForEachCtx[T any](ctx context.Context, iter Iterable[T], f ForEachFunc[T]) error {
+	ci := WithContext(ctx, iter)
+	for v, b := ci.Next(); b; v, b = ci.Next() {
+		f(v)
+	}
+	return ci.Error()
+}
+
+// ForEachIndexedFunc is the closure type that needs to be provided to ForEachIndexed.
+type ForEachIndexedFunc[T any] func(i int, v T)
+
+// ForEachIndexed works like ForEach, but also passes the zero based index of each value to the ForEachIndexedFunc
+// closure.
+func ForEachIndexed[T any](iter Iterable[T], f ForEachIndexedFunc[T]) error {
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		f(i, v)
+		i++
+	}
+	return iter.Error()
+}
+
+// ForEachErrFunc is the closure type that needs to be provided to ForEachErr. A returned error stops the iteration.
+type ForEachErrFunc[T any] func(T) error
+
+// ForEachErr works like ForEach, but stops iterating at the first error returned by the ForEachErrFunc closure. That
+// error is returned wrapped in an IndexError, so it can be distinguished from an error during iteration with
+// errors.As, and the original error is still matched by errors.Is.
+func ForEachErr[T any](iter Iterable[T], f ForEachErrFunc[T]) error {
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if err := f(v); err != nil {
+			return &IndexError{Index: i, Err: err}
+		}
+		i++
+	}
+	return iter.Error()
+}
+
+// ErrStop can be returned by a TryForEachFunc closure to stop the iteration without failing. TryForEach then returns
+// nil.
+var ErrStop = errors.New("iterator: stop")
+
+// TryForEachFunc is the closure type that needs to be provided to TryForEach. It receives the zero based index and
+// the value. A returned error stops the iteration.
+type TryForEachFunc[T any] func(i int, v T) error
+
+// TryForEach works like ForEachErr, but also passes the zero based index of each value to the TryForEachFunc closure.
+// When the closure returns ErrStop, or an error that wraps it, the iteration stops and nil is returned, which works
+// like break in a loop. Any other error of the closure is returned wrapped in an IndexError.
+func TryForEach[T any](iter Iterable[T], f TryForEachFunc[T]) error {
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if err := f(i, v); err != nil {
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+			return &IndexError{Index: i, Err: err}
+		}
+		i++
+	}
+	return iter.Error()
+}
+
+// DrainWithin
+
+// DrainDeadlineError is returned by DrainWithin when the deadline passed before the Iterable was exhausted.
+// It matches context.DeadlineExceeded with errors.Is.
+type DrainDeadlineError struct {
+	// Remaining is the amount of values that were not processed, when RemainingKnown is true.
+	Remaining int
+	// RemainingKnown is true when the Iterable implements SizeHinter, so Remaining is known.
+	RemainingKnown bool
+}
+
+// Error returns a description of the error that includes the amount of remaining values when it is known.
+func (e *DrainDeadlineError) Error() string {
+	if e.RemainingKnown {
+		return fmt.Sprintf("iterator: drain deadline exceeded with %d values remaining", e.Remaining)
+	}
+	return "iterator: drain deadline exceeded"
+}
+
+// Unwrap returns context.DeadlineExceeded.
+func (e *DrainDeadlineError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// DrainWithin accepts an Iterable, a ForEachFunc closure and a deadline and calls the closure with as many values as
+// possible before the deadline passes. It is intended to flush in-flight work during a graceful shutdown. The amount
+// of processed values is returned, together with a DrainDeadlineError when the deadline passed before the Iterable
+// was exhausted. A closure that is running when the deadline passes is not interrupted. An error is returned when an
+// error during iteration has occurred.
+func DrainWithin[T any](iter Iterable[T], f ForEachFunc[T], deadline time.Duration) (processed int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+	ci := WithContext(ctx, iter)
+	for v, b := ci.Next(); b; v, b = ci.Next() {
+		f(v)
+		processed++
+	}
+	if err = ci.Error(); errors.Is(err, context.DeadlineExceeded) {
+		remaining, known := sizeHint(iter)
+		return processed, &DrainDeadlineError{Remaining: remaining, RemainingKnown: known}
+	}
+	return processed, err
+}
+
+// WithContext
+
+// nextCtx calls NextCtx when the Iterable implements CtxIterable, otherwise Next is called.
+func nextCtx[T any](ctx context.Context, iter Iterable[T]) (T, bool) {
+	if ci, ok := iter.(CtxIterable[T]); ok {
+		return ci.NextCtx(ctx)
+	}
+	return iter.Next()
+}
+
+// ContextIterator is a struct the implements an Iterable that stops iterating when a context is cancelled.
+type ContextIterator[T any] struct {
+	// ctx is the context that stops the iteration.
+	ctx context.Context
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// err contains the error of the context after it stopped the iteration.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// When the source Iterable implements CtxIterable, NextCtx is used so a blocking Next is interrupted when the context
+// is cancelled.
+// If no more values are available, the context is cancelled or an error has occurred then a zero value of T and false
+// is returned.
+func (iter *ContextIterator[T]) Next() (T, bool) {
+	var t T
+	if iter.err != nil {
+		return t, false
+	}
+	if err := iter.ctx.Err(); err != nil {
+		iter.err = err
+		return t, false
+	}
+	v, b := nextCtx(iter.ctx, iter.srcItr)
+	if !b {
+		iter.err = iter.ctx.Err()
+	}
+	return v, b
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The error of the context is returned when the context stopped the iteration.
+func (iter *ContextIterator[T]) Error() error {
+	if iter.err != nil {
+		return iter.err
+	}
+	return iter.srcItr.Error()
+}
+
+// WithContext accepts a context and an Iterable and creates a ContextIterator that stops iterating as soon as the
+// context is cancelled.
+func WithContext[T any](ctx context.Context, iter Iterable[T]) *ContextIterator[T] {
+	return &ContextIterator[T]{
+		ctx:    ctx,
+		srcItr: iter,
+	}
+}
+
+// StageError
+
+// StageError is returned by Error of the Map, MapErr, Filter and FilterErr iterators. It wraps the error of the
+// source Iterable or of the closure together with the name of the stage and the zero based index of the element at
+// which the error occurred, so failures deep in long pipelines can be located. Functions built on Map and Filter,
+// like Tag, Sample and MovingAverage, use their own name as stage. When stages are nested, each stage adds its own
+// StageError, and the original error is still matched by errors.Is.
+type StageError struct {
+	// Stage is the name of the stage, like map or filter.
+	Stage string
+	// Index is the zero based index of the element that the stage pulled from its source when the error occurred.
+	Index int
+	// Err is the wrapped error.
+	Err error
+}
+
+// Error returns the stage, index and the message of the wrapped error.
+func (e *StageError) Error() string {
+	return fmt.Sprintf("iterator: %s stage, element %d: %v", e.Stage, e.Index, e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// stageError wraps err in a StageError, or returns nil when err is nil.
+func stageError(stage string, index int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &StageError{Stage: stage, Index: index, Err: err}
+}
+
+// Map
+
+// MapFunc is the closure type that needs to be provided to Map to perform the mapping operation with.
+type MapFunc[T any, R any] func(T) R
+
+// MapIterator is a struct the implements an Iterable that performs the map operation.
+type MapIterator[T any, R any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// mapFunc is the closure that performs the map operation.
+	mapFunc MapFunc[T, R]
+	// stage contains the name of the stage in a StageError.
+	stage string
+	// idx contains the index of the next value of srcItr.
+	idx int
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// Each value is transformed with the provided MapFunc closure.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *MapIterator[T, R]) Next() (R, bool) {
+	return iter.NextCtx(context.Background())
+}
+
+// NextCtx works like Next, but passes the context to the source Iterable when it implements CtxIterable, so a
+// blocking Next of the source is interrupted when the context is cancelled.
+func (iter *MapIterator[T, R]) NextCtx(ctx context.Context) (R, bool) {
+	v, b := nextCtx(ctx, iter.srcItr)
+	if !b {
+		var r R
+		return r, false
+	}
+	iter.idx++
+	return iter.mapFunc(v), true
+}
+
+// SizeHint returns the SizeHint of the source Iterable, because Map returns a value for each value.
+func (iter *MapIterator[T, R]) SizeHint() (int, bool) {
+	return sizeHint(iter.srcItr)
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error of the source Iterable is returned wrapped in a StageError.
+func (iter *MapIterator[T, R]) Error() error {
+	return stageError(iter.stage, iter.idx, iter.srcItr.Error())
+}
+
+// Resume resumes the source Iterable after an error when it implements Resumer.
+func (iter *MapIterator[T, R]) Resume() bool {
+	return resume(iter.srcItr)
+}
+
+// Map accepts an Iterable and MapFunc closure and creates a MapIterator that
+// will perform the map operation on the values of the provided Iterable and
+// returns the transformed values when iterated.
+func Map[T any, R any](iter Iterable[T], f MapFunc[T, R]) *MapIterator[T, R] {
+	return namedMap("map", iter, f)
+}
+
+// namedMap creates a MapIterator that uses the name of the stage in a StageError. The functions that are built on
+// Map use their own name, so an error does not point to a map stage the user did not write.
+func namedMap[T any, R any](stage string, iter Iterable[T], f MapFunc[T, R]) *MapIterator[T, R] {
+	return &MapIterator[T, R]{
+		srcItr:  iter,
+		mapFunc: f,
+		stage:   stage,
+	}
+}
+
+// MapErr
+
+// MapErrFunc is the closure type that needs to be provided to MapErr to perform a mapping operation that can fail.
+type MapErrFunc[T any, R any] func(T) (R, error)
+
+// MapErrIterator is a struct the implements an Iterable that performs a map operation that can fail.
+type MapErrIterator[T any, R any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// mapFunc is the closure that performs the map operation.
+	mapFunc MapErrFunc[T, R]
+	// stage contains the name of the stage in a StageError.
+	stage string
+	// idx contains the index of the next value of srcItr.
+	idx int
+	// err contains the error returned by mapFunc.
+	err error
+}
+
+// Next returns the first or next value of R and true if a value is available.
+// Each value is transformed with the provided MapErrFunc closure. No more values are pulled from the source Iterable
+// after the closure returned an error.
+// If no more values are available or an error has occurred then a zero value of R and false is returned.
+func (iter *MapErrIterator[T, R]) Next() (R, bool) {
+	return iter.NextCtx(context.Background())
+}
+
+// NextCtx works like Next, but passes the context to the source Iterable when it implements CtxIterable, so a
+// blocking Next of the source is interrupted when the context is cancelled.
+func (iter *MapErrIterator[T, R]) NextCtx(ctx context.Context) (R, bool) {
+	var zero R
+	if iter.err != nil {
+		return zero, false
+	}
+	v, b := nextCtx(ctx, iter.srcItr)
+	if !b {
+		return zero, false
+	}
+	r, err := iter.mapFunc(v)
+	if err != nil {
+		iter.err = err
+		return zero, false
+	}
+	iter.idx++
+	return r, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error returned by the MapErrFunc closure or the error of the source Iterable is returned wrapped in a
+// StageError.
+func (iter *MapErrIterator[T, R]) Error() error {
+	if iter.err != nil {
+		return stageError(iter.stage, iter.idx, iter.err)
+	}
+	return stageError(iter.stage, iter.idx, iter.srcItr.Error())
+}
+
+// Resume skips the value for which the MapErrFunc closure returned an error, so the iteration continues with the next
+// value. When the error was caused by the source Iterable, the source is resumed when it implements Resumer.
+func (iter *MapErrIterator[T, R]) Resume() bool {
+	if iter.err != nil {
+		iter.err = nil
+		iter.idx++
+		return true
+	}
+	return resume(iter.srcItr)
+}
+
+// MapErr accepts an Iterable and MapErrFunc closure and creates a MapErrIterator that performs the map operation on
+// the values of the provided Iterable, like Map does. When the closure returns an error the iteration stops and the
+// error is returned by Error, so parsing and conversion steps do not need to panic or return sentinel values.
+func MapErr[T any, R any](iter Iterable[T], f MapErrFunc[T, R]) *MapErrIterator[T, R] {
+	return namedMapErr("map", iter, f)
+}
+
+// namedMapErr creates a MapErrIterator that uses the name of the stage in a StageError, like namedMap does.
+func namedMapErr[T any, R any](stage string, iter Iterable[T], f MapErrFunc[T, R]) *MapErrIterator[T, R] {
+	return &MapErrIterator[T, R]{
+		srcItr:  iter,
+		mapFunc: f,
+		stage:   stage,
+	}
+}
+
+// Filter
+
+// PredicateFunc is the closure type that needs to be provided to Filter to perform the filter operation with.
+// If the predicate returns true the value will be returned, otherwise it will be filtered.
+type PredicateFunc[T any] func(T) bool
+
+// FilterIterator is a struct the implements an Iterable that performs the filter operation.
+type FilterIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// PredicateFunc is the closure that determines is the value needs to be filtered or not.
+	predicate PredicateFunc[T]
+	// stage contains the name of the stage in a StageError.
+	stage string
+	// idx contains the index of the next value of srcItr.
+	idx int
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// Each value is checked against the provided PredicateFunc closure. When false is returned the value will be filtered.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *FilterIterator[T]) Next() (T, bool) {
+	return iter.NextCtx(context.Background())
+}
+
+// NextCtx works like Next, but passes the context to the source Iterable when it implements CtxIterable, so a
+// blocking Next of the source is interrupted when the context is cancelled.
+func (iter *FilterIterator[T]) NextCtx(ctx context.Context) (T, bool) {
+	for v, b := nextCtx(ctx, iter.srcItr); b; v, b = nextCtx(ctx, iter.srcItr) {
+		iter.idx++
+		if iter.predicate(v) {
+			return v, true
+		}
+	}
+	var t T
+	return t, false
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error of the source Iterable is returned wrapped in a StageError.
+func (iter *FilterIterator[T]) Error() error {
+	return stageError(iter.stage, iter.idx, iter.srcItr.Error())
+}
+
+// Resume resumes the source Iterable after an error when it implements Resumer.
+func (iter *FilterIterator[T]) Resume() bool {
+	return resume(iter.srcItr)
+}
+
+// Filter accepts an Iterable and PredicateFunc closure and creates a FilterIterator that
+// will perform the filter operation on the values of the provided Iterable and
+// returns the filtered values when iterated.
+func Filter[T any](iter Iterable[T], predicate PredicateFunc[T]) *FilterIterator[T] {
+	return namedFilter("filter", iter, predicate)
+}
+
+// namedFilter creates a FilterIterator that uses the name of the stage in a StageError, like namedMap does.
+func namedFilter[T any](stage string, iter Iterable[T], predicate PredicateFunc[T]) *FilterIterator[T] {
+	return &FilterIterator[T]{
+		srcItr:    iter,
+		predicate: predicate,
+		stage:     stage,
+	}
+}
+
+// FilterErr
+
+// PredicateErrFunc is the closure type that needs to be provided to FilterErr to perform a filter operation that can
+// fail. If the predicate returns true the value will be returned, otherwise it will be filtered.
+type PredicateErrFunc[T any] func(T) (bool, error)
+
+// FilterErrIterator is a struct the implements an Iterable that performs a filter operation that can fail.
+type FilterErrIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// predicate is the closure that determines if the value needs to be filtered or not.
+	predicate PredicateErrFunc[T]
+	// stage contains the name of the stage in a StageError.
+	stage string
+	// idx contains the index of the next value of srcItr.
+	idx int
+	// err contains the error returned by predicate.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// Each value is checked against the provided PredicateErrFunc closure. When false is returned the value will be
+// filtered. No more values are pulled from the source Iterable after the closure returned an error.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *FilterErrIterator[T]) Next() (T, bool) {
+	return iter.NextCtx(context.Background())
+}
+
+// NextCtx works like Next, but passes the context to the source Iterable when it implements CtxIterable, so a
+// blocking Next of the source is interrupted when the context is cancelled.
+func (iter *FilterErrIterator[T]) NextCtx(ctx context.Context) (T, bool) {
+	var zero T
+	if iter.err != nil {
+		return zero, false
+	}
+	for v, b := nextCtx(ctx, iter.srcItr); b; v, b = nextCtx(ctx, iter.srcItr) {
+		ok, err := iter.predicate(v)
+		if err != nil {
+			iter.err = err
+			return zero, false
+		}
+		iter.idx++
+		if ok {
+			return v, true
+		}
+	}
+	return zero, false
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error returned by the PredicateErrFunc closure or the error of the source Iterable is returned wrapped in a
+// StageError.
+func (iter *FilterErrIterator[T]) Error() error {
+	if iter.err != nil {
+		return stageError(iter.stage, iter.idx, iter.err)
+	}
+	return stageError(iter.stage, iter.idx, iter.srcItr.Error())
+}
+
+// Resume skips the value for which the PredicateErrFunc closure returned an error, so the iteration continues with the
+// next value. When the error was caused by the source Iterable, the source is resumed when it implements Resumer.
+func (iter *FilterErrIterator[T]) Resume() bool {
+	if iter.err != nil {
+		iter.err = nil
+		iter.idx++
+		return true
+	}
+	return resume(iter.srcItr)
+}
+
+// FilterErr accepts an Iterable and PredicateErrFunc closure and creates a FilterErrIterator that performs the filter
+// operation on the values of the provided Iterable, like Filter does. When the closure returns an error the iteration
+// stops and the error is returned by Error, so predicates that perform I/O or validation do not need to swallow
+// failures.
+func FilterErr[T any](iter Iterable[T], predicate PredicateErrFunc[T]) *FilterErrIterator[T] {
+	return namedFilterErr("filter", iter, predicate)
+}
+
+// namedFilterErr creates a FilterErrIterator that uses the name of the stage in a StageError, like namedMap does.
+func namedFilterErr[T any](stage string, iter Iterable[T], predicate PredicateErrFunc[T]) *FilterErrIterator[T] {
+	return &FilterErrIterator[T]{
+		srcItr:    iter,
+		predicate: predicate,
+		stage:     stage,
+	}
+}
+
+// OnError
+
+// ErrorHandlerFunc is the closure type that needs to be provided to OnError. It receives the error of the source
+// Iterable and returns true to skip past the failure, or false to stop the iteration.
+type ErrorHandlerFunc func(err error) (resume bool)
+
+// OnErrorIterator is a struct the implements an Iterable that passes the errors of the source to an ErrorHandlerFunc.
+type OnErrorIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// handler is the closure that decides if the iteration continues after an error.
+	handler ErrorHandlerFunc
+	// err contains the error that stopped the iteration.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// When the source Iterable reports an error the ErrorHandlerFunc closure is called. When it returns true and the
+// source implements Resumer and can be resumed, the iteration continues with the next value of the source.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *OnErrorIterator[T]) Next() (T, bool) {
+	var zero T
+	for iter.err == nil {
+		v, b := iter.srcItr.Next()
+		if b {
+			return v, true
+		}
+		err := iter.srcItr.Error()
+		if err == nil {
+			break
+		}
+		if !iter.handler(err) || !resume(iter.srcItr) {
+			iter.err = err
+		}
+	}
+	return zero, false
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error that stopped the iteration is returned. This is the case when the ErrorHandlerFunc closure returned false,
+// or when the source Iterable could not be resumed.
+func (iter *OnErrorIterator[T]) Error() error {
+	return iter.err
+}
+
+// OnError accepts an Iterable and ErrorHandlerFunc closure and creates an OnErrorIterator that calls the closure for
+// each error of the source Iterable. The closure can stop the iteration, or skip past the failure when the source
+// implements Resumer, like MapErr and FilterErr do. This enables tolerant processing of dirty data, for example to
+// log and skip the lines of a file that can not be parsed. Sources that can not be resumed stop at their first error.
+func OnError[T any](iter Iterable[T], handler ErrorHandlerFunc) *OnErrorIterator[T] {
+	return &OnErrorIterator[T]{
+		srcItr:  iter,
+		handler: handler,
+	}
+}
+
+// Safe
+
+// PanicError is returned by Error of the SafeIterator when a panic was recovered. It contains the value passed to
+// panic and the stack trace of the goroutine at the moment of the panic.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace as formatted by debug.Stack.
+	Stack []byte
+}
+
+// Error returns the message with the value passed to panic.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("iterator: panic: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic when it is an error, otherwise nil.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// SafeIterator is a struct the implements an Iterable that recovers the panics raised while pulling values.
+type SafeIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// err contains the PanicError of the recovered panic.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// A panic raised by the source Iterable, or by a closure it calls on the calling goroutine, is recovered and stops
+// the iteration.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *SafeIterator[T]) Next() (v T, b bool) {
+	if iter.err != nil {
+		return v, false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			iter.err = &PanicError{Value: r, Stack: debug.Stack()}
+			var zero T
+			v, b = zero, false
+		}
+	}()
+	return iter.srcItr.Next()
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// a PanicError is returned when a panic was recovered, or the error of the source Iterable.
+func (iter *SafeIterator[T]) Error() error {
+	if iter.err != nil {
+		return iter.err
+	}
+	return iter.srcItr.Error()
+}
+
+// Safe accepts an Iterable and creates a SafeIterator that recovers the panics raised by closures that Next runs
+// synchronously on the calling goroutine, like those provided to Map and Filter, and converts them into a PanicError
+// with a stack trace, so a single bad element does not crash the whole service. Closures that run on other
+// goroutines, like the workers of ParallelMapKeyed and the fetch closure of FromKeysetPagesPrefetch, are not covered,
+// because a panic can only be recovered on the goroutine that raised it. Closures passed to the functions that
+// consume the Safe iterator, like ForEach and Channel, are not covered either.
+func Safe[T any](iter Iterable[T]) *SafeIterator[T] {
+	return &SafeIterator[T]{
+		srcItr: iter,
+	}
+}
+
+// Sample
+
+// Sample accepts an Iterable, a probability and a rand.Source and creates a FilterIterator that forwards each value
+// independently with the given probability. A probability of 0 or less filters all values, a probability of 1 or more
+// forwards all values.
+func Sample[T any](iter Iterable[T], probability float64, src rand.Source) *FilterIterator[T] {
+	r := rand.New(src)
+	return namedFilter("sample", iter, func(T) bool {
+		return r.Float64() < probability
+	})
+}
+
+// Take
+
+// TakeIterator is a struct the implements an Iterable that returns at most n values.
+type TakeIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// remaining contains the amount of values that may still be returned.
+	remaining int
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// No more values are pulled from the source Iterable after n values have been returned.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *TakeIterator[T]) Next() (T, bool) {
+	if iter.remaining <= 0 {
+		var t T
+		return t, false
+	}
+	iter.remaining--
+	return iter.srcItr.Next()
+}
+
+// SizeHint returns the amount of values that remain to be returned and true, when the source Iterable implements
+// SizeHinter or when no more values may be returned.
+func (iter *TakeIterator[T]) SizeHint() (int, bool) {
+	if iter.remaining <= 0 {
+		return 0, true
+	}
+	n, ok := sizeHint(iter.srcItr)
+	if !ok {
+		return 0, false
+	}
+	if n > iter.remaining {
+		n = iter.remaining
+	}
+	return n, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *TakeIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// Take accepts an Iterable and n and creates a TakeIterator that returns at most the first n values of the provided
+// Iterable. Take makes it possible to use infinite Iterables.
+func Take[T any](iter Iterable[T], n int) *TakeIterator[T] {
+	return &TakeIterator[T]{
+		srcItr:    iter,
+		remaining: n,
+	}
+}
+
+// TakeWhile
+
+// TakeWhileIterator is a struct the implements an Iterable that returns values as long as they match a predicate.
+type TakeWhileIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// predicate is the closure that determines if a value is returned.
+	predicate PredicateFunc[T]
+	// done is set when a value did not match the predicate.
+	done bool
+}
+
+// Next returns the first or next value of T and true if a value is available and matches the predicate.
+// No more values are pulled from the source Iterable after a value did not match the predicate.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *TakeWhileIterator[T]) Next() (T, bool) {
+	var zero T
+	if iter.done {
+		return zero, false
+	}
+	v, ok := iter.srcItr.Next()
+	if !ok || !iter.predicate(v) {
+		iter.done = true
+		return zero, false
+	}
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *TakeWhileIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// TakeWhile accepts an Iterable and a predicate and creates a TakeWhileIterator that returns the values of the
+// provided Iterable until the first value that does not match the predicate. That value is consumed, but not
+// returned. TakeWhile makes it possible to use infinite Iterables.
+func TakeWhile[T any](iter Iterable[T], predicate PredicateFunc[T]) *TakeWhileIterator[T] {
+	return &TakeWhileIterator[T]{
+		srcItr:    iter,
+		predicate: predicate,
+	}
+}
+
+// PadTo
+
+// PadIterator is a struct the implements an Iterable that pads the iteration to a minimum length.
+type PadIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// length contains the amount of values to return at least.
+	length int
+	// fill contains the value that is returned after the source Iterable has been exhausted.
+	fill T
+	// truncate is true when no more than length values may be returned.
+	truncate bool
+	// count contains the amount of values returned.
+	count int
+	// exhausted is true after the source Iterable has been exhausted.
+	exhausted bool
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// After the source Iterable has been exhausted the fill value is returned until length values have been returned.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *PadIterator[T]) Next() (T, bool) {
+	var t T
+	if iter.truncate && iter.count >= iter.length {
+		return t, false
+	}
+	if !iter.exhausted {
+		v, b := iter.srcItr.Next()
+		if b {
+			iter.count++
+			return v, true
+		}
+		iter.exhausted = true
+	}
+	if iter.count >= iter.length || iter.srcItr.Error() != nil {
+		return t, false
+	}
+	iter.count++
+	return iter.fill, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *PadIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// PadTo accepts an Iterable, a length and a fill value and creates a PadIterator that appends the fill value
+// when the provided Iterable returns fewer than length values. Iterables with more values are not truncated.
+func PadTo[T any](iter Iterable[T], length int, fill T) *PadIterator[T] {
+	return &PadIterator[T]{
+		srcItr: iter,
+		length: length,
+		fill:   fill,
+	}
+}
+
+// PadOrTruncate works like PadTo, but also stops after length values, so exactly length values are returned.
+func PadOrTruncate[T any](iter Iterable[T], length int, fill T) *PadIterator[T] {
+	return &PadIterator[T]{
+		srcItr:   iter,
+		length:   length,
+		fill:     fill,
+		truncate: true,
+	}
+}
+
+// Throttle
+
+// ThrottleIterator is a struct the implements an Iterable that returns values at most once per interval.
+type ThrottleIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// interval contains the minimum duration between two values.
+	interval time.Duration
+	// last contains the time the previous value was returned.
+	last time.Time
+	// err contains the error of the context that interrupted NextCtx.
+	err error
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// Next blocks until at least the interval has passed since the previous value was returned.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *ThrottleIterator[T]) Next() (T, bool) {
+	return iter.NextCtx(context.Background())
+}
+
+// NextCtx works like Next, but returns a zero value of T and false when the context is cancelled while waiting.
+// The error of the context is returned by Error afterwards.
+func (iter *ThrottleIterator[T]) NextCtx(ctx context.Context) (T, bool) {
+	var t T
+	v, b := nextCtx(ctx, iter.srcItr)
+	if !b {
+		return t, false
+	}
+	if !iter.last.IsZero() {
+		if wait := time.Until(iter.last.Add(iter.interval)); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				iter.err = ctx.Err()
+				return t, false
+			}
+		}
+	}
+	iter.last = time.Now()
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *ThrottleIterator[T]) Error() error {
+	if iter.err != nil {
+		return iter.err
+	}
+	return iter.srcItr.Error()
+}
+
+// Throttle accepts an Iterable and a minimum interval and creates a ThrottleIterator that returns the values of the
+// provided Iterable at most once per interval. This is useful for paced replay of events and for calling APIs
+// with a rate limit.
+func Throttle[T any](iter Iterable[T], minInterval time.Duration) *ThrottleIterator[T] {
+	return &ThrottleIterator[T]{
+		srcItr:   iter,
+		interval: minInterval,
+	}
+}
+
+// Debounce
+
+// DebounceIterator is a struct the implements an Iterable that only returns a value after no newer value arrived
+// within a quiet period.
+type DebounceIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// quiet contains the duration without newer values after which a value is returned.
+	quiet time.Duration
+	// values receives the values pulled from the source Iterable by a goroutine.
+	values chan T
+	// done is closed to stop the goroutine that pulls values from the source Iterable.
+	done chan struct{}
+	// startOnce starts the goroutine that pulls values from the source Iterable.
+	startOnce sync.Once
+	// stopOnce closes done.
+	stopOnce sync.Once
+	// pending contains the latest value that has not been returned yet.
+	pending T
+	// hasPending is true when pending contains a value.
+	hasPending bool
+	// closed is true after values has been closed.
+	closed bool
+	// err contains the error of the context that interrupted NextCtx.
+	err error
+}
+
+// start starts the goroutine that pulls values from the source Iterable.
+func (iter *DebounceIterator[T]) start() {
+	iter.startOnce.Do(func() {
+		go func() {
+			defer close(iter.values)
+			for v, b := iter.srcItr.Next(); b; v, b = iter.srcItr.Next() {
+				select {
+				case iter.values <- v:
+				case <-iter.done:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// Next blocks until no newer value arrived within the quiet period. Values that are followed by a newer value within
+// the quiet period are dropped. The last value is returned as soon as the source Iterable is exhausted.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *DebounceIterator[T]) Next() (T, bool) {
+	return iter.NextCtx(context.Background())
+}
+
+// NextCtx works like Next, but returns a zero value of T and false when the context is cancelled while waiting.
+// The error of the context is returned by Error afterwards.
+func (iter *DebounceIterator[T]) NextCtx(ctx context.Context) (T, bool) {
+	var t T
+	iter.start()
+	if !iter.hasPending {
+		if iter.closed {
+			return t, false
+		}
+		select {
+		case v, ok := <-iter.values:
+			if !ok {
+				iter.closed = true
+				return t, false
+			}
+			iter.pending, iter.hasPending = v, true
+		case <-ctx.Done():
+			iter.err = ctx.Err()
+			return t, false
+		}
+	}
+	for !iter.closed {
+		timer := time.NewTimer(iter.quiet)
+		select {
+		case v, ok := <-iter.values:
+			timer.Stop()
+			if !ok {
+				iter.closed = true
+				continue
+			}
+			iter.pending = v
+		case <-timer.C:
+			iter.hasPending = false
+			return iter.pending, true
+		case <-ctx.Done():
+			timer.Stop()
+			iter.err = ctx.Err()
+			return t, false
+		}
+	}
+	iter.hasPending = false
+	return iter.pending, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *DebounceIterator[T]) Error() error {
+	if iter.err != nil {
+		return iter.err
+	}
+	if iter.closed {
+		return iter.srcItr.Error()
+	}
+	return nil
+}
+
+// Close stops the goroutine that pulls values from the source Iterable. Close needs to be called when the
+// DebounceIterator is not iterated until Next returns false.
+func (iter *DebounceIterator[T]) Close() error {
+	iter.stopOnce.Do(func() {
+		close(iter.done)
+	})
+	return nil
+}
+
+// Debounce accepts an Iterable and a quiet period and creates a DebounceIterator that only returns a value after no
+// newer value arrived within the quiet period. It is intended for time driven sources, like a ChannelIterator, to
+// smooth bursts of events. The source Iterable is iterated by a goroutine.
+func Debounce[T any](iter Iterable[T], quiet time.Duration) *DebounceIterator[T] {
+	return &DebounceIterator[T]{
+		srcItr: iter,
+		quiet:  quiet,
+		values: make(chan T),
+		done:   make(chan struct{}),
+	}
+}
+
+// WithRemaining
+
+// Counted is a value annotated with its position and, when the amount of values is known, the total amount of values.
+type Counted[T any] struct {
+	Value T
+	// Index is the zero based position of the value.
+	Index int
+	// Remaining is the amount of values that follow this value. It is only valid when Known is true.
+	Remaining int
+	// Total is the total amount of values. It is only valid when Known is true.
+	Total int
+	// Known is true when the source Iterable provided a SizeHint.
+	Known bool
+}
+
+// Percent returns the percentage of values processed including this value, or 0 when the total is unknown.
+func (c Counted[T]) Percent() float64 {
+	if !c.Known || c.Total == 0 {
+		return 0
+	}
+	return float64(c.Index+1) * 100 / float64(c.Total)
+}
+
+// WithRemaining accepts an Iterable and creates a MapIterator that annotates each value with its position and,
+// when the provided Iterable implements SizeHinter, the remaining and total amount of values.
+func WithRemaining[T any](iter Iterable[T]) *MapIterator[T, Counted[T]] {
+	i := -1
+	return namedMap("with remaining", iter, func(v T) Counted[T] {
+		i++
+		c := Counted[T]{Value: v, Index: i}
+		if remaining, ok := sizeHint(iter); ok {
+			c.Remaining, c.Total, c.Known = remaining, i+1+remaining, true
+		}
+		return c
+	})
+}
+
+// DistinctWithLimit
+
+// DistinctIterator is a struct the implements an Iterable that drops values that have been returned before, while
+// tracking a bounded amount of values.
+type DistinctIterator[T comparable] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// maxTracked contains the maximum amount of tracked values.
+	maxTracked int
+	// seen maps tracked values to their element in lru.
+	seen map[T]*list.Element
+	// lru contains the tracked values, the most recently seen value at the front.
+	lru *list.List
+	// evicted contains the amount of values that have been evicted.
+	evicted int
+}
+
+// Next returns the first or next value of T that has not been seen recently and true if a value is available.
+// When more than maxTracked values are tracked the least recently seen value is evicted, so it is returned again
+// when it reappears.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *DistinctIterator[T]) Next() (T, bool) {
+	for v, b := iter.srcItr.Next(); b; v, b = iter.srcItr.Next() {
+		if e, ok := iter.seen[v]; ok {
+			iter.lru.MoveToFront(e)
+			continue
+		}
+		iter.seen[v] = iter.lru.PushFront(v)
+		if iter.lru.Len() > iter.maxTracked {
+			oldest := iter.lru.Back()
+			iter.lru.Remove(oldest)
+			delete(iter.seen, oldest.Value.(T))
+			iter.evicted++
+		}
+		return v, true
+	}
+	var t T
+	return t, false
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *DistinctIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// Evicted returns the amount of values that have been evicted from the tracked values. When it is not zero,
+// duplicates may have been returned.
+func (iter *DistinctIterator[T]) Evicted() int {
+	return iter.evicted
+}
+
+// DistinctWithLimit accepts an Iterable and the maximum amount of tracked values and creates a DistinctIterator that
+// drops duplicate values. At most maxTracked values are remembered, so memory usage is bounded for infinite
+// Iterables. DistinctWithLimit panics when maxTracked is not positive.
+func DistinctWithLimit[T comparable](iter Iterable[T], maxTracked int) *DistinctIterator[T] {
+	if maxTracked <= 0 {
+		panic("iterator: maxTracked must be positive")
+	}
+	return &DistinctIterator[T]{
+		srcItr:     iter,
+		maxTracked: maxTracked,
+		seen:       map[T]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// ParallelMapKeyed
+
+// parallelResult contains the result of a mapping operation executed by a ParallelMapKeyedIterator.
+type parallelResult[U any] struct {
+	value U
+	err   error
+}
+
+// ParallelMapKeyedIterator is a struct the implements an Iterable that performs a mapping operation that can fail
+// concurrently, while limiting the amount of concurrent operations per key.
+type ParallelMapKeyedIterator[T any, U any, K comparable] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// key is the closure that returns the key of a value.
+	key func(T) K
+	// perKey contains the maximum amount of concurrent operations per key.
+	perKey int
+	// total contains the maximum amount of concurrent operations.
+	total int
+	// f is the closure that performs the mapping operation.
+	f func(T) (U, error)
+	// results receives the results of the mapping operations.
+	results chan parallelResult[U]
+	// done is closed to stop the goroutines.
+	done chan struct{}
+	// startOnce starts the goroutine that dispatches the values.
+	startOnce sync.Once
+	// stopOnce stops the goroutines.
+	stopOnce sync.Once
+	// mu guards running, inflight and stopped.
+	mu sync.Mutex
+	// cond is signalled when an operation completes or the iterator is stopped.
+	cond *sync.Cond
+	// running contains the amount of running operations.
+	running int
+	// inflight contains the amount of running operations per key.
+	inflight map[K]int
+	// stopped is true after the iterator has been stopped.
+	stopped bool
+	// srcErr contains the error of the source Iterable. It is written before results is closed.
+	srcErr error
+	// err contains the error that ended the iteration.
+	err error
+}
+
+// dispatch pulls the values from the source Iterable and starts an operation for each value as soon as the limits
+// allow it.
+func (iter *ParallelMapKeyedIterator[T, U, K]) dispatch() {
+	var wg sync.WaitGroup
+	for v, b := iter.srcItr.Next(); b; v, b = iter.srcItr.Next() {
+		k := iter.key(v)
+		iter.mu.Lock()
+		for !iter.stopped && (iter.running >= iter.total || iter.inflight[k] >= iter.perKey) {
+			iter.cond.Wait()
+		}
+		if iter.stopped {
+			iter.mu.Unlock()
+			break
+		}
+		iter.running++
+		iter.inflight[k]++
+		iter.mu.Unlock()
+
+		wg.Add(1)
+		go func(v T, k K) {
+			defer wg.Done()
+			u, err := iter.f(v)
+			select {
+			case iter.results <- parallelResult[U]{value: u, err: err}:
+			case <-iter.done:
+			}
+			iter.mu.Lock()
+			iter.running--
+			if iter.inflight[k]--; iter.inflight[k] == 0 {
+				delete(iter.inflight, k)
+			}
+			iter.cond.Broadcast()
+			iter.mu.Unlock()
+		}(v, k)
+	}
+	wg.Wait()
+	iter.srcErr = iter.srcItr.Error()
+	close(iter.results)
+}
+
+// stop stops dispatching values and unblocks the running operations.
+func (iter *ParallelMapKeyedIterator[T, U, K]) stop() {
+	iter.stopOnce.Do(func() {
+		close(iter.done)
+		iter.mu.Lock()
+		iter.stopped = true
+		iter.cond.Broadcast()
+		iter.mu.Unlock()
+	})
+}
+
+// Next returns the first or next mapped value and true if a value is available.
+// The values are returned in the order in which the operations complete. The iteration stops at the first error
+// returned by the mapping closure.
+// If no more values are available or an error has occurred then a zero value of U and false is returned.
+func (iter *ParallelMapKeyedIterator[T, U, K]) Next() (U, bool) {
+	var u U
+	if iter.err != nil {
+		return u, false
+	}
+	iter.startOnce.Do(func() {
+		go iter.dispatch()
+	})
+	r, ok := <-iter.results
+	if !ok {
+		iter.err = iter.srcErr
+		return u, false
+	}
+	if r.err != nil {
+		iter.err = r.err
+		iter.stop()
+		return u, false
+	}
+	return r.value, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the first error of the mapping closure or the error of the source Iterable is returned.
+func (iter *ParallelMapKeyedIterator[T, U, K]) Error() error {
+	return iter.err
+}
+
+// Close stops dispatching values and releases the goroutines. Close needs to be called when the
+// ParallelMapKeyedIterator is not iterated until Next returns false.
+func (iter *ParallelMapKeyedIterator[T, U, K]) Close() error {
+	iter.stop()
+	return nil
+}
+
+// ParallelMapKeyed accepts an Iterable, a key closure, the maximum amount of concurrent operations per key and in
+// total, and a mapping closure that can fail. It creates a ParallelMapKeyedIterator that executes the mapping closure
+// in goroutines, with at most perKey operations for the same key and at most total operations at the same time.
+// This is required when the mapping closure mutates state per entity. Limits smaller than 1 are treated as 1.
+// A value whose key has reached its limit holds back the values that follow it.
+func ParallelMapKeyed[T any, U any, K comparable](iter Iterable[T], key func(T) K, perKey, total int, f func(T) (U, error)) *ParallelMapKeyedIterator[T, U, K] {
+	if perKey < 1 {
+		perKey = 1
+	}
+	if total < 1 {
+		total = 1
+	}
+	pi := &ParallelMapKeyedIterator[T, U, K]{
+		srcItr:   iter,
+		key:      key,
+		perKey:   perKey,
+		total:    total,
+		f:        f,
+		results:  make(chan parallelResult[U]),
+		done:     make(chan struct{}),
+		inflight: map[K]int{},
+	}
+	pi.cond = sync.NewCond(&pi.mu)
+	return pi
+}
+
+// RoundRobin
+
+// MultiError is an error that contains the errors of multiple Iterables.
+type MultiError []error
+
+// Error returns the messages of all errors separated by a semicolon.
+func (e MultiError) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the contained errors.
+func (e MultiError) Unwrap() []error {
+	return e
+}
+
+// Is reports whether any of the contained errors matches target.
+func (e MultiError) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first contained error that matches target, and if so, sets target to that error and returns true.
+func (e MultiError) As(target any) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundRobinIterator is a struct the implements an Iterable that interleaves the values of multiple Iterables.
+type RoundRobinIterator[T any] struct {
+	// active contains the Iterables that are not exhausted yet.
+	active []Iterable[T]
+	// pos has the position in active of the Iterable to pull the next value from.
+	pos int
+	// errs contains the errors of the exhausted Iterables.
+	errs MultiError
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// A value is pulled from each Iterable in turn. Exhausted Iterables are skipped.
+// If no more values are available then a zero value of T and false is returned.
+func (iter *RoundRobinIterator[T]) Next() (T, bool) {
+	for len(iter.active) > 0 {
+		src := iter.active[iter.pos]
+		if v, b := src.Next(); b {
+			iter.pos = (iter.pos + 1) % len(iter.active)
+			return v, true
+		}
+		if err := src.Error(); err != nil {
+			iter.errs = append(iter.errs, err)
+		}
+		iter.active = append(iter.active[:iter.pos], iter.active[iter.pos+1:]...)
+		if iter.pos >= len(iter.active) {
+			iter.pos = 0
+		}
+	}
+	var t T
+	return t, false
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. When multiple Iterables returned an error a MultiError is returned.
+func (iter *RoundRobinIterator[T]) Error() error {
+	switch len(iter.errs) {
+	case 0:
+		return nil
+	case 1:
+		return iter.errs[0]
+	}
+	return iter.errs
+}
+
+// RoundRobin accepts Iterables and creates a RoundRobinIterator that returns a value of each Iterable in turn,
+// skipping exhausted Iterables, until all Iterables are exhausted. An Iterable that fails is treated as exhausted
+// and its error is returned by Error after the iteration has completed.
+func RoundRobin[T any](iters ...Iterable[T]) *RoundRobinIterator[T] {
+	return &RoundRobinIterator[T]{
+		active: append([]Iterable[T](nil), iters...),
+	}
+}
+
+// Split
+
+// SplitIterator is a struct the implements an Iterable that performs the split operation.
+type SplitIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// isDelim is the closure that determines if a value is a delimiter.
+	isDelim PredicateFunc[T]
+	// delimited is true when the previous group was ended by a delimiter, so another group follows.
+	delimited bool
+	// done is true when the source Iterable has been exhausted.
+	done bool
+}
+
+// Next returns the first or next group of values and true if a group is available.
+// Values are collected until a value is encountered for which the PredicateFunc closure returns true. The delimiter
+// itself is dropped. Like strings.Split, consecutive delimiters or a delimiter at the start or end of the
+// iteration result in empty groups. An empty source does not return any group.
+// If no more values are available or an error has occurred then nil and false is returned.
+func (iter *SplitIterator[T]) Next() ([]T, bool) {
+	if iter.done {
+		return nil, false
+	}
+	group := []T{}
+	started := iter.delimited
+	for v, b := iter.srcItr.Next(); b; v, b = iter.srcItr.Next() {
+		started = true
+		if iter.isDelim(v) {
+			iter.delimited = true
+			return group, true
+		}
+		group = append(group, v)
+	}
+	iter.done = true
+	if !started || iter.srcItr.Error() != nil {
+		return nil, false
+	}
+	return group, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *SplitIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// Split accepts an Iterable and PredicateFunc closure and creates a SplitIterator that
+// groups the values of the provided Iterable into slices separated by the values for which the
+// PredicateFunc closure returns true. Unlike strings.Split, which returns one empty string for an empty string, an
+// empty Iterable results in no groups at all.
+func Split[T any](iter Iterable[T], isDelim PredicateFunc[T]) *SplitIterator[T] {
+	return &SplitIterator[T]{
+		srcItr:  iter,
+		isDelim: isDelim,
+	}
+}
+
+// ChunkByWeight
+
+// ChunkByWeightIterator is a struct the implements an Iterable that packs values into chunks with a limited total
+// weight.
+type ChunkByWeightIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// weigh is the closure that returns the weight of a value.
+	weigh func(T) int
+	// maxWeight is the maximum total weight of a chunk.
+	maxWeight int
+	// pending is the value that did not fit in the previous chunk.
+	pending T
+	// hasPending is true when pending contains a value.
+	hasPending bool
+	// done is true when the source Iterable has been exhausted.
+	done bool
+}
+
+// Next returns the first or next chunk of values and true if a chunk is available.
+// Values are added to the chunk as long as the total weight does not exceed the maximum weight. A value that weighs
+// more than the maximum weight on its own is returned as a chunk with a single value.
+// If no more values are available or an error has occurred then nil and false is returned.
+func (iter *ChunkByWeightIterator[T]) Next() ([]T, bool) {
+	var chunk []T
+	weight := 0
+	if iter.hasPending {
+		var zero T
+		chunk = append(chunk, iter.pending)
+		weight = iter.weigh(iter.pending)
+		iter.pending, iter.hasPending = zero, false
+	}
+	for !iter.done && weight < iter.maxWeight {
+		v, b := iter.srcItr.Next()
+		if !b {
+			iter.done = true
+			break
+		}
+		w := iter.weigh(v)
+		if len(chunk) > 0 && weight+w > iter.maxWeight {
+			iter.pending, iter.hasPending = v, true
+			break
+		}
+		chunk = append(chunk, v)
+		weight += w
+	}
+	if len(chunk) == 0 || (iter.done && iter.srcItr.Error() != nil) {
+		return nil, false
+	}
+	return chunk, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *ChunkByWeightIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// ChunkByWeight accepts an Iterable, a weigh closure and a maximum weight and creates a ChunkByWeightIterator that
+// packs consecutive values into chunks whose total weight does not exceed maxWeight, like batches of requests that
+// must stay below a payload size. ChunkByWeight panics when maxWeight is not positive.
+func ChunkByWeight[T any](iter Iterable[T], weigh func(T) int, maxWeight int) *ChunkByWeightIterator[T] {
+	if maxWeight <= 0 {
+		panic("iterator: maxWeight must be positive")
+	}
+	return &ChunkByWeightIterator[T]{
+		srcItr:    iter,
+		weigh:     weigh,
+		maxWeight: maxWeight,
+	}
+}
+
+// FromBatches
+
+// BatchIterator is a struct the implements an Iterable that returns the values of the slices returned by an Iterable
+// of slices one by one.
+type BatchIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the batches from.
+	srcItr Iterable[[]T]
+	// batch contains the current batch.
+	batch []T
+	// idx has the position in batch.
+	idx int
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// A new batch is pulled from the source Iterable when all values of the current batch have been returned, empty
+// batches are skipped.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *BatchIterator[T]) Next() (T, bool) {
+	for iter.idx >= len(iter.batch) {
+		batch, ok := iter.srcItr.Next()
+		if !ok {
+			var zero T
+			iter.batch, iter.idx = nil, 0
+			return zero, false
+		}
+		iter.batch, iter.idx = batch, 0
+	}
+	iter.idx++
+	return iter.batch[iter.idx-1], true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *BatchIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// FromBatches accepts an Iterable of slices and creates a BatchIterator that flattens the slices into their values,
+// which is the inverse of ChunkByWeight. Each value is copied out of the current slice when it is returned and the
+// slice is released before the next slice is pulled, so the source Iterable may reuse its buffer for the next batch.
+func FromBatches[T any](iter Iterable[[]T]) *BatchIterator[T] {
+	return &BatchIterator[T]{
+		srcItr: iter,
+	}
+}
+
+// Replayable
+
+// ReplayIterator is a struct the implements an Iterable that records the values it pulls from another Iterable, so
+// they can be iterated again after Restart is called.
+type ReplayIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// recorded contains all values that have been pulled from srcItr.
+	recorded []T
+	// idx has the position in recorded of the next value.
+	idx int
+	// exhausted is set when srcItr returned false.
+	exhausted bool
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// Recorded values are returned first, values are only pulled from the source Iterable after all recorded values have
+// been returned.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *ReplayIterator[T]) Next() (T, bool) {
+	if iter.idx < len(iter.recorded) {
+		iter.idx++
+		return iter.recorded[iter.idx-1], true
+	}
+	if iter.exhausted {
+		var zero T
+		return zero, false
+	}
+	v, ok := iter.srcItr.Next()
+	if !ok {
+		iter.exhausted = true
+		return v, false
+	}
+	iter.recorded = append(iter.recorded, v)
+	iter.idx++
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// the error of the source Iterable is returned, also while the recorded values are replayed.
+func (iter *ReplayIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// Restart makes the iteration start again at the first value. The recorded values are returned again, and when the
+// source Iterable was not exhausted yet, the iteration continues with its remaining values afterwards.
+func (iter *ReplayIterator[T]) Restart() {
+	iter.idx = 0
+}
+
+// Replayable accepts an Iterable and creates a ReplayIterator that records all values pulled from it, so a
+// non-repeatable source can be consumed more than once by calling Restart. All values are kept in memory for the
+// lifetime of the ReplayIterator.
+func Replayable[T any](iter Iterable[T]) *ReplayIterator[T] {
+	return &ReplayIterator[T]{
+		srcItr: iter,
+	}
+}
+
+// DiffSorted
+
+// CompareFunc is the closure type that returns a negative number when a is ordered before b, a positive number when
+// a is ordered after b and zero when a and b are ordered equally.
+type CompareFunc[T any] func(a, b T) int
+
+// EqualFunc is the closure type that reports whether a and b are equal.
+type EqualFunc[T any] func(a, b T) bool
+
+// ChangeKind describes how a value differs between two sorted Iterables.
+type ChangeKind int
+
+const (
+	// Unchanged is used when the value is found in both Iterables.
+	Unchanged ChangeKind = iota
+	// Added is used when the value is only found in the new Iterable.
+	Added
+	// Removed is used when the value is only found in the old Iterable.
+	Removed
+	// Modified is used when the value is found in both Iterables, but the EqualFunc closure reports a difference.
+	Modified
+)
+
+// String returns the name of the ChangeKind.
+func (k ChangeKind) String() string {
+	switch k {
+	case Unchanged:
+		return "Unchanged"
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Modified:
+		return "Modified"
+	}
+	return "Unknown"
+}
+
+// Change is a record returned by the DiffIterator. Old contains the value from the old Iterable and is the
+// zero value of T when Kind is Added. New contains the value from the new Iterable and is the zero value of T when
+// Kind is Removed.
+type Change[T any] struct {
+	Kind ChangeKind
+	Old  T
+	New  T
+}
+
+// DiffIterator is a struct the implements an Iterable that compares two sorted Iterables.
+type DiffIterator[T any] struct {
+	// oldItr is the Iterable with the old values.
+	oldItr Iterable[T]
+	// newItr is the Iterable with the new values.
+	newItr Iterable[T]
+	// cmp is the closure that determines the order of the values.
+	cmp CompareFunc[T]
+	// equal is the closure that determines if values that are ordered equally are modified. May be nil.
+	equal EqualFunc[T]
+	// oldVal and oldOk contain the current value of oldItr.
+	oldVal T
+	oldOk  bool
+	// newVal and newOk contain the current value of newItr.
+	newVal T
+	newOk  bool
+	// primed is true after the first values have been pulled from both Iterables.
+	primed bool
+}
+
+// Next returns the first or next Change and true if a Change is available.
+// Both Iterables must be sorted in the order defined by the CompareFunc closure. Only one value of each Iterable is
+// held in memory at a time.
+// If no more values are available or an error has occurred then a zero value of Change and false is returned.
+func (iter *DiffIterator[T]) Next() (Change[T], bool) {
+	if !iter.primed {
+		iter.oldVal, iter.oldOk = iter.oldItr.Next()
+		iter.newVal, iter.newOk = iter.newItr.Next()
+		iter.primed = true
+	}
+	if iter.Error() != nil {
+		return Change[T]{}, false
+	}
+	var c Change[T]
+	switch {
+	case iter.oldOk && iter.newOk:
+		order := iter.cmp(iter.oldVal, iter.newVal)
+		if order < 0 {
+			c = Change[T]{Kind: Removed, Old: iter.oldVal}
+			iter.oldVal, iter.oldOk = iter.oldItr.Next()
+		} else if order > 0 {
+			c = Change[T]{Kind: Added, New: iter.newVal}
+			iter.newVal, iter.newOk = iter.newItr.Next()
+		} else {
+			c = Change[T]{Kind: Unchanged, Old: iter.oldVal, New: iter.newVal}
+			if iter.equal != nil && !iter.equal(iter.oldVal, iter.newVal) {
+				c.Kind = Modified
+			}
+			iter.oldVal, iter.oldOk = iter.oldItr.Next()
+			iter.newVal, iter.newOk = iter.newItr.Next()
+		}
+	case iter.oldOk:
+		c = Change[T]{Kind: Removed, Old: iter.oldVal}
+		iter.oldVal, iter.oldOk = iter.oldItr.Next()
+	case iter.newOk:
+		c = Change[T]{Kind: Added, New: iter.newVal}
+		iter.newVal, iter.newOk = iter.newItr.Next()
+	default:
+		return Change[T]{}, false
+	}
+	return c, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The error of the old Iterable takes precedence over the error of the new Iterable.
+func (iter *DiffIterator[T]) Error() error {
+	if err := iter.oldItr.Error(); err != nil {
+		return err
+	}
+	return iter.newItr.Error()
+}
+
+// DiffSorted accepts an old and new Iterable, both sorted in the order defined by the CompareFunc closure, and creates
+// a DiffIterator that lazily returns a Change for every value, marking it as Added, Removed or Unchanged.
+func DiffSorted[T any](oldItr, newItr Iterable[T], cmp CompareFunc[T]) *DiffIterator[T] {
+	return DiffSortedFunc(oldItr, newItr, cmp, nil)
+}
+
+// DiffSortedFunc works like DiffSorted, but values that are ordered equally are also checked with the EqualFunc
+// closure. When the EqualFunc closure returns false the Change is marked as Modified instead of Unchanged.
+func DiffSortedFunc[T any](oldItr, newItr Iterable[T], cmp CompareFunc[T], equal EqualFunc[T]) *DiffIterator[T] {
+	return &DiffIterator[T]{
+		oldItr: oldItr,
+		newItr: newItr,
+		cmp:    cmp,
+		equal:  equal,
+	}
+}
+
+// Tagged
+
+// Tagged is an envelope that carries metadata, such as the source file, line number or a trace ID, alongside a value
+// through the stages of a pipeline.
+type Tagged[T any] struct {
+	Value T
+	Meta  map[string]any
+}
+
+// TagFunc is the closure type that needs to be provided to Tag. It receives the zero based index and the value and
+// returns the metadata for the value.
+type TagFunc[T any] func(i int, v T) map[string]any
+
+// Tag accepts an Iterable and TagFunc closure and creates a MapIterator that wraps each value in a Tagged envelope
+// with the metadata returned by the TagFunc closure.
+func Tag[T any](iter Iterable[T], f TagFunc[T]) *MapIterator[T, Tagged[T]] {
+	i := -1
+	return namedMap("tag", iter, func(v T) Tagged[T] {
+		i++
+		return Tagged[T]{Value: v, Meta: f(i, v)}
+	})
+}
+
+// MapTagged accepts an Iterable of Tagged values and MapFunc closure and creates a MapIterator that
+// performs the map operation on the wrapped values while keeping the metadata.
+func MapTagged[T any, R any](iter Iterable[Tagged[T]], f MapFunc[T, R]) *MapIterator[Tagged[T], Tagged[R]] {
+	return namedMap("map tagged", iter, func(v Tagged[T]) Tagged[R] {
+		return Tagged[R]{Value: f(v.Value), Meta: v.Meta}
+	})
+}
+
+// FilterTagged accepts an Iterable of Tagged values and PredicateFunc closure and creates a FilterIterator that
+// performs the filter operation on the wrapped values while keeping the metadata.
+func FilterTagged[T any](iter Iterable[Tagged[T]], predicate PredicateFunc[T]) *FilterIterator[Tagged[T]] {
+	return namedFilter("filter tagged", iter, func(v Tagged[T]) bool {
+		return predicate(v.Value)
+	})
+}
+
+// Untag accepts an Iterable of Tagged values and creates a MapIterator that returns the wrapped values without
+// the metadata.
+func Untag[T any](iter Iterable[Tagged[T]]) *MapIterator[Tagged[T], T] {
+	return namedMap("untag", iter, func(v Tagged[T]) T {
+		return v.Value
+	})
+}
+
+// PartitionConsistent
+
+// Partitioned is a value annotated with the index of the partition it belongs to.
+type Partitioned[T any] struct {
+	Partition int
+	Value     T
+}
+
+// jumpHash maps key to a bucket in the range [0, buckets) with the jump consistent hash algorithm of Lamping and
+// Veach. When the amount of buckets grows from n to n+1 only 1/(n+1) of the keys move to another bucket.
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// consistentPartition returns the partition of the key using a consistent hash.
+func consistentPartition(key string, partitions int) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return jumpHash(h.Sum64(), partitions)
+}
+
+// PartitionConsistent accepts an Iterable, a key closure and the amount of partitions and creates a MapIterator
+// that annotates each value with a partition index in the range [0, partitions). The partition is determined by a
+// consistent hash of the key, so equal keys always end up in the same partition and only a small part of the keys
+// move to another partition when the amount of partitions changes. PartitionConsistent panics when partitions is
+// not positive.
+func PartitionConsistent[T any](iter Iterable[T], key func(T) string, partitions int) *MapIterator[T, Partitioned[T]] {
+	if partitions <= 0 {
+		panic("iterator: partitions must be positive")
+	}
+	return namedMap("partition consistent", iter, func(v T) Partitioned[T] {
+		return Partitioned[T]{Partition: consistentPartition(key(v), partitions), Value: v}
+	})
+}
+
+// ErrNoChannels is returned by FanOutConsistent when no channels are passed to send the values to.
+var ErrNoChannels = errors.New("iterator: no channels")
+
+// FanOutConsistent accepts an Iterable, a key closure and a channel per partition and sends each value to the channel
+// of the partition determined by PartitionConsistent. The channels are not closed.
+// ErrNoChannels is returned without pulling any values when channels is empty. An error is returned when an error
+// during iteration has occurred.
+func FanOutConsistent[T any](iter Iterable[T], key func(T) string, channels []chan<- T) error {
+	if len(channels) == 0 {
+		return ErrNoChannels
+	}
+	pi := PartitionConsistent(iter, key, len(channels))
+	for v, b := pi.Next(); b; v, b = pi.Next() {
+		channels[v.Partition] <- v.Value
+	}
+	return pi.Error()
+}
+
+// MovingAggregate
+
+// MovingAggregateIterator is a struct the implements an Iterable that performs a rolling aggregation over a window
+// of values.
+type MovingAggregateIterator[T any, A any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// window contains the values that are part of the current aggregate, used as a ring buffer.
+	window []T
+	// head is the position of the oldest value in the window once the window is full.
+	head int
+	// size is the maximum amount of values in the window.
+	size int
+	// acc contains the current aggregate.
+	acc A
+	// add is the closure that adds a value to the aggregate.
+	add ReduceFunc[T, A]
+	// evict is the closure that removes a value from the aggregate.
+	evict ReduceFunc[T, A]
+}
+
+// Next returns the aggregate of the current window and true if a value is available.
+// Each value is added to the aggregate. When the window is full the oldest value is evicted from the aggregate.
+// If no more values are available or an error has occurred then a zero value of A and false is returned.
+func (iter *MovingAggregateIterator[T, A]) Next() (A, bool) {
+	v, b := iter.srcItr.Next()
+	if !b {
+		var a A
+		return a, false
+	}
+	iter.acc = iter.add(iter.acc, v)
+	if len(iter.window) < iter.size {
+		iter.window = append(iter.window, v)
+		return iter.acc, true
+	}
+	iter.acc = iter.evict(iter.acc, iter.window[iter.head])
+	iter.window[iter.head] = v
+	iter.head = (iter.head + 1) % iter.size
+	return iter.acc, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *MovingAggregateIterator[T, A]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// MovingAggregate accepts an Iterable, a window size, an init value and two ReduceFunc closures, and creates a
+// MovingAggregateIterator that returns the aggregate of the last window values for each value. The add closure
+// adds a value to the aggregate and the evict closure removes a value that left the window from the aggregate.
+// MovingAggregate panics when window is not positive.
+func MovingAggregate[T any, A any](iter Iterable[T], window int, init A, add ReduceFunc[T, A], evict ReduceFunc[T, A]) *MovingAggregateIterator[T, A] {
+	if window <= 0 {
+		panic("iterator: window must be positive")
+	}
+	return &MovingAggregateIterator[T, A]{
+		srcItr: iter,
+		size:   window,
+		acc:    init,
+		add:    add,
+		evict:  evict,
+	}
+}
+
+// MovingAverage accepts an Iterable of numbers and a window size and creates a MapIterator that returns the average
+// of the last window values for each value. MovingAverage panics when window is not positive.
+func MovingAverage[T Number](iter Iterable[T], window int) *MapIterator[float64, float64] {
+	sum := MovingAggregate(iter, window, 0.0, func(a float64, v T) float64 {
+		return a + float64(v)
+	}, func(a float64, v T) float64 {
+		return a - float64(v)
+	})
+	n := 0
+	return namedMap[float64]("moving average", sum, func(a float64) float64 {
+		if n < window {
+			n++
+		}
+		return a / float64(n)
+	})
+}
+
+// Delta
+
+// DeltaIterator is a struct the implements an Iterable that returns the difference between successive values.
+type DeltaIterator[T Number] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// previous contains the previous value of the source Iterable.
+	previous T
+	// started is true after the first value has been pulled from the source Iterable.
+	started bool
+}
+
+// Next returns the difference between the next value and its predecessor and true if a value is available.
+// The first value of the source Iterable has no predecessor, so n values result in n-1 differences.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *DeltaIterator[T]) Next() (T, bool) {
+	if !iter.started {
+		v, b := iter.srcItr.Next()
+		if !b {
+			return v, false
+		}
+		iter.previous = v
+		iter.started = true
+	}
+	v, b := iter.srcItr.Next()
+	if !b {
+		var t T
+		return t, false
+	}
+	d := v - iter.previous
+	iter.previous = v
+	return d, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *DeltaIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// Delta accepts an Iterable of numbers and creates a DeltaIterator that returns the difference between each value
+// and its predecessor.
+func Delta[T Number](iter Iterable[T]) *DeltaIterator[T] {
+	return &DeltaIterator[T]{
+		srcItr: iter,
+	}
+}
+
+// Rank
+
+// Ranked is a value annotated with its rank. The first rank is 1.
+type Ranked[T any] struct {
+	Rank  int
+	Value T
+}
+
+// RankIterator is a struct the implements an Iterable that returns values annotated with their rank.
+type RankIterator[T any] struct {
+	// srcItr is the Iterable this iterator pulls the original values from.
+	srcItr Iterable[T]
+	// less is the closure that determines the order of the values.
+	less LessFunc[T]
+	// dense is true when equal values do not leave gaps in the ranking.
+	dense bool
+	// ranked contains the buffered and ranked values.
+	ranked []Ranked[T]
+	// idx has the position in ranked
+	idx int
+	// buffered is true after the values of the source Iterable have been buffered.
+	buffered bool
+}
+
+// buffer drains the source Iterable, sorts the values and assigns the ranks.
+func (iter *RankIterator[T]) buffer() {
+	iter.buffered = true
+	values, err := ToSlice(iter.srcItr)
+	if err != nil {
+		return
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return iter.less(values[i], values[j])
+	})
+	iter.ranked = make([]Ranked[T], len(values))
+	rank := 0
+	for i, v := range values {
+		if i == 0 || iter.less(values[i-1], v) {
+			if iter.dense {
+				rank++
+			} else {
+				rank = i + 1
+			}
+		}
+		iter.ranked[i] = Ranked[T]{Rank: rank, Value: v}
+	}
+}
+
+// Next returns the first or next ranked value and true if a value is available.
+// All values of the source Iterable are buffered and sorted when Next is called the first time. The values are
+// returned in the order defined by the LessFunc closure, so the value with rank 1 is returned first.
+// If no more values are available or an error has occurred then a zero value of Ranked and false is returned.
+func (iter *RankIterator[T]) Next() (Ranked[T], bool) {
+	if !iter.buffered {
+		iter.buffer()
+	}
+	if iter.idx >= len(iter.ranked) {
+		return Ranked[T]{}, false
+	}
+	iter.idx++
+	return iter.ranked[iter.idx-1], true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned.
+func (iter *RankIterator[T]) Error() error {
+	return iter.srcItr.Error()
+}
+
+// Rank accepts an Iterable and LessFunc closure and creates a RankIterator that returns the values in the order
+// defined by the LessFunc closure, annotated with their rank. Equal values share the same rank and leave a gap in the
+// ranking, like 1, 2, 2, 4.
+func Rank[T any](iter Iterable[T], less LessFunc[T]) *RankIterator[T] {
+	return &RankIterator[T]{
+		srcItr: iter,
+		less:   less,
+	}
+}
+
+// DenseRank works like Rank, but equal values do not leave a gap in the ranking, like 1, 2, 2, 3.
+func DenseRank[T any](iter Iterable[T], less LessFunc[T]) *RankIterator[T] {
+	return &RankIterator[T]{
+		srcItr: iter,
+		less:   less,
+		dense:  true,
+	}
+}
+
+// NormalizeMinMax
+
+// NormalizeMinMax accepts an Iterable of numbers and returns a MapIterator that returns each value scaled to the range
+// [0, 1], where the smallest value becomes 0 and the largest value becomes 1. When all values are equal each value
+// becomes 0. Most Iterables can only be iterated once, so the values are buffered in memory during a first pass that
+// finds the bounds. The error of the first pass is returned.
+func NormalizeMinMax[T Number](iter Iterable[T]) (*MapIterator[T, float64], error) {
+	values, err := ToSlice(iter)
+	if err != nil {
+		return nil, err
+	}
+	var min, max T
+	for i, v := range values {
+		if i == 0 || v < min {
+			min = v
+		}
+		if i == 0 || v > max {
+			max = v
+		}
+	}
+	scale := float64(max) - float64(min)
+	return namedMap[T]("normalize min max", FromSlice(values), func(v T) float64 {
+		if scale == 0 {
+			return 0
+		}
+		return (float64(v) - float64(min)) / scale
+	}), nil
+}
+
+// Consume
+
+// ErrConsumed is returned by a ConsumeIterator when a second consumer requests values after the first consumer has
+// completed the iteration.
+var ErrConsumed = errors.New("iterator: already consumed")
+
+// ConsumeIterator is a struct the implements an Iterable that can only be consumed once.
+type ConsumeIterator[T any] struct {
+	// srcItr is the source Iterable.
+	srcItr Iterable[T]
+	// done is set when Next returned false.
+	done bool
+	// completed is set when Error was called after Next returned false, which marks the end of the first consumer.
+	completed bool
+	// consumed is set when Next was called by a second consumer.
+	consumed bool
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// When Next is called after the first consumer has completed, a second consumer has started and Error returns
+// ErrConsumed. Calling Next again before Error was called just returns false.
+// If no more values are available or an error has occurred then a zero value of T and false is returned.
+func (iter *ConsumeIterator[T]) Next() (T, bool) {
+	if iter.done {
+		if iter.completed {
+			iter.consumed = true
+		}
+		var v T
+		return v, false
+	}
+	v, b := iter.srcItr.Next()
+	iter.done = !b
+	return v, b
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The error of the source Iterable takes precedence, otherwise ErrConsumed is returned when
+// the Iterable was consumed a second time. Calling Error after Next returned false marks the end of the first
+// consumer.
+func (iter *ConsumeIterator[T]) Error() error {
+	if err := iter.srcItr.Error(); err != nil {
+		return err
+	}
+	if iter.consumed {
+		return ErrConsumed
+	}
+	if iter.done {
+		iter.completed = true
+	}
+	return nil
+}
+
+// Consume accepts an Iterable and creates a ConsumeIterator that fails with ErrConsumed when a second consumer
+// starts draining it after the first consumer has completed the iteration. A consumer has completed when it called
+// Error after Next returned false, which all functions of this package that drain an Iterable do. This catches an
+// Iterable that is accidentally passed to two terminals, where the second would otherwise silently see no values.
+func Consume[T any](iter Iterable[T]) *ConsumeIterator[T] {
+	return &ConsumeIterator[T]{
+		srcItr: iter,
+	}
+}
+
+// Chain
+
+// Stage is a step of a pipeline that transforms an Iterable of T into an Iterable of R. Stages can be chained with
+// Chain2 and Chain3, so the type parameters are inferred once per stage instead of at every nested call site.
+type Stage[T any, R any] func(iter Iterable[T]) Iterable[R]
+
+// MapStage creates a Stage that performs the map operation with the provided MapFunc closure.
+func MapStage[T any, R any](f MapFunc[T, R]) Stage[T, R] {
+	return func(iter Iterable[T]) Iterable[R] {
+		return Map(iter, f)
+	}
+}
+
+// FilterStage creates a Stage that performs the filter operation with the provided PredicateFunc closure.
+func FilterStage[T any](predicate PredicateFunc[T]) Stage[T, T] {
+	return func(iter Iterable[T]) Iterable[T] {
+		return Filter(iter, predicate)
+	}
+}
+
+// Chain2 creates a Stage that passes the Iterable through the first and then the second Stage.
+func Chain2[A any, B any, C any](first Stage[A, B], second Stage[B, C]) Stage[A, C] {
+	return func(iter Iterable[A]) Iterable[C] {
+		return second(first(iter))
+	}
+}
+
+// Chain3 creates a Stage that passes the Iterable through the first, second and third Stage.
+func Chain3[A any, B any, C any, D any](first Stage[A, B], second Stage[B, C], third Stage[C, D]) Stage[A, D] {
+	return func(iter Iterable[A]) Iterable[D] {
+		return third(second(first(iter)))
+	}
+}
+
+// Registry
+
+// ErrUnknownName is returned by a Registry when no factory is registered with the requested name.
+var ErrUnknownName = errors.New("iterator: unknown name")
+
+// ErrDuplicateName is returned by a Registry when a factory is registered with a name that is already in use.
+var ErrDuplicateName = errors.New("iterator: duplicate name")
+
+// StepSpec describes a step of a pipeline by the name of its factory and the parameters passed to the factory.
+type StepSpec struct {
+	Name   string
+	Params any
+}
+
+// PipelineSpec describes a pipeline that starts with a source, passes the values through stages and ends in a sink.
+type PipelineSpec struct {
+	Source StepSpec
+	Stages []StepSpec
+	Sink   StepSpec
+}
+
+// Registry contains named factories of sources, stages and sinks for values of T. It allows applications to build
+// pipelines that are configured by users, like "source: csv, stage: nonEmpty, sink: jsonl". A Registry is safe for
+// concurrent use.
+type Registry[T any] struct {
+	// mu guards the maps with factories.
+	mu sync.RWMutex
+	// sources contains the source factories by name.
+	sources map[string]func(params any) (Iterable[T], error)
+	// stages contains the stage factories by name.
+	stages map[string]func(iter Iterable[T], params any) (Iterable[T], error)
+	// sinks contains the sink factories by name.
+	sinks map[string]func(iter Iterable[T], params any) error
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry[T any]() *Registry[T] {
+	return &Registry[T]{
+		sources: map[string]func(params any) (Iterable[T], error){},
+		stages:  map[string]func(iter Iterable[T], params any) (Iterable[T], error){},
+		sinks:   map[string]func(iter Iterable[T], params any) error{},
+	}
+}
+
+// registryParams converts the parameters to the type expected by a factory. Nil is converted to the zero value of P.
+func registryParams[P any](kind, name string, params any) (P, error) {
+	var p P
+	if params == nil {
+		return p, nil
+	}
+	p, ok := params.(P)
+	if !ok {
+		return p, fmt.Errorf("iterator: %s %q expects parameters of type %T, got %T", kind, name, p, params)
+	}
+	return p, nil
+}
+
+// RegisterSource registers a source factory under the provided name. The factory receives parameters of type P.
+// ErrDuplicateName is returned when a source with the same name is already registered.
+func RegisterSource[T any, P any](r *Registry[T], name string, f func(params P) (Iterable[T], error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sources[name]; ok {
+		return fmt.Errorf("%w: source %q", ErrDuplicateName, name)
+	}
+	r.sources[name] = func(params any) (Iterable[T], error) {
+		p, err := registryParams[P]("source", name, params)
+		if err != nil {
+			return nil, err
+		}
+		return f(p)
+	}
+	return nil
+}
+
+// RegisterStage registers a stage factory under the provided name. The factory receives the Iterable of the previous
+// step and parameters of type P. ErrDuplicateName is returned when a stage with the same name is already registered.
+func RegisterStage[T any, P any](r *Registry[T], name string, f func(iter Iterable[T], params P) (Iterable[T], error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.stages[name]; ok {
+		return fmt.Errorf("%w: stage %q", ErrDuplicateName, name)
+	}
+	r.stages[name] = func(iter Iterable[T], params any) (Iterable[T], error) {
+		p, err := registryParams[P]("stage", name, params)
+		if err != nil {
+			return nil, err
+		}
+		return f(iter, p)
+	}
+	return nil
+}
+
+// RegisterSink registers a sink factory under the provided name. The factory receives the Iterable of the last stage
+// and parameters of type P, and consumes the Iterable. ErrDuplicateName is returned when a sink with the same name is
+// already registered.
+func RegisterSink[T any, P any](r *Registry[T], name string, f func(iter Iterable[T], params P) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sinks[name]; ok {
+		return fmt.Errorf("%w: sink %q", ErrDuplicateName, name)
+	}
+	r.sinks[name] = func(iter Iterable[T], params any) error {
+		p, err := registryParams[P]("sink", name, params)
+		if err != nil {
+			return err
+		}
+		return f(iter, p)
+	}
+	return nil
+}
+
+// Build creates the source and stages of the PipelineSpec and returns the Iterable of the last stage. The sink of the
+// PipelineSpec is ignored. ErrUnknownName is returned when a factory is not registered.
+func (r *Registry[T]) Build(spec PipelineSpec) (Iterable[T], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, ok := r.sources[spec.Source.Name]
+	if !ok {
+		return nil, fmt.Errorf("%w: source %q", ErrUnknownName, spec.Source.Name)
+	}
+	iter, err := source(spec.Source.Params)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range spec.Stages {
+		stage, ok := r.stages[step.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: stage %q", ErrUnknownName, step.Name)
+		}
+		if iter, err = stage(iter, step.Params); err != nil {
+			return nil, err
+		}
+	}
+	return iter, nil
+}
+
+// Run builds the PipelineSpec and consumes the resulting Iterable with its sink. ErrUnknownName is returned when a
+// factory is not registered.
+func (r *Registry[T]) Run(spec PipelineSpec) error {
+	r.mu.RLock()
+	sink, ok := r.sinks[spec.Sink.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: sink %q", ErrUnknownName, spec.Sink.Name)
+	}
+	iter, err := r.Build(spec)
+	if err != nil {
+		return err
+	}
+	return sink(iter, spec.Sink.Params)
+}
+
+// Reduce
+
+// ReduceFunc is the closure type that needs to be provided to Reduce to perform the reduce operation with.
+type ReduceFunc[T any, R any] func(R, T) R
+
+// Reduce accepts an Iterable, init value and ReduceFunc and reduces the values of the iterator to a single value by
+// calling the ReduceFunc closure.
+func Reduce[T any, R any](iter Iterable[T], init R, reducer ReduceFunc[T, R]) (R, error) {
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		init = reducer(init, v)
+	}
+	return init, iter.Error()
+}
+
+// ReduceWhile
+
+// ReduceWhileFunc is the closure type that needs to be provided to ReduceWhile. It returns the reduced value and
+// false when no more values are needed.
+type ReduceWhileFunc[T any, R any] func(R, T) (R, bool)
+
+// ReduceWhile works like Reduce, but stops consuming values as soon as the ReduceWhileFunc closure returns false.
+// The value returned by that call is the result. An error is returned when an error during iteration has occurred.
+func ReduceWhile[T any, R any](iter Iterable[T], init R, reducer ReduceWhileFunc[T, R]) (R, error) {
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		var more bool
+		if init, more = reducer(init, v); !more {
+			return init, nil
+		}
+	}
+	return init, iter.Error()
+}
+
+// TryFold
+
+// IndexError is the error that wraps an error of a closure with the zero based index of the value that caused it.
+type IndexError struct {
+	// Index is the zero based index of the value.
+	Index int
+	// Err is the error returned by the closure.
+	Err error
+}
+
+// Error returns the error with the index of the value.
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("iterator: value at index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error returned by the closure.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// TryFoldFunc is the closure type that needs to be provided to TryFold. It returns the reduced value or an error.
+type TryFoldFunc[T any, R any] func(R, T) (R, error)
+
+// TryFold works like Reduce, but stops at the first error returned by the TryFoldFunc closure. That error is
+// returned wrapped in an IndexError, together with the value reduced before the failing value. An error is returned
+// when an error during iteration has occurred.
+func TryFold[T any, R any](iter Iterable[T], init R, reducer TryFoldFunc[T, R]) (R, error) {
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		r, err := reducer(init, v)
+		if err != nil {
+			return init, &IndexError{Index: i, Err: err}
+		}
+		init = r
+		i++
+	}
+	return init, iter.Error()
+}
+
+// Sum
+
+// Sum accepts an Iterable of numbers and returns the sum of all values. Zero is returned for an empty Iterable.
+// An error is returned when an error during iteration has occurred.
+func Sum[T Number](iter Iterable[T]) (T, error) {
+	return Reduce(iter, T(0), func(a T, v T) T {
+		return a + v
+	})
+}
+
+// Product
+
+// Product accepts an Iterable of numbers and returns the product of all values. One is returned for an empty
+// Iterable. An error is returned when an error during iteration has occurred.
+func Product[T Number](iter Iterable[T]) (T, error) {
+	return Reduce(iter, T(1), func(a T, v T) T {
+		return a * v
+	})
+}
+
+// Any
+
+// Any accepts an Iterable and PredicateFunc closure and returns true when the predicate returns true for at least one
+// value. The iteration stops at the first matching value. An error is returned when an error during iteration has
+// occurred.
+func Any[T any](iter Iterable[T], predicate PredicateFunc[T]) (bool, error) {
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if predicate(v) {
+			return true, nil
+		}
+	}
+	return false, iter.Error()
+}
+
+// All
+
+// All accepts an Iterable and PredicateFunc closure and returns true when the predicate returns true for every value,
+// which includes an empty Iterable. The iteration stops at the first value that does not match. An error is returned
+// when an error during iteration has occurred.
+func All[T any](iter Iterable[T], predicate PredicateFunc[T]) (bool, error) {
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if !predicate(v) {
+			return false, nil
+		}
+	}
+	return true, iter.Error()
+}
+
+// None
+
+// None accepts an Iterable and PredicateFunc closure and returns true when the predicate returns false for every
+// value, which includes an empty Iterable. The iteration stops at the first matching value. An error is returned when
+// an error during iteration has occurred.
+func None[T any](iter Iterable[T], predicate PredicateFunc[T]) (bool, error) {
+	found, err := Any(iter, predicate)
+	return !found, err
+}
+
+// First
+
+// First accepts an Iterable and returns the first value and true. When the Iterable is empty a zero value of T and
+// false is returned. Only the first value is consumed. An error is returned when an error during iteration has
+// occurred.
+func First[T any](iter Iterable[T]) (T, bool, error) {
+	v, b := iter.Next()
+	if !b {
+		return v, false, iter.Error()
+	}
+	return v, true, nil
+}
+
+// Last
+
+// Last accepts an Iterable and returns the last value and true. When the Iterable is empty a zero value of T and
+// false is returned. Only the last value is kept in memory. An error is returned when an error during iteration has
+// occurred.
+func Last[T any](iter Iterable[T]) (T, bool, error) {
+	var last T
+	found := false
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		last, found = v, true
+	}
+	return last, found, iter.Error()
+}
+
+// Nth
+
+// Nth accepts an Iterable and a zero based index n and returns the value at index n and true. When the Iterable has n
+// or fewer values, or n is negative, a zero value of T and false is returned. The iteration stops at index n.
+// An error is returned when an error during iteration has occurred.
+func Nth[T any](iter Iterable[T], n int) (T, bool, error) {
+	var zero T
+	if n < 0 {
+		return zero, false, nil
+	}
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if i == n {
+			return v, true, nil
+		}
+		i++
+	}
+	return zero, false, iter.Error()
+}
+
+// Find
+
+// Find accepts an Iterable and PredicateFunc closure and returns the first value for which the predicate returns true
+// and true. When no value matches a zero value of T and false is returned. The iteration stops at the first match.
+// An error is returned when an error during iteration has occurred.
+func Find[T any](iter Iterable[T], predicate PredicateFunc[T]) (T, bool, error) {
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if predicate(v) {
+			return v, true, nil
+		}
+	}
+	var v T
+	return v, false, iter.Error()
+}
+
+// FindIndex
+
+// FindIndex accepts an Iterable and PredicateFunc closure and returns the zero based index of the first value for
+// which the predicate returns true. When no value matches -1 is returned. The iteration stops at the first match.
+// An error is returned when an error during iteration has occurred.
+func FindIndex[T any](iter Iterable[T], predicate PredicateFunc[T]) (int, error) {
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if predicate(v) {
+			return i, nil
+		}
+		i++
+	}
+	return -1, iter.Error()
+}
+
+// Contains
+
+// Contains accepts an Iterable and a value and returns true when the Iterable contains the value. The iteration stops
+// at the first occurrence. An error is returned when an error during iteration has occurred.
+func Contains[T comparable](iter Iterable[T], value T) (bool, error) {
+	return Any(iter, func(v T) bool {
+		return v == value
+	})
+}
+
+// IndexOf
+
+// IndexOf accepts an Iterable and a value and returns the zero based index of the first occurrence of the value.
+// When the Iterable does not contain the value -1 is returned. The iteration stops at the first occurrence.
+// An error is returned when an error during iteration has occurred.
+func IndexOf[T comparable](iter Iterable[T], value T) (int, error) {
+	return FindIndex(iter, func(v T) bool {
+		return v == value
+	})
+}
+
+// Average
+
+// Average accepts an Iterable of numbers and returns the mean of all values, computed in one pass. Zero is returned
+// for an empty Iterable. An error is returned when an error during iteration has occurred.
+func Average[T Number](iter Iterable[T]) (float64, error) {
+	var mean float64
+	var n int
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		n++
+		// The running mean does not overflow like a running sum of large values would.
+		mean += (float64(v) - mean) / float64(n)
+	}
+	return mean, iter.Error()
+}
+
+// Stats
+
+// Statistics contains the summary of an Iterable of numbers returned by Stats.
+type Statistics struct {
+	// Count is the amount of values.
+	Count int
+	// Sum is the sum of all values.
+	Sum float64
+	// Min is the smallest value.
+	Min float64
+	// Max is the largest value.
+	Max float64
+	// Mean is the arithmetic mean of all values.
+	Mean float64
+	// Variance is the population variance of all values.
+	Variance float64
+	// StdDev is the population standard deviation of all values.
+	StdDev float64
+}
+
+// Stats accepts an Iterable of numbers and returns the Statistics of all values, computed in one pass with Welford's
+// algorithm. The zero value of Statistics is returned for an empty Iterable. An error is returned when an error during
+// iteration has occurred.
+func Stats[T Number](iter Iterable[T]) (Statistics, error) {
+	var s Statistics
+	// m2 is the sum of the squared differences from the mean.
+	var m2 float64
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		x := float64(v)
+		s.Count++
+		s.Sum += x
+		if s.Count == 1 || x < s.Min {
+			s.Min = x
+		}
+		if s.Count == 1 || x > s.Max {
+			s.Max = x
+		}
+		delta := x - s.Mean
+		s.Mean += delta / float64(s.Count)
+		m2 += delta * (x - s.Mean)
+	}
+	if s.Count > 0 {
+		s.Variance = m2 / float64(s.Count)
+		s.StdDev = math.Sqrt(s.Variance)
+	}
+	return s, iter.Error()
+}
+
+// Quantiles
+
+// checkQuantiles returns an error when a quantile is outside of the range [0, 1].
+func checkQuantiles(qs []float64) error {
+	for _, q := range qs {
+		if !(q >= 0 && q <= 1) {
+			return fmt.Errorf("iterator: quantile %v is outside of the range [0, 1]", q)
+		}
+	}
+	return nil
+}
+
+// quantile returns the quantile q of the sorted values with linear interpolation between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	h := float64(len(sorted)-1) * q
+	lo := int(h)
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// Quantiles accepts an Iterable of numbers and quantiles between 0 and 1 and returns the exact value of each quantile,
+// interpolated linearly between the closest ranks. For example 0.5 returns the median and 0.99 the 99th percentile.
+// All values are buffered, use QuantilesApprox for unbounded streams. NaN is returned for each quantile of an empty
+// Iterable. An error is returned when a quantile is outside the range [0, 1] or an error during iteration has
+// occurred.
+func Quantiles[T Number](iter Iterable[T], qs ...float64) ([]float64, error) {
+	if err := checkQuantiles(qs); err != nil {
+		return nil, err
+	}
+	var values []float64
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		values = append(values, float64(v))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	sort.Float64s(values)
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		result[i] = quantile(values, q)
+	}
+	return result, nil
+}
+
+// P2Quantile estimates a quantile of a stream of values in constant memory with the P² algorithm of Jain and
+// Chlamtac. The estimate is exact until five values have been added.
+type P2Quantile struct {
+	// p is the quantile that is estimated.
+	p float64
+	// count is the amount of added values.
+	count int
+	// heights contains the heights of the five markers.
+	heights [5]float64
+	// positions contains the actual positions of the five markers.
+	positions [5]float64
+	// desired contains the desired positions of the five markers.
+	desired [5]float64
+	// increments contains the increments of the desired positions for each added value.
+	increments [5]float64
+}
+
+// NewP2Quantile creates a P2Quantile that estimates the quantile p, which must be between 0 and 1.
+// NewP2Quantile panics when p is outside the range [0, 1].
+func NewP2Quantile(p float64) *P2Quantile {
+	if err := checkQuantiles([]float64{p}); err != nil {
+		panic(err.Error())
+	}
+	return &P2Quantile{
+		p:          p,
+		positions:  [5]float64{1, 2, 3, 4, 5},
+		desired:    [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5},
+		increments: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+// Add adds a value to the estimation.
+func (e *P2Quantile) Add(x float64) {
+	if e.count < 5 {
+		e.heights[e.count] = x
+		e.count++
+		if e.count == 5 {
+			sort.Float64s(e.heights[:])
+		}
+		return
+	}
+	e.count++
+	var k int
+	switch {
+	case x < e.heights[0]:
+		e.heights[0] = x
+		k = 0
+	case x >= e.heights[4]:
+		e.heights[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3 && x >= e.heights[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.positions[i]++
+	}
+	for i := range e.desired {
+		e.desired[i] += e.increments[i]
+	}
+	for i := 1; i < 4; i++ {
+		d := e.desired[i] - e.positions[i]
+		if (d >= 1 && e.positions[i+1]-e.positions[i] > 1) || (d <= -1 && e.positions[i-1]-e.positions[i] < -1) {
+			sign := 1.0
+			if d < 0 {
+				sign = -1
+			}
+			h := e.parabolic(i, sign)
+			if e.heights[i-1] < h && h < e.heights[i+1] {
+				e.heights[i] = h
+			} else {
+				e.heights[i] = e.linear(i, int(sign))
+			}
+			e.positions[i] += sign
+		}
+	}
+}
+
+// parabolic returns the height of marker i after moving it d positions, predicted with a parabolic formula.
+func (e *P2Quantile) parabolic(i int, d float64) float64 {
+	q, n := e.heights, e.positions
+	return q[i] + d/(n[i+1]-n[i-1])*((n[i]-n[i-1]+d)*(q[i+1]-q[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-d)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+// linear returns the height of marker i after moving it d positions, predicted with a linear formula.
+func (e *P2Quantile) linear(i int, d int) float64 {
+	q, n := e.heights, e.positions
+	return q[i] + float64(d)*(q[i+d]-q[i])/(n[i+d]-n[i])
+}
+
+// Value returns the estimated quantile. NaN is returned when no values have been added.
+func (e *P2Quantile) Value() float64 {
+	if e.count < 5 {
+		sorted := append([]float64(nil), e.heights[:e.count]...)
+		sort.Float64s(sorted)
+		return quantile(sorted, e.p)
+	}
+	return e.heights[2]
+}
+
+// QuantilesApprox works like Quantiles, but estimates each quantile with a P2Quantile, so the memory usage does not
+// grow with the amount of values. Use it for unbounded streams, like latency measurements.
+func QuantilesApprox[T Number](iter Iterable[T], qs ...float64) ([]float64, error) {
+	if err := checkQuantiles(qs); err != nil {
+		return nil, err
+	}
+	estimators := make([]*P2Quantile, len(qs))
+	for i, q := range qs {
+		estimators[i] = NewP2Quantile(q)
+	}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		for _, e := range estimators {
+			e.Add(float64(v))
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	result := make([]float64, len(qs))
+	for i, e := range estimators {
+		result[i] = e.Value()
+	}
+	return result, nil
+}
+
+// Histogram
+
+// HistogramBuckets contains the counts of values per bucket returned by Histogram.
+type HistogramBuckets[T Number] struct {
+	// Bounds contains the inclusive upper bounds of the buckets, in ascending order.
+	Bounds []T
+	// Counts contains the amount of values of each bucket. Counts[i] counts the values greater than Bounds[i-1] and
+	// less than or equal to Bounds[i]. The last count contains the values greater than the last bound.
+	Counts []int
+	// Total is the amount of values.
+	Total int
+}
+
+// Histogram accepts an Iterable of numbers and the inclusive upper bounds of buckets in ascending order and counts
+// the values into the buckets in one pass. Values greater than the last bound are counted in an overflow bucket.
+// An error is returned when the bounds are not in ascending order or an error during iteration has occurred.
+func Histogram[T Number](iter Iterable[T], buckets []T) (HistogramBuckets[T], error) {
+	for i := 1; i < len(buckets); i++ {
+		if !(buckets[i-1] < buckets[i]) {
+			return HistogramBuckets[T]{}, fmt.Errorf("iterator: histogram bucket %v is not greater than %v", buckets[i], buckets[i-1])
+		}
+	}
+	h := HistogramBuckets[T]{
+		Bounds: append([]T(nil), buckets...),
+		Counts: make([]int, len(buckets)+1),
+	}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		i := sort.Search(len(h.Bounds), func(i int) bool {
+			return v <= h.Bounds[i]
+		})
+		h.Counts[i]++
+		h.Total++
+	}
+	return h, iter.Error()
+}
+
+// Min
+
+// Min accepts an Iterable and returns the smallest value and true. When the Iterable is empty a zero value of T and
+// false is returned. An error is returned when an error during iteration has occurred.
+func Min[T Ordered](iter Iterable[T]) (T, bool, error) {
+	m, found := iter.Next()
+	if !found {
+		return m, false, iter.Error()
+	}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if v < m {
+			m = v
+		}
+	}
+	return m, true, iter.Error()
+}
+
+// Max
+
+// Max accepts an Iterable and returns the largest value and true. When the Iterable is empty a zero value of T and
+// false is returned. An error is returned when an error during iteration has occurred.
+func Max[T Ordered](iter Iterable[T]) (T, bool, error) {
+	m, found := iter.Next()
+	if !found {
+		return m, false, iter.Error()
+	}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if v > m {
+			m = v
+		}
+	}
+	return m, true, iter.Error()
+}
+
+// MinMax
+
+// MinMax accepts an Iterable and returns the smallest and largest value and true in a single pass, comparing the
+// values in pairs so about 1.5 comparisons per value are needed. When the Iterable is empty zero values of T and false
+// are returned. An error is returned when an error during iteration has occurred.
+func MinMax[T Ordered](iter Iterable[T]) (minimum T, maximum T, ok bool, err error) {
+	minimum, ok = iter.Next()
+	if !ok {
+		return minimum, maximum, false, iter.Error()
+	}
+	maximum = minimum
+	for {
+		a, b := iter.Next()
+		if !b {
+			break
+		}
+		c, d := iter.Next()
+		if !d {
+			if a < minimum {
+				minimum = a
+			} else if a > maximum {
+				maximum = a
+			}
+			break
+		}
+		if c < a {
+			a, c = c, a
+		}
+		if a < minimum {
+			minimum = a
+		}
+		if c > maximum {
+			maximum = c
+		}
+	}
+	return minimum, maximum, true, iter.Error()
+}
+
+// MinBy
+
+// MinBy accepts an Iterable and a key closure and returns the first value with the smallest key and true. When the
+// Iterable is empty a zero value of T and false is returned. An error is returned when an error during iteration has
+// occurred.
+func MinBy[T any, K Ordered](iter Iterable[T], key func(T) K) (T, bool, error) {
+	m, found := iter.Next()
+	if !found {
+		return m, false, iter.Error()
+	}
+	mk := key(m)
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if k := key(v); k < mk {
+			m, mk = v, k
+		}
+	}
+	return m, true, iter.Error()
+}
+
+// MaxBy
+
+// MaxBy accepts an Iterable and a key closure and returns the first value with the largest key and true. When the
+// Iterable is empty a zero value of T and false is returned. An error is returned when an error during iteration has
+// occurred.
+func MaxBy[T any, K Ordered](iter Iterable[T], key func(T) K) (T, bool, error) {
+	m, found := iter.Next()
+	if !found {
+		return m, false, iter.Error()
+	}
+	mk := key(m)
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if k := key(v); k > mk {
+			m, mk = v, k
+		}
+	}
+	return m, true, iter.Error()
+}
+
+// ToSlice
+
+// ToSlice renders the Iterable to a slice.
+func ToSlice[T any](iter Iterable[T]) ([]T, error) {
+	var result []T
+
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		result = append(result, v)
+	}
+
+	return result, iter.Error()
+}
+
+// AppendSlice appends the values of the Iterable to dst and returns the extended slice, like append does. This makes
+// it possible to reuse a buffer, for example by passing dst[:0]. When the Iterable implements SizeHinter and dst does
+// not have enough capacity, dst is grown once upfront. The values appended before an error occurred are returned
+// with the error.
+func AppendSlice[T any](iter Iterable[T], dst []T) ([]T, error) {
+	if n, ok := sizeHint(iter); ok && cap(dst)-len(dst) < n {
+		grown := make([]T, len(dst), len(dst)+n)
+		copy(grown, dst)
+		dst = grown
+	}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		dst = append(dst, v)
+	}
+	return dst, iter.Error()
+}
+
+// ErrTooMany is returned by ToSliceMax when the Iterable returns more values than allowed.
+var ErrTooMany = errors.New("iterator: too many values")
+
+// ToSliceMax renders the Iterable to a slice, but stops iterating and returns ErrTooMany as soon as the
+// Iterable returns more than max values. The values received until that moment are returned with the error.
+// This protects against exhausting memory when an Iterable unexpectedly returns a huge amount of values.
+func ToSliceMax[T any](iter Iterable[T], max int) ([]T, error) {
+	var result []T
+
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if len(result) >= max {
+			return result, ErrTooMany
+		}
+		result = append(result, v)
+	}
+
+	return result, iter.Error()
+}
+
+// ToSortedSlice renders the Iterable to a slice that is sorted in ascending order. The values received until an error
+// occurred are returned unsorted with the error.
+func ToSortedSlice[T Ordered](iter Iterable[T]) ([]T, error) {
+	return ToSortedSliceFunc(iter, func(a, b T) bool {
+		return a < b
+	})
+}
+
+// ToSortedSliceFunc renders the Iterable to a slice that is sorted in the order defined by the LessFunc closure. The
+// sort is stable, so equal values keep the order in which the Iterable returned them. The values received until an
+// error occurred are returned unsorted with the error.
+func ToSortedSliceFunc[T any](iter Iterable[T], less LessFunc[T]) ([]T, error) {
+	result, err := ToSlice(iter)
+	if err != nil {
+		return result, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return result, nil
+}
+
+// CollectInto
+
+// Collector is the interface implemented by containers that values can be streamed into, like ring buffers, bloom
+// filters or database batch writers.
+type Collector[T any] interface {
+	// Add adds the value to the container.
+	Add(v T) error
+}
+
+// CollectorFunc is a closure that implements the Collector interface.
+type CollectorFunc[T any] func(v T) error
+
+// Add calls the closure with the value.
+func (f CollectorFunc[T]) Add(v T) error {
+	return f(v)
+}
+
+// CollectInto accepts an Iterable and a Collector and adds each value to the Collector. The iteration stops at the
+// first error of the Collector, which is returned wrapped in an IndexError. An error is returned when an error during
+// iteration has occurred.
+func CollectInto[T any](iter Iterable[T], c Collector[T]) error {
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if err := c.Add(v); err != nil {
+			return &IndexError{Index: i, Err: err}
+		}
+		i++
+	}
+	return iter.Error()
+}
+
+// Drain
+
+// Sink is the interface implemented by consumers that accept values one by one and write them in batches, like
+// database inserters or message producers. Unlike a Collector, a Sink is flushed when the iteration has completed.
+type Sink[T any] interface {
+	// Accept accepts the value, which may be buffered until the next flush.
+	Accept(v T) error
+	// Flush writes the buffered values.
+	Flush() error
+}
+
+// Drain accepts an Iterable and a Sink and passes each value to Accept of the Sink. The iteration stops at the first
+// error of Accept, which is returned wrapped in an IndexError, and the Sink is not flushed. Otherwise the Sink is
+// flushed when the iteration has completed, also when an error during iteration has occurred, so the accepted values
+// are not lost. The error during iteration takes precedence over the error of Flush.
+func Drain[T any](iter Iterable[T], s Sink[T]) error {
+	i := 0
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if err := s.Accept(v); err != nil {
+			return &IndexError{Index: i, Err: err}
+		}
+		i++
+	}
+	err := s.Flush()
+	if iterErr := iter.Error(); iterErr != nil {
+		return iterErr
+	}
+	return err
+}
+
+// ToMap
+
+// ToMap accepts an Iterable, a key closure and a value closure and returns a map with the value of each value of the
+// Iterable stored under its key. When keys collide the last value is kept. An error is returned when an error during
+// iteration has occurred.
+func ToMap[T any, K comparable, V any](iter Iterable[T], key func(T) K, value func(T) V) (map[K]V, error) {
+	m := map[K]V{}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		m[key(v)] = value(v)
+	}
+	return m, iter.Error()
+}
+
+// ToMapKeyed accepts an Iterable and a key closure and returns a map with each value of the Iterable stored under its
+// key. When keys collide the last value is kept. An error is returned when an error during iteration has occurred.
+func ToMapKeyed[T any, K comparable](iter Iterable[T], key func(T) K) (map[K]T, error) {
+	return ToMap(iter, key, func(v T) T {
+		return v
+	})
+}
+
+// ToMapMerge works like ToMap, but when keys collide the merge closure is called with the value stored under the key
+// and the value of the new value, and the result is stored instead. Merge is not called for the first value of a key.
+// This makes it possible to count or sum by key, or to keep the first value instead of the last value.
+func ToMapMerge[T any, K comparable, V any](iter Iterable[T], key func(T) K, value func(T) V, merge func(old, new V) V) (map[K]V, error) {
+	m := map[K]V{}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		k, nv := key(v), value(v)
+		if old, ok := m[k]; ok {
+			nv = merge(old, nv)
+		}
+		m[k] = nv
+	}
+	return m, iter.Error()
+}
+
+// Equal
+
+// Equal accepts two Iterables and returns true when both return the same values in the same order. The iteration
+// stops at the first mismatch or when one Iterable is exhausted before the other. An error is returned when an error
+// during iteration of either Iterable has occurred.
+func Equal[T comparable](a, b Iterable[T]) (bool, error) {
+	return EqualBy(a, b, func(x, y T) bool {
+		return x == y
+	})
+}
+
+// EqualBy works like Equal, but compares the values with the provided EqualFunc closure.
+func EqualBy[T any](a, b Iterable[T], equal EqualFunc[T]) (bool, error) {
+	for {
+		va, oka := a.Next()
+		vb, okb := b.Next()
+		if !oka || !okb {
+			if err := a.Error(); err != nil {
+				return false, err
+			}
+			if err := b.Error(); err != nil {
+				return false, err
+			}
+			return oka == okb, nil
+		}
+		if !equal(va, vb) {
+			return false, nil
+		}
+	}
+}
+
+// Compare
+
+// Compare accepts two Iterables and compares their values lexicographically, like bytes.Compare. It returns -1 when
+// a is ordered before b, 1 when a is ordered after b and 0 when both return the same values. An Iterable that is a
+// prefix of the other is ordered first. The iteration stops at the first difference. An error is returned when an
+// error during iteration of either Iterable has occurred.
+func Compare[T Ordered](a, b Iterable[T]) (int, error) {
+	for {
+		va, oka := a.Next()
+		vb, okb := b.Next()
+		if !oka || !okb {
+			if err := a.Error(); err != nil {
+				return 0, err
+			}
+			if err := b.Error(); err != nil {
+				return 0, err
+			}
+			switch {
+			case oka:
+				return 1, nil
+			case okb:
+				return -1, nil
+			}
+			return 0, nil
+		}
+		if va < vb {
+			return -1, nil
+		}
+		if va > vb {
+			return 1, nil
+		}
+	}
+}
+
+// IsSorted
+
+// IsSorted accepts an Iterable and LessFunc closure and returns true when the values are sorted in the order defined
+// by the LessFunc closure, which includes equal neighbours. The iteration stops at the first value that is ordered
+// before its predecessor, and false and nil are returned without calling Error, like Any does when it stops early.
+// An error is returned when an error during iteration has occurred.
+func IsSorted[T any](iter Iterable[T], less LessFunc[T]) (bool, error) {
+	prev, b := iter.Next()
+	if !b {
+		return true, iter.Error()
+	}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if less(v, prev) {
+			return false, nil
+		}
+		prev = v
+	}
+	return true, iter.Error()
+}
+
+// Frequencies
+
+// Frequencies accepts an Iterable and returns a map with the amount of occurrences of each value.
+// An error is returned when an error during iteration has occurred.
+func Frequencies[T comparable](iter Iterable[T]) (map[T]int, error) {
+	return CountBy(iter, func(v T) T {
+		return v
+	})
+}
+
+// CountBy accepts an Iterable and a key closure and returns a map with the amount of values for each key.
+// An error is returned when an error during iteration has occurred.
+func CountBy[T any, K comparable](iter Iterable[T], key func(T) K) (map[K]int, error) {
+	m := map[K]int{}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		m[key(v)]++
+	}
+	return m, iter.Error()
+}
+
+// GroupReduce
+
+// GroupReduce accepts an Iterable, a key closure, an init value and ReduceFunc closure and reduces the values of each
+// key to a single value in one pass, starting each key with the init value. The values of a key are never collected.
+// An error is returned when an error during iteration has occurred.
+func GroupReduce[T any, K comparable, R any](iter Iterable[T], key func(T) K, init R, reducer ReduceFunc[T, R]) (map[K]R, error) {
+	m := map[K]R{}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		k := key(v)
+		acc, ok := m[k]
+		if !ok {
+			acc = init
+		}
+		m[k] = reducer(acc, v)
+	}
+	return m, iter.Error()
+}
+
+// JoinString
+
+// JoinString accepts an Iterable of strings and a separator and concatenates the values with the separator placed
+// between them, like strings.Join. An error is returned when an error during iteration has occurred.
+func JoinString(iter Iterable[string], sep string) (string, error) {
+	var sb strings.Builder
+	first := true
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if !first {
+			sb.WriteString(sep)
+		}
+		first = false
+		sb.WriteString(v)
+	}
+	return sb.String(), iter.Error()
+}
+
+// TopK
+
+// LessFunc is the closure type that reports whether a must be ordered before b.
+type LessFunc[T any] func(a, b T) bool
+
+// boundedHeap implements heap.Interface for a slice of T ordered by a LessFunc closure.
+type boundedHeap[T any] struct {
+	// values contains the values in the heap
+	values []T
+	// less is the closure that determines the order of the heap
+	less LessFunc[T]
+}
+
+func (h *boundedHeap[T]) Len() int           { return len(h.values) }
+func (h *boundedHeap[T]) Less(i, j int) bool { return h.less(h.values[i], h.values[j]) }
+func (h *boundedHeap[T]) Swap(i, j int)      { h.values[i], h.values[j] = h.values[j], h.values[i] }
+func (h *boundedHeap[T]) Push(x any)         { h.values = append(h.values, x.(T)) }
+func (h *boundedHeap[T]) Pop() any {
+	v := h.values[len(h.values)-1]
+	h.values = h.values[:len(h.values)-1]
+	return v
+}
+
+// TopK accepts an Iterable, k and LessFunc closure and returns the k largest values of the Iterable, ordered from
+// largest to smallest. A heap bounded to k values is used, so the memory usage does not depend on the amount of values
+// returned by the Iterable. Nil is returned when k is not positive.
+func TopK[T any](iter Iterable[T], k int, less LessFunc[T]) ([]T, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	h := &boundedHeap[T]{less: less}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if h.Len() < k {
+			heap.Push(h, v)
+		} else if less(h.values[0], v) {
+			h.values[0] = v
+			heap.Fix(h, 0)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	result := make([]T, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(T)
+	}
+	return result, nil
 }
 
-// Next returns the first or next value of T and true if a value is available.
-// If no more values are available or an error has occurred then a zero value of T and false is returned.
-func (iter *ChannelIterator[T]) Next() (v T, r bool) {
-	v, r = <-iter.c
-	return
+// BottomK accepts an Iterable, k and LessFunc closure and returns the k smallest values of the Iterable, ordered from
+// smallest to largest. A heap bounded to k values is used, so the memory usage does not depend on the amount of values
+// returned by the Iterable. Nil is returned when k is not positive.
+func BottomK[T any](iter Iterable[T], k int, less LessFunc[T]) ([]T, error) {
+	return TopK(iter, k, func(a, b T) bool {
+		return less(b, a)
+	})
 }
 
-// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
-// an error is returned. The ChannelIterator never returns an error.
-func (iter *ChannelIterator[T]) Error() error {
-	return nil
+// ToHeap
+
+// ToHeap accepts an Iterable and a heap and pushes all values of the Iterable on the heap with heap.Push, so the
+// heap invariants are maintained. The heap must be initialized before ToHeap is called. The values pushed before an
+// error occurred remain on the heap.
+func ToHeap[T any](iter Iterable[T], h heap.Interface) error {
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		heap.Push(h, v)
+	}
+	return iter.Error()
 }
 
-// FromChannel creates a ChannelIterator that iterates the provided channel.
-func FromChannel[T any](c <-chan T) *ChannelIterator[T] {
-	return &ChannelIterator[T]{
-		c: c,
+// SampleReservoir
+
+// SampleReservoir accepts an Iterable, k and a rand.Source and returns a uniform random sample of k values from the
+// Iterable in a single pass, without knowing the amount of values upfront. All values are returned when the Iterable
+// returns k or fewer values. Nil is returned when k is not positive.
+func SampleReservoir[T any](iter Iterable[T], k int, src rand.Source) ([]T, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	r := rand.New(src)
+	var reservoir []T
+	n := int64(0)
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		n++
+		if len(reservoir) < k {
+			reservoir = append(reservoir, v)
+		} else if j := r.Int63n(n); j < int64(k) {
+			reservoir[j] = v
+		}
 	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return reservoir, nil
 }
 
-// Algorithms
-// Foreach
+// Set
 
-// ForEachFunc is the closure type that needs to be provided to ForEach
-type ForEachFunc[T any] func(T)
+// Set is a set of comparable values that can be created from and iterated as an Iterable.
+type Set[T comparable] map[T]struct{}
 
-// ForEach accepts an Iterable and calls the provided ForEachFunc closure with each value.
+// SetFromIterator accepts an Iterable and returns a Set with all distinct values of the Iterable.
 // An error is returned when an error during iteration has occurred.
-func ForEach[T any](iter Iterable[T], f ForEachFunc[T]) error {
+func SetFromIterator[T comparable](iter Iterable[T]) (Set[T], error) {
+	s := Set[T]{}
 	for v, b := iter.Next(); b; v, b = iter.Next() {
-		f(v)
+		s[v] = struct{}{}
 	}
-	return iter.Error()
+	return s, iter.Error()
 }
 
-// Map
+// ToSet accepts an Iterable and returns a Set with all distinct values of the Iterable, like SetFromIterator. The Set
+// is a map[T]struct{}, so it can also be used as a plain membership map. An error is returned when an error during
+// iteration has occurred.
+func ToSet[T comparable](iter Iterable[T]) (Set[T], error) {
+	return SetFromIterator(iter)
+}
 
-// MapFunc is the closure type that needs to be provided to Map to perform the mapping operation with.
-type MapFunc[T any, R any] func(T) R
+// Add adds the provided values to the Set.
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
 
-// MapIterator is a struct the implements an Iterable that performs the map operation.
-type MapIterator[T any, R any] struct {
-	// srcItr is the Iterable this iterator pulls the original values from.
-	srcItr Iterable[T]
-	// mapFunc is the closure that performs the map operation.
-	mapFunc MapFunc[T, R]
+// Contains returns true when the provided value is in the Set.
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s[v]
+	return ok
 }
 
-// Next returns the first or next value of T and true if a value is available.
-// Each value is transformed with the provided MapFunc closure.
-// If no more values are available or an error has occurred then a zero value of T and false is returned.
-func (iter *MapIterator[T, R]) Next() (R, bool) {
-	v, b := iter.srcItr.Next()
-	if !b {
-		var r R
-		return r, false
+// Len returns the amount of values in the Set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Iter creates a SliceIterator that iterates the values of the Set in an undefined order. Changes to the Set after Iter
+// is called are not visible to the SliceIterator.
+func (s Set[T]) Iter() *SliceIterator[T] {
+	values := make([]T, 0, len(s))
+	for v := range s {
+		values = append(values, v)
 	}
-	return iter.mapFunc(v), true
+	return FromSlice(values)
 }
 
-// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
-// an error is returned.
-func (iter *MapIterator[T, R]) Error() error {
-	return iter.srcItr.Error()
+// Union returns a new Set with the values that are in the Set, the other Set or both.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	r := make(Set[T], len(s)+len(other))
+	for v := range s {
+		r[v] = struct{}{}
+	}
+	for v := range other {
+		r[v] = struct{}{}
+	}
+	return r
 }
 
-// Map accepts an Iterable and MapFunc closure and creates a MapIterator that
-// will perform the map operation on the values of the provided Iterable and
-// returns the transformed values when iterated.
-func Map[T any, R any](iter Iterable[T], f MapFunc[T, R]) *MapIterator[T, R] {
-	return &MapIterator[T, R]{
-		srcItr:  iter,
-		mapFunc: f,
+// Intersect returns a new Set with the values that are in both the Set and the other Set.
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	r := Set[T]{}
+	for v := range small {
+		if _, ok := large[v]; ok {
+			r[v] = struct{}{}
+		}
 	}
+	return r
 }
 
-// Filter
+// Diff returns a new Set with the values that are in the Set, but not in the other Set.
+func (s Set[T]) Diff(other Set[T]) Set[T] {
+	r := Set[T]{}
+	for v := range s {
+		if _, ok := other[v]; !ok {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
 
-// PredicateFunc is the closure type that needs to be provided to Filter to perform the filter operation with.
-// If the predicate returns true the value will be returned, otherwise it will be filtered.
-type PredicateFunc[T any] func(T) bool
+// ToChannel
 
-// FilterIterator is a struct the implements an Iterable that performs the filter operation.
-type FilterIterator[T any] struct {
-	// srcItr is the Iterable this iterator pulls the original values from.
-	srcItr Iterable[T]
-	// PredicateFunc is the closure that determines is the value needs to be filtered or not.
-	predicate PredicateFunc[T]
-}
+// ToChannel renders the Iterable to a channel.
+func ToChannel[T any](iter Iterable[T], c chan<- T) error {
 
-// Next returns the first or next value of T and true if a value is available.
-// Each value is checked against the provided PredicateFunc closure. When false is returned the value will be filtered.
-// If no more values are available or an error has occurred then a zero value of T and false is returned.
-func (iter *FilterIterator[T]) Next() (T, bool) {
-	for v, b := iter.srcItr.Next(); b; v, b = iter.srcItr.Next() {
-		if iter.predicate(v) {
-			return v, true
-		}
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		c <- v
 	}
-	var t T
-	return t, false
+
+	return iter.Error()
 }
 
-// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
-// an error is returned.
-func (iter *FilterIterator[T]) Error() error {
-	return iter.srcItr.Error()
+// NewChannelFrom accepts an Iterable and a buffer size and starts a goroutine that sends the values to the returned
+// value channel, which has the provided buffer size. The value channel is closed when the iteration has completed.
+// The error channel then receives the error of the iteration, when one occurred, and is closed. Receiving from the
+// error channel after the value channel was closed therefore returns nil on success. The value channel must be
+// drained, otherwise the goroutine is blocked forever.
+func NewChannelFrom[T any](iter Iterable[T], buffer int) (<-chan T, <-chan error) {
+	c := make(chan T, buffer)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		err := ToChannel(iter, c)
+		close(c)
+		if err != nil {
+			errc <- err
+		}
+	}()
+	return c, errc
 }
 
-// Filter accepts an Iterable and PredicateFunc closure and creates a FilterIterator that
-// will perform the filter operation on the values of the provided Iterable and
-// returns the filtered values when iterated.
-func Filter[T any](iter Iterable[T], predicate PredicateFunc[T]) *FilterIterator[T] {
-	return &FilterIterator[T]{
-		srcItr:    iter,
-		predicate: predicate,
-	}
+// Channel accepts an Iterable and a buffer size and starts a goroutine that sends the values to the returned channel,
+// which has the provided buffer size and is closed when the iteration has completed. The goroutine owns the channel,
+// so no goroutine or close handling is needed at the call site. The channel is closed after the iteration has
+// completed, so Error of the Iterable can be called safely after the channel was closed to check whether an error
+// occurred. The channel must be drained, otherwise the goroutine is blocked forever. Use ChannelCtx to stop early.
+func Channel[T any](iter Iterable[T], buffer int) <-chan T {
+	c := make(chan T, buffer)
+	go func() {
+		defer close(c)
+		for v, b := iter.Next(); b; v, b = iter.Next() {
+			c <- v
+		}
+	}()
+	return c
 }
 
-// Reduce
+// ChannelCtx works like Channel, but the goroutine stops and closes the channel when the context is cancelled, also
+// while it is waiting to send a value or for the next value of an Iterable that implements CtxIterable. Cancelling
+// the context therefore releases the goroutine when the consumer stops receiving before the channel was closed.
+func ChannelCtx[T any](ctx context.Context, iter Iterable[T], buffer int) <-chan T {
+	c := make(chan T, buffer)
+	go func() {
+		defer close(c)
+		for ctx.Err() == nil {
+			v, b := nextCtx(ctx, iter)
+			if !b {
+				return
+			}
+			select {
+			case c <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
 
-// ReduceFunc is the closure type that needs to be provided to Reduce to perform the reduce operation with.
-type ReduceFunc[T any, R any] func(R, T) R
+// ToCSV
 
-// Reduce accepts an Iterable, init value and ReduceFunc and reduces the values of the iterator to a single value by
-// calling the ReduceFunc closure.
-func Reduce[T any, R any](iter Iterable[T], init R, reducer ReduceFunc[T, R]) (R, error) {
+// ToCSV writes each record of the Iterable to the csv.Writer and flushes the writer when the iteration has completed.
+// An error is returned when writing failed or an error during iteration has occurred.
+func ToCSV(iter Iterable[[]string], w *csv.Writer) error {
 	for v, b := iter.Next(); b; v, b = iter.Next() {
-		init = reducer(init, v)
+		if err := w.Write(v); err != nil {
+			return err
+		}
 	}
-	return init, iter.Error()
+	w.Flush()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return w.Error()
 }
 
-// ToSlice
-
-// ToSlice renders the Iterable to a slice.
-func ToSlice[T any](iter Iterable[T]) ([]T, error) {
-	var result []T
+// ToJSONArray
 
+// ToJSONArray writes the values of the Iterable to w as a JSON array. Each value is encoded and written when it is
+// pulled, so the array is never buffered as a whole. When an error during iteration has occurred the closing bracket
+// is not written, so a reader of the output notices that the array is incomplete. An error is returned when encoding
+// or writing failed or an error during iteration has occurred.
+func ToJSONArray[T any](iter Iterable[T], w io.Writer) error {
+	// buf is reused for each element, which is written together with the separator that precedes it.
+	var buf []byte
+	sep := byte('[')
 	for v, b := iter.Next(); b; v, b = iter.Next() {
-		result = append(result, v)
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf[:0], sep), data...)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		sep = ','
 	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if sep == '[' {
+		_, err := io.WriteString(w, "[]")
+		return err
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
 
-	return result, iter.Error()
+// ToJSONLines writes the values of the Iterable to w as newline delimited JSON, also known as NDJSON or JSON Lines,
+// with one encoded value per line. An error is returned when encoding or writing failed or an error during iteration
+// has occurred.
+func ToJSONLines[T any](iter Iterable[T], w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
 }
 
-// ToChannel
+// ExecuteTemplatePerElement
 
-// ToChannel renders the Iterable to a channel.
-func ToChannel[T any](iter Iterable[T], c chan<- T) error {
+// Template is the interface implemented by the templates of text/template and html/template.
+type Template interface {
+	Execute(w io.Writer, data any) error
+}
 
+// ExecuteTemplatePerElement accepts an Iterable, a Template and an io.Writer and executes the template once for each
+// value, with the value as data, streaming the output to the writer. This renders large datasets without collecting
+// them into a slice for a range action. The iteration stops at the first error of the template. An error is returned
+// when an error during iteration has occurred.
+func ExecuteTemplatePerElement[T any](iter Iterable[T], tmpl Template, w io.Writer) error {
 	for v, b := iter.Next(); b; v, b = iter.Next() {
-		c <- v
+		if err := tmpl.Execute(w, v); err != nil {
+			return err
+		}
 	}
-
 	return iter.Error()
 }
 
@@ -256,6 +5348,16 @@ func (g *GeneratingIterator[T]) Next() (T, bool) {
 	return t, r
 }
 
+// SizeHint returns the amount of values that remain to be generated and true. When the amount does not fit in an int
+// false is returned.
+func (g *GeneratingIterator[T]) SizeHint() (int, bool) {
+	n := g.repeat - g.count
+	if n > math.MaxInt {
+		return 0, false
+	}
+	return int(n), true
+}
+
 // Error returns nil after Next returned false when the iteration has completed successfully, otherwise
 // an error is returned. The GeneratingIterator never returns an error.
 func (g *GeneratingIterator[T]) Error() error {
@@ -273,11 +5375,242 @@ func Generate[T any](p T, r uint64, gf GeneratorFunc[T]) *GeneratingIterator[T]
 	}
 }
 
+// GenerateCtx works like Generate, but stops iterating when the context is cancelled. Error then returns the error of
+// the context.
+func GenerateCtx[T any](ctx context.Context, p T, r uint64, gf GeneratorFunc[T]) *ContextIterator[T] {
+	return WithContext[T](ctx, Generate(p, r, gf))
+}
+
+// UnfoldIterator is a generic struct implementing an iterator that returns the values of a closure, which
+// evolves a state with each iteration.
+type UnfoldIterator[S any, T any] struct {
+	// state contains the state that is passed to the closure.
+	state S
+	// f contains the closure that returns a value, the next state and false when the iteration has completed.
+	f func(S) (T, S, bool)
+	// done is set when the closure signalled that the iteration has completed.
+	done bool
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// The closure is not called anymore after it returned false.
+// If no more values are available then a zero value of T and false is returned.
+func (u *UnfoldIterator[S, T]) Next() (T, bool) {
+	var zero T
+	if u.done {
+		return zero, false
+	}
+	v, next, ok := u.f(u.state)
+	if !ok {
+		u.done = true
+		return zero, false
+	}
+	u.state = next
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The UnfoldIterator never returns an error.
+func (u *UnfoldIterator[S, T]) Error() error {
+	return nil
+}
+
+// Unfold accepts a seed state and a closure and returns an UnfoldIterator. Each iteration the closure is called with
+// the current state, starting with seed, and returns a value, the next state and true, or false when the iteration
+// has completed. The value returned together with false is ignored.
+func Unfold[S any, T any](seed S, f func(S) (T, S, bool)) *UnfoldIterator[S, T] {
+	return &UnfoldIterator[S, T]{
+		state: seed,
+		f:     f,
+	}
+}
+
+// FibonacciIterator is a struct the implements an Iterable that generates the Fibonacci numbers.
+type FibonacciIterator struct {
+	// a contains the next number to return.
+	a uint64
+	// b contains the number after a.
+	b uint64
+	// last is set when a is the last number that fits in an uint64.
+	last bool
+	// done is set when all numbers that fit in an uint64 have been returned.
+	done bool
+}
+
+// Next returns the first or next Fibonacci number and true if a value is available.
+// If no more values are available then zero and false is returned.
+func (f *FibonacciIterator) Next() (uint64, bool) {
+	if f.done {
+		return 0, false
+	}
+	v := f.a
+	switch {
+	case f.last:
+		f.done = true
+	case f.a > math.MaxUint64-f.b:
+		f.a, f.last = f.b, true
+	default:
+		f.a, f.b = f.b, f.a+f.b
+	}
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The FibonacciIterator never returns an error.
+func (f *FibonacciIterator) Error() error {
+	return nil
+}
+
+// Fibonacci returns a FibonacciIterator that generates the Fibonacci numbers starting with 0, 1, 1, 2, 3. The
+// iteration is infinite for practical purposes, but ends after the 94th number, because the next number does not fit
+// in an uint64. Use Take or TakeWhile to limit the iteration.
+func Fibonacci() *FibonacciIterator {
+	return &FibonacciIterator{
+		a: 0,
+		b: 1,
+	}
+}
+
+// PrimeIterator is a struct the implements an Iterable that generates the prime numbers.
+type PrimeIterator struct {
+	// primes contains the primes generated so far, except 2. They are used to test the next candidates.
+	primes []int
+	// candidate contains the last returned prime.
+	candidate int
+}
+
+// Next returns the first or next prime number and true.
+func (p *PrimeIterator) Next() (int, bool) {
+	if p.candidate < 2 {
+		p.candidate = 2
+		return p.candidate, true
+	}
+	if p.candidate == 2 {
+		p.candidate = 3
+	} else {
+		p.candidate += 2
+	}
+	for !p.isPrime(p.candidate) {
+		p.candidate += 2
+	}
+	p.primes = append(p.primes, p.candidate)
+	return p.candidate, true
+}
+
+// isPrime returns true when the odd number n is not divisible by any of the odd primes generated so far.
+func (p *PrimeIterator) isPrime(n int) bool {
+	for _, prime := range p.primes {
+		if prime*prime > n {
+			break
+		}
+		if n%prime == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The PrimeIterator never returns an error.
+func (p *PrimeIterator) Error() error {
+	return nil
+}
+
+// Primes returns an infinite PrimeIterator that generates the prime numbers in ascending order starting with 2.
+// Each prime is tested by trial division with the primes that were generated before, which are kept in memory.
+// Use Take or TakeWhile to limit the iteration.
+func Primes() *PrimeIterator {
+	return &PrimeIterator{}
+}
+
+// RandomIterator is a generic struct implementing an infinite iterator that generates uniformly distributed random
+// numbers.
+type RandomIterator[T Number] struct {
+	// rand generates the random bits.
+	rand *rand.Rand
+	// min contains the smallest value that can be generated.
+	min T
+	// max contains the upper bound of the generated values, which is never generated itself.
+	max T
+}
+
+// Next returns the next random value of T and true.
+func (ri *RandomIterator[T]) Next() (T, bool) {
+	if T(1)/2 != 0 {
+		for {
+			// Rounding can make the result equal to max, especially for float32.
+			if v := ri.min + T(ri.rand.Float64())*(ri.max-ri.min); v < ri.max {
+				return v, true
+			}
+		}
+	}
+	// The conversions to uint64 and back rely on wrapping arithmetic, so the span of signed ranges is correct even
+	// when max-min overflows T.
+	span := uint64(ri.max) - uint64(ri.min)
+	// Values below threshold are rejected, so each offset within the span is equally likely.
+	threshold := -span % span
+	for {
+		if v := ri.rand.Uint64(); v >= threshold {
+			return ri.min + T(v%span), true
+		}
+	}
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The RandomIterator never returns an error.
+func (ri *RandomIterator[T]) Error() error {
+	return nil
+}
+
+// Random creates an infinite RandomIterator that generates uniformly distributed random numbers in the half-open
+// range [min, max) with the provided rand.Source. The same seeded source always generates the same values.
+// Random panics when min is not less than max.
+func Random[T Number](src rand.Source, min, max T) *RandomIterator[T] {
+	if !(min < max) {
+		panic("iterator: min must be less than max")
+	}
+	return &RandomIterator[T]{
+		rand: rand.New(src),
+		min:  min,
+		max:  max,
+	}
+}
+
+// RandomN works like Random, but generates only n values.
+func RandomN[T Number](src rand.Source, min, max T, n int) *TakeIterator[T] {
+	return Take[T](Random(src, min, max), n)
+}
+
 // The SignedIntegers interface defines all valid numerics to be used in the generic NumberGenerator
 type SignedIntegers interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64
 }
 
+// The UnsignedIntegers interface defines all unsigned integer types.
+type UnsignedIntegers interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// The Integers interface defines all signed and unsigned integer types.
+type Integers interface {
+	SignedIntegers | UnsignedIntegers
+}
+
+// The Floats interface defines all floating point types.
+type Floats interface {
+	~float32 | ~float64
+}
+
+// The Number interface defines all integer and floating point types.
+type Number interface {
+	Integers | Floats
+}
+
+// The Ordered interface defines all types that support the < operator.
+type Ordered interface {
+	Integers | Floats | ~string
+}
+
 // RepeatingIntegerGenerator accepts en initial value, a repeat value and a step value.
 // The initial value is increased after each iteration step with the step value.
 func RepeatingIntegerGenerator[T SignedIntegers](i T, r uint64, s T) *GeneratingIterator[T] {
@@ -317,3 +5650,142 @@ func StepSequence[T SignedIntegers](start T, end T, step T) *GeneratingIterator[
 func Sequence[T SignedIntegers](start T, end T) *GeneratingIterator[T] {
 	return StepSequence(start, end, 1)
 }
+
+// SequenceFrom accepts an integer start value and returns a GeneratingIterator that counts upward from start
+// (inclusive) with a step of 1 without an end. The values wrap around when the maximum value of T is exceeded.
+// The iterator is infinite, use Take or TakeWhile to bound it.
+func SequenceFrom[T Integers](start T) *GeneratingIterator[T] {
+	return Generate(start, math.MaxUint64, func(p T, c uint64, r uint64) T {
+		return start + T(c)
+	})
+}
+
+// Linspace returns a GeneratingIterator that returns n evenly spaced values from start (inclusive) to end (inclusive).
+// Each value is calculated from its index instead of adding a step repeatedly, so rounding errors do not accumulate,
+// and the last value is exactly end. When n is 1 only start is returned. Linspace panics when n is negative.
+func Linspace(start, end float64, n int) *GeneratingIterator[float64] {
+	if n < 0 {
+		panic("iterator: n must not be negative")
+	}
+	return Generate(start, uint64(n), func(p float64, c uint64, r uint64) float64 {
+		if c == 0 {
+			return start
+		}
+		if c == r-1 {
+			return end
+		}
+		return start + (end-start)*float64(c)/float64(r-1)
+	})
+}
+
+// floatStepTolerance is the fraction of a step by which end may be missed because of rounding errors while it is
+// still returned by FloatStepSequence.
+const floatStepTolerance = 1e-9
+
+// FloatStepSequence returns a GeneratingIterator that returns the values start, start+step, start+2*step, and so on,
+// up to end. End is only returned when it is reached within a tolerance of a billionth of step, which absorbs the
+// rounding errors of steps that cannot be represented exactly, like 0.1. Each value is calculated from its index
+// instead of adding step repeatedly, so rounding errors do not accumulate. FloatStepSequence will correct the sign of
+// step for generating a sequence from start to end, and panics when step is zero, infinite or NaN.
+func FloatStepSequence(start, end, step float64) *GeneratingIterator[float64] {
+	if step == 0 || math.IsInf(step, 0) || math.IsNaN(step) {
+		panic("iterator: step must be finite and not zero")
+	}
+	step = math.Abs(step)
+	if start > end {
+		step = -step
+	}
+	steps := math.Floor((end-start)/step + floatStepTolerance)
+	return Generate(start, uint64(steps)+1, func(p float64, c uint64, r uint64) float64 {
+		return start + step*float64(c)
+	})
+}
+
+// SequenceCtx works like Sequence, but stops iterating when the context is cancelled. Error then returns the error of
+// the context, so backfills over very large ranges can be interrupted.
+func SequenceCtx[T SignedIntegers](ctx context.Context, start T, end T) *ContextIterator[T] {
+	return WithContext[T](ctx, Sequence(start, end))
+}
+
+// IDs accepts a closure that returns identifiers and returns a GeneratingIterator that returns a new identifier each
+// iteration. The iterator is infinite, use Take to bound it.
+func IDs(gen func() string) *GeneratingIterator[string] {
+	return Generate("", math.MaxUint64, func(p string, c, r uint64) string {
+		return gen()
+	})
+}
+
+// IDsErr works like IDs, but accepts a closure that can fail, like the closure returned by NewULIDGenerator. The
+// iteration stops when the closure returns an error, which is then returned by Error.
+func IDsErr(gen func() (string, error)) *FuncIterator[string] {
+	return FromFuncErr(func() (string, bool, error) {
+		id, err := gen()
+		if err != nil {
+			return "", false, err
+		}
+		return id, true, nil
+	})
+}
+
+// NewMonotonicIDGenerator returns a closure that returns the prefix followed by a zero padded counter that starts
+// at 1, so the identifiers sort in the order they were generated. The closure is not safe for concurrent use.
+func NewMonotonicIDGenerator(prefix string) func() string {
+	var counter uint64
+	return func() string {
+		counter++
+		return fmt.Sprintf("%s%020d", prefix, counter)
+	}
+}
+
+// crockford is the Crockford base32 alphabet used to encode ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ErrULIDOverflow is returned by the closure of NewULIDGenerator when the random bits cannot be incremented any
+// further within the same millisecond.
+var ErrULIDOverflow = errors.New("iterator: ULID random bits overflow")
+
+// NewULIDGenerator returns a closure that returns ULIDs: 26 character identifiers that contain the current time in
+// milliseconds followed by 80 random bits from the provided rand.Source. Identifiers generated within the same
+// millisecond increment the random bits, so the identifiers sort in the order they were generated. When the random
+// bits overflow the closure returns ErrULIDOverflow until the next millisecond, as the ULID specification requires.
+// Use IDsErr to iterate the identifiers. The closure is not safe for concurrent use.
+func NewULIDGenerator(src rand.Source) func() (string, error) {
+	r := rand.New(src)
+	var lastMs, hi, lo uint64
+	return func() (string, error) {
+		ms := uint64(time.Now().UnixMilli())
+		if ms <= lastMs {
+			// Keep the identifiers monotonic within the same millisecond, or when the clock moves backwards.
+			if hi == 0xFFFF && lo == math.MaxUint64 {
+				return "", ErrULIDOverflow
+			}
+			ms = lastMs
+			lo++
+			if lo == 0 {
+				hi++
+			}
+		} else {
+			lastMs = ms
+			hi = uint64(r.Int63n(1 << 16))
+			lo = r.Uint64()
+		}
+		// The 128 bit ULID is stored in two words: 48 bits of time and 16 random bits, followed by 64 random bits.
+		top := ms<<16 | hi
+		var id [26]byte
+		for i := range id {
+			// Each character encodes 5 bits of the 130 bit number, of which the 2 most significant bits are zero.
+			pos := uint(125 - 5*i)
+			var v uint64
+			switch {
+			case pos >= 64:
+				v = top >> (pos - 64)
+			case pos > 59:
+				v = top<<(64-pos) | lo>>pos
+			default:
+				v = lo >> pos
+			}
+			id[i] = crockford[v&0x1F]
+		}
+		return string(id[:]), nil
+	}
+}
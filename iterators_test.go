@@ -1,13 +1,37 @@
 package iterator
 
 import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"container/list"
+	"container/ring"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/cucumber/godog"
+	"io"
+	"io/fs"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"testing/iotest"
+	"text/template"
+	"time"
 )
 
 // Examples
@@ -317,388 +341,5556 @@ func ExampleGenerate() {
 	// 3
 }
 
+func ExampleSplit() {
+	// Split groups the values of an Iterable into slices. Values for which the predicate returns true are used
+	// as delimiter and are dropped.
+	si := FromSlice([]string{"GET", "/", "|", "POST", "/users", "|", "DELETE", "/users/1"})
+	pi := Split[string](si, func(v string) bool {
+		return v == "|"
+	})
+
+	// Print each group from the split iterator. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[[]string](pi, func(v []string) {
+		fmt.Println(v)
+	})
+
+	// Output:
+	// [GET /]
+	// [POST /users]
+	// [DELETE /users/1]
+}
+
+func ExampleToSliceMax() {
+	// ToSliceMax works like ToSlice, but refuses to collect more values than the given maximum.
+
+	// Get a sequence iterator that generates values from 1 to 1000.
+	si := Sequence(1, 1000)
+	// Convert the iterator into a slice, but allow at most 10 values.
+	s, err := ToSliceMax[int](si, 10)
+	if errors.Is(err, ErrTooMany) {
+		fmt.Println("too many values, got:", s)
+	}
+
+	// Output:
+	// too many values, got: [1 2 3 4 5 6 7 8 9 10]
+}
+
+func ExampleTopK() {
+	type player struct {
+		name  string
+		score int
+	}
+
+	players := FromSlice([]player{{"ann", 12}, {"bob", 40}, {"cid", 7}, {"dee", 33}, {"eve", 21}})
+
+	// TopK keeps only the k best values in memory while iterating. Error is ignored. Errors can only occur in
+	// Iterators which can have an error state. For example a custom iterator that reads data from the database, but
+	// the connection is terminated while the iteration was not completed.
+	best, _ := TopK[player](players, 3, func(a, b player) bool {
+		return a.score < b.score
+	})
+
+	for _, p := range best {
+		fmt.Println(p.name, p.score)
+	}
+
+	// Output:
+	// bob 40
+	// dee 33
+	// eve 21
+}
+
+func ExampleDiffSortedFunc() {
+	type account struct {
+		id      int
+		balance int
+	}
+
+	yesterday := FromSlice([]account{{1, 100}, {2, 250}, {3, 75}})
+	today := FromSlice([]account{{1, 100}, {3, 80}, {4, 10}})
+
+	// Accounts are ordered by id. An account with the same id but a different balance is Modified.
+	di := DiffSortedFunc[account](yesterday, today, func(a, b account) int {
+		return a.id - b.id
+	}, func(a, b account) bool {
+		return a.balance == b.balance
+	})
+
+	// Print each change from the diff iterator. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[Change[account]](di, func(c Change[account]) {
+		fmt.Println(c.Kind, c.Old, c.New)
+	})
+
+	// Output:
+	// Unchanged {1 100} {1 100}
+	// Removed {2 250} {0 0}
+	// Modified {3 75} {3 80}
+	// Added {0 0} {4 10}
+}
+
+func ExampleTag() {
+	lines := FromSlice([]string{"12", "x", "7"})
+
+	// Tag each line with its line number, so it is still known after the line has been transformed.
+	ti := Tag[string](lines, func(i int, v string) map[string]any {
+		return map[string]any{"line": i + 1}
+	})
+	// Parse each line. The metadata is kept.
+	mi := MapTagged[string, error](ti, func(v string) error {
+		_, err := strconv.Atoi(v)
+		return err
+	})
+	// Keep only the lines that failed to parse.
+	fi := FilterTagged[error](mi, func(err error) bool {
+		return err != nil
+	})
+
+	// Print each failure with its line number. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[Tagged[error]](fi, func(v Tagged[error]) {
+		fmt.Println("line", v.Meta["line"], "failed:", v.Value)
+	})
+
+	// Untag removes the envelope again.
+	values, _ := ToSlice[string](Untag[string](Tag[string](FromSlice([]string{"a", "b"}), func(i int, v string) map[string]any {
+		return nil
+	})))
+	fmt.Println(values)
+
+	// Output:
+	// line 2 failed: strconv.Atoi: parsing "x": invalid syntax
+	// [a b]
+}
+
+func ExampleSampleReservoir() {
+	// Get a sequence iterator that generates values from 1 to 1000000.
+	si := Sequence(1, 1000000)
+
+	// Take a sample of 5 values in a single pass. The seed makes the sample reproducible. Error is ignored.
+	// Errors can only occur in Iterators which can have an error state. For example a custom iterator that reads
+	// data from the database, but the connection is terminated while the iteration was not completed.
+	sample, _ := SampleReservoir[int](si, 5, rand.NewSource(42))
+
+	fmt.Println(len(sample))
+
+	// Output:
+	// 5
+}
+
+func ExamplePartitionConsistent() {
+	users := FromSlice([]string{"alice", "bob", "carol", "alice", "bob"})
+
+	// Annotate each user with the worker that owns it. Equal keys always end up at the same worker.
+	pi := PartitionConsistent[string](users, func(v string) string {
+		return v
+	}, 3)
+
+	// Print each user with its partition. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[Partitioned[string]](pi, func(v Partitioned[string]) {
+		fmt.Println(v.Value, v.Partition)
+	})
+
+	// Output:
+	// alice 0
+	// bob 2
+	// carol 2
+	// alice 0
+	// bob 2
+}
+
+func ExampleSample() {
+	// Get a sequence iterator that generates values from 1 to 10000.
+	si := Sequence(1, 10000)
+	// Forward roughly 1 percent of the values. The seed makes the sample reproducible.
+	fi := Sample[int](si, 0.01, rand.NewSource(7))
+
+	// Count the sampled values. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	count, _ := Reduce[int](fi, 0, func(c int, v int) int {
+		return c + 1
+	})
+
+	fmt.Println(count > 50 && count < 150)
+
+	// Output:
+	// true
+}
+
+func ExampleMovingAverage() {
+	latencies := FromSlice([]int{10, 20, 30, 40, 50})
+
+	// Calculate the average of the last 2 values for each value.
+	ai := MovingAverage[int](latencies, 2)
+
+	// Print each average. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[float64](ai, func(v float64) {
+		fmt.Println(v)
+	})
+
+	// Output:
+	// 10
+	// 15
+	// 25
+	// 35
+	// 45
+}
+
+func ExampleIDs() {
+	// IDs returns an infinite iterator, Take bounds it to 3 identifiers.
+	ii := Take[string](IDs(NewMonotonicIDGenerator("order-")), 3)
+
+	// Print each identifier. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[string](ii, func(v string) {
+		fmt.Println(v)
+	})
+
+	// Output:
+	// order-00000000000000000001
+	// order-00000000000000000002
+	// order-00000000000000000003
+}
+
+func ExampleTake() {
+	// Get a sequence iterator that generates values from 1 to 10.
+	si := Sequence(1, 10)
+	// Only take the first 3 values.
+	ti := Take[int](si, 3)
+
+	// Print each value. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[int](ti, func(v int) {
+		fmt.Println(v)
+	})
+
+	// Output:
+	// 1
+	// 2
+	// 3
+}
+
+func ExampleDelta() {
+	// Counter readings that only increase.
+	readings := FromSlice([]uint64{100, 130, 180, 190})
+	// Delta returns the increase between each reading.
+	di := Delta[uint64](readings)
+
+	// Print each difference. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[uint64](di, func(v uint64) {
+		fmt.Println(v)
+	})
+
+	// Output:
+	// 30
+	// 50
+	// 10
+}
+
+func ExampleRank() {
+	type player struct {
+		name  string
+		score int
+	}
+
+	players := FromSlice([]player{{"ann", 12}, {"bob", 40}, {"cid", 12}, {"dee", 33}})
+
+	// Rank the players with the highest score first.
+	ri := Rank[player](players, func(a, b player) bool {
+		return a.score > b.score
+	})
+
+	// Print the leaderboard. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[Ranked[player]](ri, func(v Ranked[player]) {
+		fmt.Println(v.Rank, v.Value.name, v.Value.score)
+	})
+
+	// Output:
+	// 1 bob 40
+	// 2 dee 33
+	// 3 ann 12
+	// 3 cid 12
+}
+
+func ExampleNormalizeMinMax() {
+	temperatures := FromSlice([]float64{12.5, 20, 15, 10})
+
+	// Scale the temperatures to the range 0 to 1. The iterator is consumed by NormalizeMinMax.
+	ni, err := NormalizeMinMax[float64](temperatures)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Print each scaled value. Error is ignored, because the values are buffered by NormalizeMinMax.
+	_ = ForEach[float64](ni, func(v float64) {
+		fmt.Println(v)
+	})
+
+	// Output:
+	// 0.25
+	// 1
+	// 0.5
+	// 0
+}
+
+func ExampleWithContext() {
+	// A channel that never receives values and is never closed.
+	c := make(chan int)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// WithContext interrupts the blocking Next of the channel iterator when the context is cancelled.
+	ci := WithContext[int](ctx, FromChannel(c))
+
+	err := ForEach[int](ci, func(v int) {
+		fmt.Println(v)
+	})
+
+	fmt.Println(err)
+
+	// Output:
+	// context deadline exceeded
+}
+
+func ExamplePadOrTruncate() {
+	// Render fixed width records of 3 columns from variable length rows.
+	rows := [][]string{{"a", "b"}, {"c", "d", "e", "f"}}
+
+	for _, row := range rows {
+		// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom
+		// iterator that reads data from the database, but the connection is terminated while the iteration was not
+		// completed.
+		record, _ := ToSlice[string](PadOrTruncate[string](FromSlice(row), 3, "-"))
+		fmt.Println(record)
+	}
+
+	// Output:
+	// [a b -]
+	// [c d e]
+}
+
+func ExampleFromKeysetPages() {
+	// users is a table sorted by id.
+	users := []string{"ann", "bob", "cid", "dee", "eve"}
+
+	// fetch returns at most limit users with an id greater than after, like:
+	// SELECT id, name FROM users WHERE id > $1 ORDER BY id LIMIT $2
+	fetch := func(after int, limit int) ([]string, int, bool, error) {
+		end := after + limit
+		if end > len(users) {
+			end = len(users)
+		}
+		return users[after:end], end, end < len(users), nil
+	}
+
+	// Print each user. The error of the fetch closure is returned by ForEach.
+	err := ForEach[string](FromKeysetPages(fetch, 2), func(v string) {
+		fmt.Println(v)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// ann
+	// bob
+	// cid
+	// dee
+	// eve
+	// <nil>
+}
+
+func ExampleThrottle() {
+	// Replay events with at most one event per 10 milliseconds.
+	ti := Throttle[string](FromSlice([]string{"login", "click", "logout"}), 10*time.Millisecond)
+
+	start := time.Now()
+	// Print each event. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[string](ti, func(v string) {
+		fmt.Println(v)
+	})
+	fmt.Println(time.Since(start) >= 20*time.Millisecond)
+
+	// Output:
+	// login
+	// click
+	// logout
+	// true
+}
+
+func ExampleDebounce() {
+	keystrokes := make(chan string)
+
+	go func() {
+		defer close(keystrokes)
+		// The user types quickly, pauses and types again.
+		for _, v := range []string{"g", "go", "gol"} {
+			keystrokes <- v
+		}
+		time.Sleep(50 * time.Millisecond)
+		keystrokes <- "golang"
+	}()
+
+	// Only search when the user stopped typing for 20 milliseconds.
+	di := Debounce[string](FromChannel(keystrokes), 20*time.Millisecond)
+
+	// Print each search. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[string](di, func(v string) {
+		fmt.Println("search:", v)
+	})
+
+	// Output:
+	// search: gol
+	// search: golang
+}
+
+func ExampleWithRemaining() {
+	files := FromSlice([]string{"a.csv", "b.csv", "c.csv", "d.csv"})
+
+	// Print the progress of each file. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[Counted[string]](WithRemaining[string](files), func(c Counted[string]) {
+		fmt.Printf("processed %s, %d of %d (%.0f%%)\n", c.Value, c.Index+1, c.Total, c.Percent())
+	})
+
+	// Output:
+	// processed a.csv, 1 of 4 (25%)
+	// processed b.csv, 2 of 4 (50%)
+	// processed c.csv, 3 of 4 (75%)
+	// processed d.csv, 4 of 4 (100%)
+}
+
+func ExampleDistinctWithLimit() {
+	events := FromSlice([]string{"a", "b", "a", "c", "b", "d"})
+
+	// Drop duplicate events, but remember at most 1000 events.
+	di := DistinctWithLimit[string](events, 1000)
+
+	// Print each distinct event. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[string](di, func(v string) {
+		fmt.Println(v)
+	})
+	fmt.Println("evicted:", di.Evicted())
+
+	// Output:
+	// a
+	// b
+	// c
+	// d
+	// evicted: 0
+}
+
+func ExampleParallelMapKeyed() {
+	type deposit struct {
+		account string
+		amount  int
+	}
+
+	var mu sync.Mutex
+	balances := map[string]int{}
+
+	deposits := FromSlice([]deposit{{"ann", 10}, {"bob", 5}, {"ann", 20}, {"cid", 7}, {"bob", 1}})
+
+	// Process at most 3 deposits at the same time, but never 2 deposits for the same account.
+	pi := ParallelMapKeyed[deposit, string, string](deposits, func(d deposit) string {
+		return d.account
+	}, 1, 3, func(d deposit) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		balances[d.account] += d.amount
+		return d.account, nil
+	})
+
+	err := ForEach[string](pi, func(string) {})
+
+	fmt.Println(err, balances)
+
+	// Output:
+	// <nil> map[ann:30 bob:6 cid:7]
+}
+
+func ExampleRoundRobin() {
+	// Serve the requests of each tenant fairly.
+	ann := FromSlice([]string{"ann-1", "ann-2", "ann-3"})
+	bob := FromSlice([]string{"bob-1"})
+	cid := FromSlice([]string{"cid-1", "cid-2"})
+
+	ri := RoundRobin[string](ann, bob, cid)
+
+	// Print each request. Error is ignored. Errors can only occur in Iterators which can have
+	// an error state. For example a custom iterator that reads data from the database, but the connection is
+	// terminated while the iteration was not completed.
+	_ = ForEach[string](ri, func(v string) {
+		fmt.Println(v)
+	})
+
+	// Output:
+	// ann-1
+	// bob-1
+	// cid-1
+	// ann-2
+	// cid-2
+	// ann-3
+}
+
+func ExampleRegistry() {
+	type rangeParams struct {
+		From, To int
+	}
+	type filterParams struct {
+		Divisor int
+	}
+
+	r := NewRegistry[int]()
+	_ = RegisterSource(r, "range", func(p rangeParams) (Iterable[int], error) {
+		return Sequence(p.From, p.To), nil
+	})
+	_ = RegisterStage(r, "divisible", func(iter Iterable[int], p filterParams) (Iterable[int], error) {
+		if p.Divisor == 0 {
+			return nil, errors.New("divisor must not be zero")
+		}
+		return Filter(iter, func(v int) bool {
+			return v%p.Divisor == 0
+		}), nil
+	})
+	_ = RegisterSink(r, "print", func(iter Iterable[int], p struct{}) error {
+		return ForEach(iter, func(v int) {
+			fmt.Println(v)
+		})
+	})
+
+	// The specification could be loaded from a configuration file.
+	err := r.Run(PipelineSpec{
+		Source: StepSpec{Name: "range", Params: rangeParams{From: 1, To: 20}},
+		Stages: []StepSpec{{Name: "divisible", Params: filterParams{Divisor: 6}}},
+		Sink:   StepSpec{Name: "print"},
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 6
+	// 12
+	// 18
+	// <nil>
+}
+
+func ExampleSum() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	sum, _ := Sum[int](Sequence(1, 10))
+	product, _ := Product[float64](FromSlice([]float64{1.5, 2, 4}))
+	fmt.Println(sum, product)
+
+	// Output:
+	// 55 12
+}
+
+func ExampleSet() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	admins, _ := SetFromIterator[string](FromSlice([]string{"alice", "bob"}))
+	online, _ := SetFromIterator[string](FromSlice([]string{"bob", "carol", "bob"}))
+
+	fmt.Println(online.Len(), online.Contains("carol"), online.Contains("alice"))
+
+	// Sort the values, because the order of a Set is undefined.
+	all, _ := ToSlice[string](admins.Union(online).Iter())
+	sort.Strings(all)
+	fmt.Println(all)
+
+	onlineAdmins, _ := ToSlice[string](admins.Intersect(online).Iter())
+	fmt.Println(onlineAdmins)
+
+	offlineAdmins, _ := ToSlice[string](admins.Diff(online).Iter())
+	fmt.Println(offlineAdmins)
+
+	// Output:
+	// 2 true false
+	// [alice bob carol]
+	// [bob]
+	// [alice]
+}
+
+func ExampleMin() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	smallest, found, _ := Min[int](FromSlice([]int{3, 0, 7}))
+	fmt.Println(smallest, found)
+
+	largest, found, _ := Max[string](FromSlice([]string{"pear", "apple", "plum"}))
+	fmt.Println(largest, found)
+
+	// The bool distinguishes an empty Iterable from a minimum that equals the zero value.
+	smallest, found, _ = Min[int](FromSlice([]int{}))
+	fmt.Println(smallest, found)
+
+	// Output:
+	// 0 true
+	// plum true
+	// 0 false
+}
+
+func ExampleChain3() {
+	type Record struct {
+		ID   int
+		Name string
+	}
+
+	// The type parameters of each Stage are inferred from the closures and the chained Stages.
+	pipeline := Chain3(
+		FilterStage(func(i int) bool {
+			return i%2 == 0
+		}),
+		MapStage(func(i int) string {
+			return fmt.Sprintf("item-%d", i)
+		}),
+		MapStage(func(s string) Record {
+			return Record{ID: len(s), Name: s}
+		}),
+	)
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	_ = ForEach(pipeline(FromSlice([]int{1, 2, 10})), func(r Record) {
+		fmt.Println(r.ID, r.Name)
+	})
+
+	// Output:
+	// 6 item-2
+	// 7 item-10
+}
+
+func ExampleMaxBy() {
+	type Employee struct {
+		Name   string
+		Salary int
+	}
+	employees := []Employee{{"alice", 4000}, {"bob", 5500}, {"carol", 5000}}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	highest, _, _ := MaxBy[Employee](FromSlice(employees), func(e Employee) int {
+		return e.Salary
+	})
+	first, _, _ := MinBy[Employee](FromSlice(employees), func(e Employee) string {
+		return e.Name
+	})
+	fmt.Println(highest.Name, first.Name)
+
+	// Output:
+	// bob alice
+}
+
+func ExampleConsume() {
+	iter := Consume[int](Sequence(1, 3))
+
+	values, err := ToSlice[int](iter)
+	fmt.Println(values, err)
+
+	// The second terminal fails instead of silently returning no values.
+	sum, err := Sum[int](iter)
+	fmt.Println(sum, err)
+
+	// Output:
+	// [1 2 3] <nil>
+	// 0 iterator: already consumed
+}
+
+func ExampleAverage() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	avg, _ := Average[int](Sequence(1, 10))
+	fmt.Println(avg)
+
+	// Output:
+	// 5.5
+}
+
+func ExampleFromDecoder() {
+	type Manifest struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	}
+	stream := `{"kind": "Service", "name": "web"}
+{"kind": "Deployment", "name": "web"}
+{"kind": "Deployment", "name": "worker"}`
+
+	manifests := FromDecoder[Manifest](json.NewDecoder(strings.NewReader(stream)))
+	deployments := Filter[Manifest](manifests, func(m Manifest) bool {
+		return m.Kind == "Deployment"
+	})
+	err := ForEach[Manifest](deployments, func(m Manifest) {
+		fmt.Println(m.Name)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// web
+	// worker
+	// <nil>
+}
+
+func ExampleFromHTTPBodyLines() {
+	// A response like the one returned by http.Get.
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       io.NopCloser(strings.NewReader("GET /index.html\nGET /favicon.ico\nPOST /login\n")),
+	}
+
+	lines := FromHTTPBodyLines(resp)
+	// Close the body when the iteration is stopped early. Close does nothing when the body is already closed.
+	defer lines.Close()
+
+	posts := Filter[string](lines, func(line string) bool {
+		return strings.HasPrefix(line, "POST")
+	})
+	err := ForEach[string](posts, func(line string) {
+		fmt.Println(line)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// POST /login
+	// <nil>
+}
+
+func ExampleStats() {
+	latencies := FromSlice([]float64{12, 15, 11, 30, 12})
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	s, _ := Stats[float64](latencies)
+	fmt.Printf("count=%d min=%.0f max=%.0f mean=%.1f stddev=%.2f\n", s.Count, s.Min, s.Max, s.Mean, s.StdDev)
+
+	// Output:
+	// count=5 min=11 max=30 mean=16.0 stddev=7.13
+}
+
+func ExampleAny() {
+	isNegative := func(i int) bool {
+		return i < 0
+	}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	anyNegative, _ := Any[int](FromSlice([]int{3, -1, 4}), isNegative)
+	allNegative, _ := All[int](FromSlice([]int{3, -1, 4}), isNegative)
+	noneNegative, _ := None[int](FromSlice([]int{3, 1, 4}), isNegative)
+	fmt.Println(anyNegative, allNegative, noneNegative)
+
+	// Output:
+	// true false true
+}
+
+func ExampleDrainWithin() {
+	pending := FromSlice([]string{"order-1", "order-2", "order-3"})
+
+	// On SIGTERM, flush as much pending work as possible before the process is killed.
+	processed, err := DrainWithin[string](pending, func(order string) {
+		fmt.Println("flushed", order)
+	}, time.Second)
+	fmt.Println(processed, err)
+
+	// Output:
+	// flushed order-1
+	// flushed order-2
+	// flushed order-3
+	// 3 <nil>
+}
+
+func ExampleFind() {
+	type User struct {
+		Name  string
+		Admin bool
+	}
+	users := []User{{"alice", false}, {"bob", true}, {"carol", true}}
+	isAdmin := func(u User) bool {
+		return u.Admin
+	}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	admin, found, _ := Find[User](FromSlice(users), isAdmin)
+	fmt.Println(admin.Name, found)
+
+	index, _ := FindIndex[User](FromSlice(users), isAdmin)
+	fmt.Println(index)
+
+	// Output:
+	// bob true
+	// 1
+}
+
+func ExampleNewChannelFrom() {
+	// NewChannelFrom owns the goroutine, the buffering and the closing of the channels.
+	values, errs := NewChannelFrom[int](StepSequence(1, 10, 2), 2)
+	for v := range values {
+		fmt.Println(v)
+	}
+	// The error channel is read after the value channel has been closed.
+	fmt.Println(<-errs)
+
+	// Output:
+	// 1
+	// 3
+	// 5
+	// 7
+	// 9
+	// <nil>
+}
+
+func ExampleFirst() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	first, _, _ := First[int](Sequence(1, 10))
+	last, _, _ := Last[int](Sequence(1, 10))
+	third, _, _ := Nth[int](Sequence(1, 10), 2)
+	_, found, _ := Nth[int](Sequence(1, 10), 10)
+	fmt.Println(first, last, third, found)
+
+	// Output:
+	// 1 10 3 false
+}
+
+func ExampleExecuteTemplatePerElement() {
+	type Invoice struct {
+		Customer string
+		Amount   float64
+	}
+	invoices := FromSlice([]Invoice{{"Alice & Co", 120}, {"Bob's Bikes", 80.5}})
+
+	// html/template templates can be used as well, so each value is escaped for HTML.
+	tmpl := template.Must(template.New("invoice").Parse("{{.Customer}}: {{printf \"%.2f\" .Amount}}\n"))
+	err := ExecuteTemplatePerElement[Invoice](invoices, tmpl, os.Stdout)
+	fmt.Println(err)
+
+	// Output:
+	// Alice & Co: 120.00
+	// Bob's Bikes: 80.50
+	// <nil>
+}
+
+func ExampleContains() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	found, _ := Contains[string](FromSlice([]string{"red", "green", "blue"}), "green")
+	index, _ := IndexOf[string](FromSlice([]string{"red", "green", "blue"}), "blue")
+	fmt.Println(found, index)
+
+	// Output:
+	// true 2
+}
+
+func ExampleChunkByWeight() {
+	messages := FromSlice([]string{"hello", "world", "a somewhat longer message", "bye"})
+
+	// Pack the messages into batches of at most 16 bytes, like an API with a payload limit.
+	batches := ChunkByWeight[string](messages, func(m string) int {
+		return len(m)
+	}, 16)
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	_ = ForEach[[]string](batches, func(batch []string) {
+		fmt.Printf("%q\n", batch)
+	})
+
+	// Output:
+	// ["hello" "world"]
+	// ["a somewhat longer message"]
+	// ["bye"]
+}
+
+func ExampleToMap() {
+	type User struct {
+		ID   int
+		Name string
+	}
+	users := []User{{1, "alice"}, {2, "bob"}}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	names, _ := ToMap[User](FromSlice(users), func(u User) int {
+		return u.ID
+	}, func(u User) string {
+		return u.Name
+	})
+	fmt.Println(names)
+
+	byName, _ := ToMapKeyed[User](FromSlice(users), func(u User) string {
+		return u.Name
+	})
+	fmt.Println(byName["bob"].ID)
+
+	// Output:
+	// map[1:alice 2:bob]
+	// 2
+}
+
+func ExampleSequenceCtx() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A backfill over a huge range that is stopped when the context is cancelled, for example on shutdown.
+	backfill := SequenceCtx[int64](ctx, 1, 1_000_000_000_000)
+	err := ForEach[int64](backfill, func(id int64) {
+		fmt.Println("backfilled", id)
+		if id == 3 {
+			cancel()
+		}
+	})
+	fmt.Println(err)
+
+	// Output:
+	// backfilled 1
+	// backfilled 2
+	// backfilled 3
+	// context canceled
+}
+
+func ExampleToSet() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	seen, _ := ToSet[string](FromSlice([]string{"a", "b", "a"}))
+
+	// The Set can be used as a plain map.
+	var m map[string]struct{} = seen
+	_, ok := m["b"]
+	fmt.Println(len(m), ok)
+
+	// Output:
+	// 2 true
+}
+
+func ExampleFuse() {
+	iter := Fuse[int](FromSlice([]int{1, 2}))
+	fmt.Println(State[int](iter))
+
+	for v, err := NextErr[int](iter); err == nil; v, err = NextErr[int](iter) {
+		fmt.Println(v)
+	}
+	_, err := NextErr[int](iter)
+	fmt.Println(err, State[int](iter))
+
+	// Output:
+	// Active
+	// 1
+	// 2
+	// iterator: exhausted Exhausted
+}
+
+func ExampleJoinString() {
+	odd := Filter[int](Sequence(1, 10), func(v int) bool {
+		return v%2 != 0
+	})
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	s, _ := JoinString(Map[int, string](odd, strconv.Itoa), ", ")
+	fmt.Println(s)
+
+	// Output:
+	// 1, 3, 5, 7, 9
+}
+
+func ExampleCountBy() {
+	logs := []string{"INFO start", "WARN disk", "INFO ready", "ERROR crash", "INFO stop"}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	levels, _ := CountBy[string](FromSlice(logs), func(line string) string {
+		return strings.Fields(line)[0]
+	})
+	fmt.Println(levels)
+
+	words, _ := Frequencies[string](FromSlice([]string{"a", "b", "a"}))
+	fmt.Println(words)
+
+	// Output:
+	// map[ERROR:1 INFO:3 WARN:1]
+	// map[a:2 b:1]
+}
+
+func ExampleEqual() {
+	// Verify that a refactored pipeline returns the same values as the original.
+	original := Map[int, int](Filter[int](Sequence(1, 10), func(v int) bool {
+		return v%2 == 0
+	}), func(v int) int {
+		return v * v
+	})
+	refactored := Map[int, int](StepSequence(2, 10, 2), func(v int) int {
+		return v * v
+	})
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	equal, _ := Equal[int](original, refactored)
+	fmt.Println(equal)
+
+	// Output:
+	// true
+}
+
+func ExampleCompare() {
+	// Composite keys expressed as streams of their parts.
+	v1 := FromSlice([]int{1, 10, 2})
+	v2 := FromSlice([]int{1, 9, 7})
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	c, _ := Compare[int](v1, v2)
+	fmt.Println(c)
+
+	// Output:
+	// 1
+}
+
+func ExampleIsSorted() {
+	less := func(a, b string) bool {
+		return a < b
+	}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	sorted, _ := IsSorted[string](FromSlice([]string{"apple", "banana", "cherry"}), less)
+	unsorted, _ := IsSorted[string](FromSlice([]string{"banana", "apple"}), less)
+	fmt.Println(sorted, unsorted)
+
+	// Output:
+	// true false
+}
+
+func ExampleReduceWhile() {
+	// Find how many items fit in a box with a capacity of 10.
+	weights := FromSlice([]int{3, 4, 2, 5, 1})
+	type Box struct {
+		Weight, Items int
+	}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	box, _ := ReduceWhile[int, Box](weights, Box{}, func(b Box, w int) (Box, bool) {
+		if b.Weight+w > 10 {
+			return b, false
+		}
+		return Box{Weight: b.Weight + w, Items: b.Items + 1}, true
+	})
+	fmt.Println(box.Items, box.Weight)
+
+	// Output:
+	// 3 9
+}
+
+func ExampleTryFold() {
+	amounts := FromSlice([]string{"10", "20", "x", "40"})
+
+	total, err := TryFold[string, int](amounts, 0, func(sum int, s string) (int, error) {
+		v, err := strconv.Atoi(s)
+		return sum + v, err
+	})
+	fmt.Println(total)
+	fmt.Println(err)
+
+	// Output:
+	// 30
+	// iterator: value at index 2: strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExampleForEachIndexed() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	_ = ForEachIndexed[string](FromSlice([]string{"gold", "silver", "bronze"}), func(i int, medal string) {
+		fmt.Printf("%d. %s\n", i+1, medal)
+	})
+
+	// Output:
+	// 1. gold
+	// 2. silver
+	// 3. bronze
+}
+
+// ringBuffer is a Collector that keeps the last values that were added.
+type ringBuffer struct {
+	values []string
+	next   int
+}
+
+func (r *ringBuffer) Add(v string) error {
+	r.values[r.next] = v
+	r.next = (r.next + 1) % len(r.values)
+	return nil
+}
+
+func ExampleCollectInto() {
+	// Keep the last 2 lines of a log without collecting all lines into a slice.
+	tail := &ringBuffer{values: make([]string, 2)}
+	err := CollectInto[string](FromSlice([]string{"one", "two", "three"}), tail)
+	fmt.Println(tail.values, err)
+
+	// A CollectorFunc turns a closure into a Collector.
+	err = CollectInto[int](Sequence(1, 3), CollectorFunc[int](func(v int) error {
+		if v > 2 {
+			return errors.New("batch is full")
+		}
+		return nil
+	}))
+	fmt.Println(err)
+
+	// Output:
+	// [three two] <nil>
+	// iterator: value at index 2: batch is full
+}
+
+func ExampleMinMax() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	lo, hi, ok, _ := MinMax[float64](FromSlice([]float64{21.5, 19, 23.25, 20}))
+	fmt.Println(lo, hi, ok)
+
+	// Output:
+	// 19 23.25 true
+}
+
+func ExampleQuantiles() {
+	latencies := []float64{12, 15, 11, 30, 12, 14, 13, 90, 12, 16}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	exact, _ := Quantiles[float64](FromSlice(latencies), 0.5, 0.9)
+	fmt.Printf("p50=%.1f p90=%.1f\n", exact[0], exact[1])
+
+	// A P2Quantile estimates a quantile of an unbounded stream in constant memory.
+	p50 := NewP2Quantile(0.5)
+	_ = ForEach[int](Sequence(1, 1000), func(v int) {
+		p50.Add(float64(v))
+	})
+	fmt.Println(math.Round(p50.Value()))
+
+	// Output:
+	// p50=13.5 p90=36.0
+	// 500
+}
+
+func ExampleHistogram() {
+	latencies := FromSlice([]float64{0.02, 0.07, 0.15, 0.3, 0.04, 1.2})
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	h, _ := Histogram[float64](latencies, []float64{0.05, 0.1, 0.5})
+	for i, bound := range h.Bounds {
+		fmt.Printf("<= %v: %d\n", bound, h.Counts[i])
+	}
+	fmt.Printf("> %v: %d\n", h.Bounds[len(h.Bounds)-1], h.Counts[len(h.Bounds)])
+	fmt.Println("total:", h.Total)
+
+	// Output:
+	// <= 0.05: 2
+	// <= 0.1: 1
+	// <= 0.5: 2
+	// > 0.5: 1
+	// total: 6
+}
+
+func ExampleGroupReduce() {
+	type Order struct {
+		Customer string
+		Amount   int
+	}
+	orders := []Order{{"alice", 10}, {"bob", 5}, {"alice", 7}, {"carol", 3}, {"bob", 1}}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	totals, _ := GroupReduce[Order](FromSlice(orders), func(o Order) string {
+		return o.Customer
+	}, 0, func(total int, o Order) int {
+		return total + o.Amount
+	})
+	fmt.Println(totals)
+
+	// Output:
+	// map[alice:17 bob:6 carol:3]
+}
+
+func ExampleFromMapSorted() {
+	stock := map[string]int{"pears": 0, "apples": 12, "plums": 7}
+
+	// Sorted keys make the pipeline deterministic.
+	inStock := Filter[Pair[string, int]](FromMapSorted(stock), func(p Pair[string, int]) bool {
+		return p.Value > 0
+	})
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	_ = ForEach[Pair[string, int]](inStock, func(p Pair[string, int]) {
+		fmt.Println(p.Key, p.Value)
+	})
+
+	keys, _ := ToSlice[string](FromMapKeysSorted(stock))
+	fmt.Println(keys)
+
+	// Output:
+	// apples 12
+	// plums 7
+	// [apples pears plums]
+}
+
+func ExampleFromStringRunes() {
+	// Count the vowels of a text without converting it to a []rune first.
+	vowels := Filter[rune](FromStringRunes("Iterators are lazy"), func(r rune) bool {
+		return strings.ContainsRune("aeiouAEIOU", r)
+	})
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	count, _ := Reduce[rune, int](vowels, 0, func(n int, _ rune) int {
+		return n + 1
+	})
+	fmt.Println(count)
+
+	// Output:
+	// 7
+}
+
+func ExampleFromReader() {
+	r := strings.NewReader("The quick brown fox")
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	_ = ForEach[[]byte](FromReader(r, 8), func(chunk []byte) {
+		fmt.Printf("%q\n", chunk)
+	})
+
+	// Output:
+	// "The quic"
+	// "k brown "
+	// "fox"
+}
+
+func ExampleLines() {
+	log := strings.NewReader("INFO started\nERROR disk full\nINFO stopped\n")
+
+	errorLines := Filter[string](Lines(log), func(line string) bool {
+		return strings.HasPrefix(line, "ERROR")
+	})
+	err := ForEach[string](errorLines, func(line string) {
+		fmt.Println(line)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// ERROR disk full
+	// <nil>
+}
+
+func ExampleFromScanner() {
+	scanner := bufio.NewScanner(strings.NewReader("the quick brown fox jumps"))
+	scanner.Split(bufio.ScanWords)
+
+	long := Filter[string](FromScanner(scanner), func(word string) bool {
+		return len(word) > 4
+	})
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	words, _ := ToSlice[string](long)
+	fmt.Println(words)
+
+	// Output:
+	// [quick brown jumps]
+}
+
+func ExampleFromCSV() {
+	input := "name,city\nalice,Amsterdam\nbob,Berlin\ncarol,Amsterdam\n"
+
+	records := FromCSV(csv.NewReader(strings.NewReader(input)))
+	inAmsterdam := Filter[[]string](records, func(record []string) bool {
+		return record[1] == "Amsterdam"
+	})
+	names := Map[[]string, []string](inAmsterdam, func(record []string) []string {
+		return []string{strings.ToUpper(record[0])}
+	})
+
+	err := ToCSV(names, csv.NewWriter(os.Stdout))
+	fmt.Println(err)
+
+	// Output:
+	// ALICE
+	// CAROL
+	// <nil>
+}
+
+func ExampleFromJSONArray() {
+	type Event struct {
+		Type string `json:"type"`
+		User string `json:"user"`
+	}
+	input := `[{"type": "login", "user": "alice"}, {"type": "logout", "user": "alice"}, {"type": "login", "user": "bob"}]`
+
+	events := FromJSONArray[Event](json.NewDecoder(strings.NewReader(input)))
+	logins := Filter[Event](events, func(e Event) bool {
+		return e.Type == "login"
+	})
+	err := ForEach[Event](logins, func(e Event) {
+		fmt.Println(e.User)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// alice
+	// bob
+	// <nil>
+}
+
+func ExampleFromRows() {
+	db, err := sql.Open(fakeDriverName, "alice,bob")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT name FROM users")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	names := FromRows(rows, func(rows *sql.Rows) (name string, err error) {
+		err = rows.Scan(&name)
+		return name, err
+	})
+	err = ForEach[string](names, func(name string) {
+		fmt.Println(name)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// alice
+	// bob
+	// <nil>
+}
+
+func ExampleFibonacci() {
+	numbers := TakeWhile[uint64](Fibonacci(), func(v uint64) bool {
+		return v < 100
+	})
+	values, _ := ToSlice[uint64](numbers)
+	fmt.Println(values)
+
+	// Output:
+	// [0 1 1 2 3 5 8 13 21 34 55 89]
+}
+
+func ExamplePrimes() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	primes, _ := ToSlice[int](Take[int](Primes(), 10))
+	fmt.Println(primes)
+
+	// Output:
+	// [2 3 5 7 11 13 17 19 23 29]
+}
+
+func ExampleRandomN() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	dice, _ := ToSlice[int](RandomN(rand.NewSource(1), 1, 7, 20))
+	for _, v := range dice {
+		if v < 1 || v > 6 {
+			fmt.Println("unexpected value", v)
+		}
+	}
+	fmt.Println(len(dice))
+
+	// Output:
+	// 20
+}
+
+func ExampleOnce() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	expanded, _ := Reduce[int](FromSlice([]int{1, 2, 3}), nil, func(acc []int, v int) []int {
+		values := Empty[int]()
+		if v%2 == 1 {
+			values = Once(v * 10)
+		}
+		items, _ := ToSlice[int](values)
+		return append(acc, items...)
+	})
+	fmt.Println(expanded)
+
+	// Output:
+	// [10 30]
+}
+
+func ExampleFromFunc() {
+	a, b := 0, 1
+	fibonacci := FromFunc(func() (int, bool) {
+		v := a
+		a, b = b, a+b
+		return v, v < 50
+	})
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	values, _ := ToSlice[int](fibonacci)
+	fmt.Println(values)
+
+	// Output:
+	// [0 1 1 2 3 5 8 13 21 34]
+}
+
+func ExampleSequenceFrom() {
+	names := FromSlice([]string{"alice", "bob", "carol"})
+	numbers := SequenceFrom(1)
+	err := ForEach[string](names, func(name string) {
+		n, _ := numbers.Next()
+		fmt.Printf("%d. %s\n", n, name)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 1. alice
+	// 2. bob
+	// 3. carol
+	// <nil>
+}
+
+func ExampleLinspace() {
+	err := ForEach[float64](Linspace(0, 1, 5), func(v float64) {
+		fmt.Println(v)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 0
+	// 0.25
+	// 0.5
+	// 0.75
+	// 1
+	// <nil>
+}
+
+func ExampleFloatStepSequence() {
+	err := ForEach[float64](FloatStepSequence(0, 0.5, 0.1), func(v float64) {
+		fmt.Printf("%.1f\n", v)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 0.0
+	// 0.1
+	// 0.2
+	// 0.3
+	// 0.4
+	// 0.5
+	// <nil>
+}
+
+func ExampleTicks() {
+	ticks := Ticks(time.Millisecond)
+	defer ticks.Stop()
+
+	jobs := FromSlice([]string{"first", "second", "third"})
+	err := ForEach[string](jobs, func(job string) {
+		// Each job waits for a tick, so the jobs are processed at most once per millisecond.
+		ticks.Next()
+		fmt.Println(job)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// first
+	// second
+	// third
+	// <nil>
+}
+
+func ExampleWalkDir() {
+	fsys := fstest.MapFS{
+		"README.md":             {},
+		"cmd/main.go":           {},
+		"internal/util.go":      {},
+		"internal/util_test.go": {},
+		"vendor/lib/lib.go":     {},
+	}
+	entries := WalkDir(fsys, ".", WithSkipDir(func(e DirEntryPath) bool {
+		return e.Entry.Name() == "vendor"
+	}))
+	goFiles := Filter[DirEntryPath](entries, func(e DirEntryPath) bool {
+		return strings.HasSuffix(e.Path, ".go") && !strings.HasSuffix(e.Path, "_test.go")
+	})
+	err := ForEach[DirEntryPath](goFiles, func(e DirEntryPath) {
+		fmt.Println(e.Path)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// cmd/main.go
+	// internal/util.go
+	// <nil>
+}
+
+func ExampleFromRegexp() {
+	re := regexp.MustCompile(`ERROR: (\w+)`)
+	log := "INFO: started\nERROR: timeout\nINFO: retry\nERROR: refused\nERROR: timeout\n"
+
+	// Only the first two matches are searched, the remainder of the log is not scanned.
+	first := Take[[]string](FromRegexpSubmatch(re, log), 2)
+	err := ForEach[[]string](first, func(match []string) {
+		fmt.Println(match[1])
+	})
+	fmt.Println(err)
+
+	// Output:
+	// timeout
+	// refused
+	// <nil>
+}
+
+func ExampleSplitString() {
+	csvLine := "id,name,email,created,updated"
+	// Only the first two columns are split off, the remainder of the line is never split.
+	columns, _ := ToSlice[string](Take[string](SplitString(csvLine, ","), 2))
+	fmt.Println(columns)
+
+	// Output:
+	// [id name]
+}
+
+func ExampleFieldsString() {
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	words, _ := ToSlice[string](FieldsString("  the quick\tbrown\n fox  "))
+	fmt.Println(words)
+
+	// Output:
+	// [the quick brown fox]
+}
+
+func ExampleFromList() {
+	l := list.New()
+	l.PushBack("b")
+	l.PushBack("c")
+	l.PushFront("a")
+
+	err := ForEach[string](FromList[string](l), func(s string) {
+		fmt.Println(s)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// a
+	// b
+	// c
+	// <nil>
+}
+
+func ExampleFromRing() {
+	players := ring.New(3)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		players.Value = name
+		players = players.Next()
+	}
+
+	// Two rounds of turns, starting with the second player.
+	turns, err := ToSlice[string](FromRing[string](players.Next(), 2))
+	fmt.Println(turns, err)
+
+	// Output:
+	// [bob carol alice bob carol alice] <nil>
+}
+
+type task struct {
+	name     string
+	priority int
+}
+
+// taskQueue implements heap.Interface and orders tasks by priority.
+type taskQueue []task
+
+func (q taskQueue) Len() int           { return len(q) }
+func (q taskQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q taskQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *taskQueue) Push(x any)        { *q = append(*q, x.(task)) }
+func (q *taskQueue) Pop() any {
+	old := *q
+	v := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return v
+}
+
+func ExampleFromHeap() {
+	queue := &taskQueue{}
+	heap.Init(queue)
+	err := ToHeap[task](FromSlice([]task{{"deploy", 3}, {"page on-call", 1}, {"write report", 5}, {"fix build", 2}}), queue)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = ForEach[task](FromHeap[task](queue), func(t task) {
+		fmt.Println(t.priority, t.name)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 1 page on-call
+	// 2 fix build
+	// 3 deploy
+	// 5 write report
+	// <nil>
+}
+
+func ExampleUnfold() {
+	// The Collatz sequence evolves a number until it reaches 1.
+	collatz := Unfold(6, func(n int) (int, int, bool) {
+		if n == 0 {
+			return 0, 0, false
+		}
+		switch {
+		case n == 1:
+			return n, 0, true
+		case n%2 == 0:
+			return n, n / 2, true
+		default:
+			return n, 3*n + 1, true
+		}
+	})
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	values, _ := ToSlice[int](collatz)
+	fmt.Println(values)
+
+	// Output:
+	// [6 3 10 5 16 8 4 2 1]
+}
+
+func ExampleFromPages() {
+	// The pages of a list endpoint that returns a token for the next page.
+	pages := map[string][]string{
+		"":       {"alice", "bob"},
+		"token1": {"carol"},
+		"token2": {"dave", "erin"},
+	}
+	next := map[string]string{"": "token1", "token1": "token2"}
+
+	users := FromPages("", func(token string) ([]string, string, bool, error) {
+		nextToken, more := next[token]
+		return pages[token], nextToken, !more, nil
+	})
+	err := ForEach[string](users, func(user string) {
+		fmt.Println(user)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// alice
+	// bob
+	// carol
+	// dave
+	// erin
+	// <nil>
+}
+
+func ExampleFromBatches() {
+	// Each poll of a message queue returns a batch of messages.
+	polls := FromSlice([][]string{{"m1", "m2"}, {}, {"m3"}})
+
+	err := ForEach[string](FromBatches[string](polls), func(message string) {
+		fmt.Println(message)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// m1
+	// m2
+	// m3
+	// <nil>
+}
+
+func ExampleReplayable() {
+	// The channel can only be consumed once.
+	c := make(chan int, 3)
+	c <- 10
+	c <- 20
+	c <- 30
+	close(c)
+	readings := Replayable[int](FromChannel(c))
+
+	// The first pass sums the readings, the second pass prints the share of each reading.
+	sum, _ := Sum[int](readings)
+	readings.Restart()
+	err := ForEach[int](readings, func(v int) {
+		fmt.Printf("%d%%\n", v*100/sum)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 16%
+	// 33%
+	// 50%
+	// <nil>
+}
+
+func ExampleToMapMerge() {
+	words := FieldsString("the cat and the hat and the bat")
+	counts, err := ToMapMerge[string](words, func(w string) string {
+		return w
+	}, func(string) int {
+		return 1
+	}, func(old, new int) int {
+		return old + new
+	})
+	fmt.Println(counts, err)
+
+	// Output:
+	// map[and:2 bat:1 cat:1 hat:1 the:3] <nil>
+}
+
+func ExampleToJSONArray() {
+	type Point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	points := Map[int](Sequence(1, 3), func(i int) Point {
+		return Point{X: i, Y: i * i}
+	})
+	err := ToJSONArray[Point](points, os.Stdout)
+	fmt.Println()
+	fmt.Println(err)
+
+	// Output:
+	// [{"x":1,"y":1},{"x":2,"y":4},{"x":3,"y":9}]
+	// <nil>
+}
+
+func ExampleToJSONLines() {
+	err := ToJSONLines[string](FromSlice([]string{"alpha", "beta"}), os.Stdout)
+	fmt.Println(err)
+
+	// Output:
+	// "alpha"
+	// "beta"
+	// <nil>
+}
+
+func ExampleChannel() {
+	words := FromSlice([]string{"alpha", "beta", "gamma"})
+	for word := range Channel[string](words, 1) {
+		fmt.Println(word)
+	}
+	// The channel is closed after the iteration has completed, so the error can be checked safely.
+	fmt.Println(words.Error())
+
+	// Output:
+	// alpha
+	// beta
+	// gamma
+	// <nil>
+}
+
+func ExampleChannelCtx() {
+	ctx, cancel := context.WithCancel(context.Background())
+	// The consumer stops early, cancelling releases the goroutine that feeds the channel.
+	defer cancel()
+	for v := range ChannelCtx[int](ctx, SequenceFrom(1), 0) {
+		if v == 3 {
+			break
+		}
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleAppendSlice() {
+	buffer := make([]int, 0, 16)
+	for batch := 1; batch <= 3; batch++ {
+		// The buffer is reused for each batch, so no new slice is allocated.
+		// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom
+		// iterator that reads data from the database, but the connection is terminated while the iteration was not
+		// completed.
+		buffer, _ = AppendSlice[int](Sequence(batch*10, batch*10+2), buffer[:0])
+		fmt.Println(buffer)
+	}
+
+	// Output:
+	// [10 11 12]
+	// [20 21 22]
+	// [30 31 32]
+}
+
+func ExampleToSortedSlice() {
+	names, err := ToSortedSlice[string](FromSlice([]string{"carol", "alice", "bob"}))
+	fmt.Println(names, err)
+
+	// Output:
+	// [alice bob carol] <nil>
+}
+
+func ExampleToSortedSliceFunc() {
+	words, err := ToSortedSliceFunc[string](FieldsString("pear fig banana kiwi"), func(a, b string) bool {
+		return len(a) < len(b)
+	})
+	fmt.Println(words, err)
+
+	// Output:
+	// [fig pear kiwi banana] <nil>
+}
+
+// batchInserter is a Sink that inserts values in batches.
+type batchInserter struct {
+	size  int
+	batch []string
+}
+
+func (b *batchInserter) Accept(v string) error {
+	b.batch = append(b.batch, v)
+	if len(b.batch) == b.size {
+		return b.Flush()
+	}
+	return nil
+}
+
+func (b *batchInserter) Flush() error {
+	if len(b.batch) > 0 {
+		fmt.Printf("INSERT %d rows: %v\n", len(b.batch), b.batch)
+		b.batch = b.batch[:0]
+	}
+	return nil
+}
+
+func ExampleDrain() {
+	users := FromSlice([]string{"alice", "bob", "carol", "dave", "erin"})
+	err := Drain[string](users, &batchInserter{size: 2})
+	fmt.Println(err)
+
+	// Output:
+	// INSERT 2 rows: [alice bob]
+	// INSERT 2 rows: [carol dave]
+	// INSERT 1 rows: [erin]
+	// <nil>
+}
+
+func ExampleMapErr() {
+	input := FieldsString("12 7 x 30")
+	numbers := MapErr[string](input, strconv.Atoi)
+	err := ForEach[int](numbers, func(v int) {
+		fmt.Println(v)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 12
+	// 7
+	// iterator: map stage, element 2: strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExampleFilterErr() {
+	paths := FromSlice([]string{"/etc/hosts", "/tmp", "relative/path", "/var/log"})
+	absolute := FilterErr[string](paths, func(p string) (bool, error) {
+		if !strings.HasPrefix(p, "/") {
+			return false, fmt.Errorf("path %q is not absolute", p)
+		}
+		return strings.HasPrefix(p, "/etc") || strings.HasPrefix(p, "/var"), nil
+	})
+	err := ForEach[string](absolute, func(p string) {
+		fmt.Println(p)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// /etc/hosts
+	// iterator: filter stage, element 2: path "relative/path" is not absolute
+}
+
+func ExampleForEachErr() {
+	errQuotaExceeded := errors.New("quota exceeded")
+	quota := 2
+	uploads := FromSlice([]string{"a.png", "b.png", "c.png"})
+	err := ForEachErr[string](uploads, func(name string) error {
+		if quota == 0 {
+			return errQuotaExceeded
+		}
+		quota--
+		fmt.Println("uploaded", name)
+		return nil
+	})
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, errQuotaExceeded))
+
+	// Output:
+	// uploaded a.png
+	// uploaded b.png
+	// iterator: value at index 2: quota exceeded
+	// true
+}
+
+func ExampleTryForEach() {
+	lines := FromSlice([]string{"header", "data 1", "data 2", "END", "trailer"})
+	err := TryForEach[string](lines, func(i int, line string) error {
+		if line == "END" {
+			return ErrStop
+		}
+		fmt.Println(i, line)
+		return nil
+	})
+	fmt.Println(err)
+
+	// Output:
+	// 0 header
+	// 1 data 1
+	// 2 data 2
+	// <nil>
+}
+
+func ExampleStageError() {
+	records := FromSlice([]string{"1", "2", "three", "4"})
+	numbers := MapErr[string](records, strconv.Atoi)
+	positive := Filter[int](numbers, func(v int) bool {
+		return v > 0
+	})
+	_, err := ToSlice[int](positive)
+
+	var se *StageError
+	for errors.As(err, &se) {
+		fmt.Printf("%s stage, element %d\n", se.Stage, se.Index)
+		err = se.Err
+	}
+	fmt.Println(err)
+
+	// Output:
+	// filter stage, element 2
+	// map stage, element 2
+	// strconv.Atoi: parsing "three": invalid syntax
+}
+
+func ExampleOnError() {
+	lines := FromSlice([]string{"1", "two", "3", "", "5"})
+	numbers := MapErr[string](lines, strconv.Atoi)
+	tolerant := OnError[int](numbers, func(err error) bool {
+		fmt.Println("skipped:", err)
+		return true
+	})
+	sum, _ := Sum[int](tolerant)
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	fmt.Println(sum)
+
+	// Output:
+	// skipped: iterator: map stage, element 1: strconv.Atoi: parsing "two": invalid syntax
+	// skipped: iterator: map stage, element 3: strconv.Atoi: parsing "": invalid syntax
+	// 9
+}
+
+func ExampleSafe() {
+	divided := Map[int](FromSlice([]int{4, 2, 0, 1}), func(v int) int {
+		return 8 / v
+	})
+	safe := Safe[int](divided)
+	result, err := ToSlice[int](safe)
+	fmt.Println(result)
+	fmt.Println(err)
+
+	var pe *PanicError
+	fmt.Println(errors.As(err, &pe) && len(pe.Stack) > 0)
+
+	// Output:
+	// [2 4]
+	// iterator: panic: runtime error: integer divide by zero
+	// true
+}
+
+func ExampleFromYAMLDocs() {
+	type Manifest struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+	}
+	stream := `kind: Service
+metadata:
+  name: web
+---
+kind: Deployment
+metadata:
+  name: web
+---
+kind: Deployment
+metadata:
+  name: worker
+`
+
+	manifests := FromYAMLDocs[Manifest](strings.NewReader(stream))
+	deployments := Filter[Manifest](manifests, func(m Manifest) bool {
+		return m.Kind == "Deployment"
+	})
+	err := ForEach[Manifest](deployments, func(m Manifest) {
+		fmt.Println(m.Metadata.Name)
+	})
+	fmt.Println(err)
+
+	// Output:
+	// web
+	// worker
+	// <nil>
+}
+
 // Tests
 
-type testFixture struct {
-	slice                   []int
-	resultingIntIterator    Iterable[int]
-	resultingStringIterator Iterable[string]
-	predicate               PredicateFunc[int]
-	mapper                  MapFunc[int, string]
-	resultingSlice          []int
-	reducer                 ReduceFunc[int, int]
-	initialReduceValue      int
-	counter                 ForEachFunc[int]
-	count                   int
-	sum                     int
-	generator               GeneratorFunc[string]
-	repeat                  uint64
-	start                   int
-	end                     int
-	step                    int
-	channel                 chan int
+type testFixture struct {
+	slice                        []int
+	resultingIntIterator         Iterable[int]
+	resultingStringIterator      Iterable[string]
+	predicate                    PredicateFunc[int]
+	mapper                       MapFunc[int, string]
+	resultingSlice               []int
+	reducer                      ReduceFunc[int, int]
+	initialReduceValue           int
+	counter                      ForEachFunc[int]
+	count                        int
+	sum                          int
+	generator                    GeneratorFunc[string]
+	repeat                       uint64
+	start                        int
+	end                          int
+	step                         int
+	channel                      chan int
+	resultingGroupIterator       Iterable[[]int]
+	err                          error
+	secondIntIterator            Iterable[int]
+	resultingChangeIterator      Iterable[Change[int]]
+	resultingTaggedIterator      Iterable[Tagged[int]]
+	resultingPartitionedIterator Iterable[Partitioned[int]]
+	channels                     []chan int
+	resultingRankedIterator      Iterable[Ranked[int]]
+	resultingFloatIterator       Iterable[float64]
+	ctx                          context.Context
+	cancel                       context.CancelFunc
+	table                        []int
+	fetches                      int32
+	fetch                        KeysetPageFunc[int, int]
+	started                      time.Time
+	resultingCountedIterator     Iterable[Counted[int]]
+	stats                        *concurrencyStats
+	registry                     *Registry[int]
+	set                          Set[int]
+	found                        bool
+	average                      float64
+	stream                       string
+	body                         *trackingBody
+	response                     *http.Response
+	bodyIterator                 interface{ Close() error }
+	statistics                   Statistics
+	answer                       bool
+	processed                    int
+	index                        int
+	valueChannel                 <-chan int
+	errorChannel                 <-chan error
+	template                     Template
+	output                       bytes.Buffer
+	intMap                       map[int]int
+	str                          string
+	comparison                   int
+	collected                    []int
+	collector                    Collector[int]
+	maximum                      int
+	quantiles                    []float64
+	histogram                    HistogramBuckets[int]
+	resultingPairIterator        Iterable[Pair[int, int]]
+	reader                       io.Reader
+	scanner                      *bufio.Scanner
+	resultingRecordIterator      Iterable[[]string]
+	db                           *sql.DB
+	rowsIterator                 *RowsIterator[int]
+	rowsClosed                   bool
+	sourceIterator               Iterable[int]
+	randomSettings               [3]int
+	calls                        int
+	tickIterator                 *TickIterator
+	ticks                        Iterable[time.Time]
+	fsys                         fs.FS
+	walkIterator                 *WalkDirIterator
+	walkedPaths                  []string
+	regexp                       *regexp.Regexp
+	text                         string
+	submatches                   Iterable[[]string]
+	separator                    string
+	list                         *list.List
+	ring                         *ring.Ring
+	heap                         *boundedHeap[int]
+	pages                        [][]int
+	failingPage                  int
+	cursors                      []string
+	batches                      Iterable[[]int]
+	replayIterator               *ReplayIterator[int]
+	pulled                       int
+	receiveChannel               <-chan int
+	buffer                       []int
+	sink                         *batchingSink
+	received                     []string
+	release                      chan struct{}
+}
+
+var t testFixture
+
+func toSliceOfInts(table *godog.Table) (result []int, err error) {
+	var value int
+	for _, row := range table.Rows {
+		value, err = strconv.Atoi(row.Cells[0].Value)
+		if err != nil {
+			return
+		}
+		result = append(result, value)
+	}
+	return
+}
+
+func toSliceOfStrings(table *godog.Table) (result []string) {
+	var value string
+	for _, row := range table.Rows {
+		value = row.Cells[0].Value
+		result = append(result, value)
+	}
+	return
+}
+
+func nextReturnsTrueTimesAndThenReturnsFalse(num int) error {
+	for ; num > 0; num-- {
+
+		if _, r := t.resultingIntIterator.Next(); r != true {
+			return errors.New("expected: true got: false")
+		}
+	}
+	if _, r := t.resultingIntIterator.Next(); r != false {
+		return errors.New("expected: false got: true")
+	}
+	return nil
+}
+
+func aSliceIteratorIsReturnedWithIdxContaining(arg1 int) error {
+	si := t.resultingIntIterator.(*SliceIterator[int])
+	if arg1 != si.idx {
+		return fmt.Errorf("expected: %v got: %v", arg1, si.idx)
+	}
+	return nil
+}
+
+func aSliceIteratorIsReturnedWithReverseContainingFalse() error {
+	si := t.resultingIntIterator.(*SliceIterator[int])
+	if false != si.reverse {
+		return fmt.Errorf("expected: %v got: %v", false, si.reverse)
+	}
+	return nil
+}
+
+func aSliceIteratorIsReturnedWithReverseContainingTrue() error {
+	si := t.resultingIntIterator.(*SliceIterator[int])
+	if true != si.reverse {
+		return fmt.Errorf("expected: %v got: %v", true, si.reverse)
+	}
+	return nil
+}
+
+func aSliceIteratorIsReturnedWithValuesContaining(listofints *godog.Table) error {
+	si := t.resultingIntIterator.(*SliceIterator[int])
+	s, err := toSliceOfInts(listofints)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(s, si.values) {
+		return fmt.Errorf("expected: %v got: %v", s, si.values)
+	}
+	return nil
+}
+
+func aSliceWithTheFollowingValues(listofints *godog.Table) (err error) {
+	t.slice, err = toSliceOfInts(listofints)
+	return
+}
+
+func fromSliceIsCalled() {
+	t.resultingIntIterator = FromSlice(t.slice)
+}
+
+func fromReverseSliceIsCalled() {
+	t.resultingIntIterator = FromReverseSlice(t.slice)
+}
+
+func aPredicateThatOnlySelectsOddNumbers() {
+	t.predicate = func(a int) bool {
+		result := (a % 2) != 0
+		return result
+	}
+}
+
+func anIterableWithTheFollowingValues(listofints *godog.Table) error {
+	s, err := toSliceOfInts(listofints)
+	if err != nil {
+		return err
+	}
+	t.resultingIntIterator = FromSlice(s)
+	return nil
+}
+
+func filterIsCalled() {
+	t.resultingIntIterator = Filter(t.resultingIntIterator, t.predicate)
+}
+
+func aMapFunctionThatMultiplesTheValuesAndConvertsTheIntToAStringPrefixedWithTest() {
+	t.mapper = func(i int) string {
+		return "test" + strconv.Itoa(i*2)
+	}
+}
+
+func mapIsCalled() {
+	t.resultingStringIterator = Map(t.resultingIntIterator, t.mapper)
+}
+
+func aSliceIsReturnedWithTheFollowingValues(listofints *godog.Table) error {
+	s, err := toSliceOfInts(listofints)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(s, t.resultingSlice) {
+		return fmt.Errorf("expected: %v got: %v", s, t.resultingSlice)
+	}
+	return nil
+}
+
+func toSliceIsCalled() (err error) {
+	t.resultingSlice, err = ToSlice(t.resultingIntIterator)
+	return
+}
+
+func aReduceFunctionThatSumsAllValues() {
+	t.reducer = func(a, b int) int {
+		return a + b
+	}
+}
+
+func initialValueOf(init int) {
+	t.initialReduceValue = init
+}
+
+func reduceIsCalled() (err error) {
+	t.sum, err = Reduce(t.resultingIntIterator, t.initialReduceValue, t.reducer)
+	return
+}
+
+func theReturnedSumIs(expected int) error {
+	if t.sum != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, t.sum)
+	}
+	return nil
+}
+
+func aForeachFunctionThatSumsAndCountsTheCalls() {
+	t.counter = func(i int) {
+		t.count++
+		t.sum += i
+	}
+}
+
+func foreachIsCalled() error {
+	return ForEach(t.resultingIntIterator, t.counter)
+}
+
+func theReturnedCountIs(expected int) error {
+	if t.count != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, t.sum)
+	}
+	return nil
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingStrings(listofints *godog.Table) error {
+	expected := toSliceOfStrings(listofints)
+
+	var results []string
+
+	for v, b := t.resultingStringIterator.Next(); b; v, b = t.resultingStringIterator.Next() {
+		results = append(results, v)
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingIntegers(listofints *godog.Table) error {
+	expected, err := toSliceOfInts(listofints)
+
+	if err != nil {
+		return err
+	}
+
+	var results []int
+
+	for v, b := t.resultingIntIterator.Next(); b; v, b = t.resultingIntIterator.Next() {
+		results = append(results, v)
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func aGeneratorFuncThatReturnsTheCountAndRepeatConcatenatedWithAComma() {
+	t.generator = func(p string, c, r uint64) string {
+		return fmt.Sprintf("%d,%d", c, r)
+	}
+}
+
+func aRepeatValueOf(r int) {
+	t.repeat = uint64(r)
+}
+
+func generateIsCalled() {
+	t.resultingStringIterator = Generate("", t.repeat, t.generator)
+}
+
+func aStartValueOf(s int) {
+	t.start = s
+}
+
+func anEndValueOf(e int) {
+	t.end = e
+}
+
+func anStepValueOf(s int) {
+	t.step = s
+}
+
+func stepSequenceIsCalled() {
+	t.resultingIntIterator = StepSequence(t.start, t.end, t.step)
+}
+
+func sequenceIsCalled() {
+	t.resultingIntIterator = Sequence(t.start, t.end)
+}
+
+func valuesStringToIntSlice(in string) (result []int, err error) {
+	for _, s := range strings.Split(in, ",") {
+		i, err2 := strconv.Atoi(s)
+		if err2 != nil {
+			err = err2
+			return
+		}
+		result = append(result, i)
+	}
+	return
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingValues(values string) error {
+	expected, err := valuesStringToIntSlice(values)
+	if err != nil {
+		return err
+	}
+	results, err := ToSlice(t.resultingIntIterator)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+	return nil
+}
+
+type ErrorIterator[T any] struct{}
+
+func (e *ErrorIterator[T]) Next() (T, bool) {
+	var t T
+	return t, false
+}
+
+func (e *ErrorIterator[T]) Error() error {
+	return errors.New("iterator not implemented")
+}
+
+func anIterableInAnErrorState() {
+	t.resultingIntIterator = &ErrorIterator[int]{}
+}
+
+func errorOfStringIteratorReturnsAnError() error {
+	if t.resultingStringIterator.Error() == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func errorOfStringIteratorReturnsNil() error {
+	if t.resultingStringIterator.Error() != nil {
+		return errors.New("expected nil but got an error")
+	}
+	return nil
+}
+
+func errorOfIntIteratorReturnsAnError() error {
+	if t.resultingIntIterator.Error() == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func errorOfIntIteratorReturnsNil() error {
+	if t.resultingIntIterator.Error() != nil {
+		return errors.New("expected nil but got an error")
+	}
+	return nil
+}
+
+func aClosedChannelWithTheFollowingValues(listofints *godog.Table) {
+	t.channel = make(chan int)
+	go func() {
+		values, err := toSliceOfInts(listofints)
+		if err != nil {
+			panic(err)
+		}
+		for _, v := range values {
+			t.channel <- v
+		}
+		close(t.channel)
+	}()
+}
+
+func fromChannelIsCalled() {
+	t.resultingIntIterator = FromChannel(t.channel)
+}
+
+func theChannelIsClosed() {
+	close(t.channel)
+}
+
+func theFollowingValuesAreReceivedOnTheChannel(listofints *godog.Table) error {
+	var results []int
+	expected, err := toSliceOfInts(listofints)
+	if err != nil {
+		return err
+	}
+	for v := range t.channel {
+		results = append(results, v)
+	}
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+	return nil
+}
+
+func toChannelIsCalled() {
+	go func() {
+		defer close(t.channel)
+		err := ToChannel(t.resultingIntIterator, t.channel)
+		if err != nil {
+			panic(err)
+		}
+	}()
+}
+
+func aPredicateThatOnlySelectsZero() {
+	t.predicate = func(a int) bool {
+		return a == 0
+	}
+}
+
+func splitIsCalled() {
+	t.resultingGroupIterator = Split(t.resultingIntIterator, t.predicate)
+}
+
+func toSliceOfGroups(table *godog.Table) (result [][]int, err error) {
+	for _, row := range table.Rows {
+		group := []int{}
+		if row.Cells[0].Value != "" {
+			group, err = valuesStringToIntSlice(row.Cells[0].Value)
+			if err != nil {
+				return
+			}
+		}
+		result = append(result, group)
+	}
+	return
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingGroups(listofgroups *godog.Table) error {
+	expected, err := toSliceOfGroups(listofgroups)
+	if err != nil {
+		return err
+	}
+
+	var results [][]int
+
+	for v, b := t.resultingGroupIterator.Next(); b; v, b = t.resultingGroupIterator.Next() {
+		results = append(results, v)
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func nextOfGroupIteratorReturnsFalse() error {
+	if _, b := t.resultingGroupIterator.Next(); b {
+		return errors.New("expected: false got: true")
+	}
+	return nil
+}
+
+func errorOfGroupIteratorReturnsAnError() error {
+	if t.resultingGroupIterator.Error() == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func errorOfGroupIteratorReturnsNil() error {
+	if t.resultingGroupIterator.Error() != nil {
+		return errors.New("expected nil but got an error")
+	}
+	return nil
+}
+
+func aChannel() {
+	t.channel = make(chan int)
+}
+
+func toSliceMaxIsCalledWithAMaximumOf(max int) {
+	t.resultingSlice, t.err = ToSliceMax(t.resultingIntIterator, max)
+}
+
+func noErrorIsReturned() error {
+	if t.err != nil {
+		return fmt.Errorf("expected nil but got: %v", t.err)
+	}
+	return nil
+}
+
+func anErrorIsReturned() error {
+	if t.err == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func errTooManyIsReturned() error {
+	if !errors.Is(t.err, ErrTooMany) {
+		return fmt.Errorf("expected: %v got: %v", ErrTooMany, t.err)
+	}
+	return nil
+}
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func topKIsCalledWithKOf(k int) {
+	t.resultingSlice, t.err = TopK(t.resultingIntIterator, k, intLess)
+}
+
+func bottomKIsCalledWithKOf(k int) {
+	t.resultingSlice, t.err = BottomK(t.resultingIntIterator, k, intLess)
+}
+
+func aSecondIterableWithTheFollowingValues(listofints *godog.Table) error {
+	s, err := toSliceOfInts(listofints)
+	if err != nil {
+		return err
+	}
+	t.secondIntIterator = FromSlice(s)
+	return nil
+}
+
+func aSecondIterableInAnErrorState() {
+	t.secondIntIterator = &ErrorIterator[int]{}
+}
+
+func diffSortedIsCalled() {
+	t.resultingChangeIterator = DiffSorted(t.resultingIntIterator, t.secondIntIterator, func(a, b int) int {
+		return a - b
+	})
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingChanges(listofchanges *godog.Table) error {
+	var expected []string
+	for _, row := range listofchanges.Rows {
+		expected = append(expected, row.Cells[0].Value+" "+row.Cells[1].Value)
+	}
+
+	var results []string
+
+	for c, b := t.resultingChangeIterator.Next(); b; c, b = t.resultingChangeIterator.Next() {
+		v := c.New
+		if c.Kind == Removed {
+			v = c.Old
+		}
+		results = append(results, c.Kind.String()+" "+strconv.Itoa(v))
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func nextReturnsNoChangeAndErrorOfChangeIteratorReturnsAnError() error {
+	if _, b := t.resultingChangeIterator.Next(); b {
+		return errors.New("expected: false got: true")
+	}
+	if t.resultingChangeIterator.Error() == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func tagIsCalledWithTheIndexAsMetadata() {
+	t.resultingTaggedIterator = Tag(t.resultingIntIterator, func(i int, v int) map[string]any {
+		return map[string]any{"index": i}
+	})
+}
+
+func mapTaggedIsCalledWithAMapFunctionThatDoublesTheValues() {
+	t.resultingTaggedIterator = MapTagged(t.resultingTaggedIterator, func(v int) int {
+		return v * 2
+	})
+}
+
+func untagIsCalled() {
+	t.resultingIntIterator = Untag(t.resultingTaggedIterator)
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingTaggedValues(listoftagged *godog.Table) error {
+	var expected []string
+	for _, row := range listoftagged.Rows {
+		expected = append(expected, row.Cells[0].Value+"@"+row.Cells[1].Value)
+	}
+
+	var results []string
+
+	for v, b := t.resultingTaggedIterator.Next(); b; v, b = t.resultingTaggedIterator.Next() {
+		results = append(results, fmt.Sprintf("%d@%v", v.Value, v.Meta["index"]))
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func sampleReservoirIsCalledWithKOf(k int) {
+	t.resultingSlice, t.err = SampleReservoir(t.resultingIntIterator, k, rand.NewSource(1))
+}
+
+func theReturnedSliceContainsDistinctValuesBetweenAnd(count, min, max int) error {
+	if len(t.resultingSlice) != count {
+		return fmt.Errorf("expected: %v values got: %v", count, t.resultingSlice)
+	}
+	seen := map[int]bool{}
+	for _, v := range t.resultingSlice {
+		if v < min || v > max || seen[v] {
+			return fmt.Errorf("unexpected value %v in %v", v, t.resultingSlice)
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+func partitionConsistentIsCalledWithPartitions(partitions int) {
+	t.resultingPartitionedIterator = PartitionConsistent(t.resultingIntIterator, strconv.Itoa, partitions)
+}
+
+func everyValueIsAssignedToTheSamePartitionAsEqualValuesWithinPartitions(partitions int) error {
+	assigned := map[int]int{}
+	for v, b := t.resultingPartitionedIterator.Next(); b; v, b = t.resultingPartitionedIterator.Next() {
+		if v.Partition < 0 || v.Partition >= partitions {
+			return fmt.Errorf("partition %v of %v out of range", v.Partition, v.Value)
+		}
+		if p, ok := assigned[v.Value]; ok && p != v.Partition {
+			return fmt.Errorf("expected: %v got: %v for %v", p, v.Partition, v.Value)
+		}
+		assigned[v.Value] = v.Partition
+	}
+	return t.resultingPartitionedIterator.Error()
+}
+
+func errorOfPartitionedIteratorReturnsAnError() error {
+	if t.resultingPartitionedIterator.Error() == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func fanOutConsistentIsCalledWithChannels(n int) error {
+	t.channels = make([]chan int, n)
+	channels := make([]chan<- int, n)
+	for i := range t.channels {
+		t.channels[i] = make(chan int, 100)
+		channels[i] = t.channels[i]
+	}
+	return FanOutConsistent(t.resultingIntIterator, strconv.Itoa, channels)
+}
+
+func fanOutConsistentIsCalledWithoutChannels() {
+	t.err = FanOutConsistent(t.resultingIntIterator, strconv.Itoa, nil)
+}
+
+func errNoChannelsIsReturned() error {
+	if !errors.Is(t.err, ErrNoChannels) {
+		return fmt.Errorf("expected: %v got: %v", ErrNoChannels, t.err)
+	}
+	return nil
+}
+
+func valuesAreReceivedOnTheChannelsAndEqualValuesAreReceivedOnTheSameChannel(count int) error {
+	received := 0
+	assigned := map[int]int{}
+	for i, c := range t.channels {
+		close(c)
+		for v := range c {
+			received++
+			if p, ok := assigned[v]; ok && p != i {
+				return fmt.Errorf("expected: %v got: %v for %v", p, i, v)
+			}
+			assigned[v] = i
+		}
+	}
+	if received != count {
+		return fmt.Errorf("expected: %v got: %v", count, received)
+	}
+	return nil
+}
+
+func sampleIsCalledWithAProbabilityOf(p float64) {
+	t.resultingIntIterator = Sample(t.resultingIntIterator, p, rand.NewSource(1))
+}
+
+func movingAggregateIsCalledWithASumOverAWindowOf(window int) {
+	t.resultingIntIterator = MovingAggregate(t.resultingIntIterator, window, 0, func(a, v int) int {
+		return a + v
+	}, func(a, v int) int {
+		return a - v
+	})
+}
+
+func takeIsCalledWith(n int) {
+	t.resultingIntIterator = Take(t.resultingIntIterator, n)
+}
+
+func iDsIsCalledWithAMonotonicGeneratorWithPrefix(prefix string) {
+	t.resultingStringIterator = IDs(NewMonotonicIDGenerator(prefix))
+}
+
+func iDsIsCalledWithAULIDGenerator() {
+	t.resultingStringIterator = IDsErr(NewULIDGenerator(rand.NewSource(1)))
+}
+
+// maxSource is a rand.Source that always returns the largest value, so the random bits of a ULID are all set.
+type maxSource struct{}
+
+func (maxSource) Int63() int64 {
+	return math.MaxInt64
+}
+
+func (maxSource) Seed(int64) {}
+
+func iDsIsCalledWithAULIDGeneratorWhoseRandomBitsAreAllSet() {
+	t.resultingStringIterator = IDsErr(NewULIDGenerator(maxSource{}))
+}
+
+func theIdentifiersStopWithErrULIDOverflow() error {
+	previous := ""
+	for v, b := t.resultingStringIterator.Next(); b; v, b = t.resultingStringIterator.Next() {
+		if v <= previous {
+			return fmt.Errorf("expected %v to sort after %v", v, previous)
+		}
+		previous = v
+	}
+	if previous == "" {
+		return errors.New("expected at least one identifier")
+	}
+	if err := t.resultingStringIterator.Error(); !errors.Is(err, ErrULIDOverflow) {
+		return fmt.Errorf("expected: %v got: %v", ErrULIDOverflow, err)
+	}
+	return nil
+}
+
+func takeIsCalledOnTheIdentifiersWith(n int) {
+	t.resultingStringIterator = Take(t.resultingStringIterator, n)
+}
+
+func theIdentifiersAreUniqueSortedAndCharactersLong(length int) error {
+	previous := ""
+	for v, b := t.resultingStringIterator.Next(); b; v, b = t.resultingStringIterator.Next() {
+		if len(v) != length {
+			return fmt.Errorf("expected length: %v got: %v", length, v)
+		}
+		if v <= previous {
+			return fmt.Errorf("expected %v to sort after %v", v, previous)
+		}
+		previous = v
+	}
+	return nil
+}
+
+func deltaIsCalled() {
+	t.resultingIntIterator = Delta(t.resultingIntIterator)
+}
+
+func rankIsCalled() {
+	t.resultingRankedIterator = Rank(t.resultingIntIterator, intLess)
+}
+
+func denseRankIsCalled() {
+	t.resultingRankedIterator = DenseRank(t.resultingIntIterator, intLess)
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingRankedValues(listofranked *godog.Table) error {
+	var expected []string
+	for _, row := range listofranked.Rows {
+		expected = append(expected, row.Cells[0].Value+":"+row.Cells[1].Value)
+	}
+
+	var results []string
+
+	for v, b := t.resultingRankedIterator.Next(); b; v, b = t.resultingRankedIterator.Next() {
+		results = append(results, fmt.Sprintf("%d:%d", v.Rank, v.Value))
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func nextReturnsNoRankedValueAndErrorOfRankedIteratorReturnsAnError() error {
+	if _, b := t.resultingRankedIterator.Next(); b {
+		return errors.New("expected: false got: true")
+	}
+	if t.resultingRankedIterator.Error() == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func normalizeMinMaxIsCalled() {
+	var ni *MapIterator[int, float64]
+	ni, t.err = NormalizeMinMax(t.resultingIntIterator)
+	if t.err == nil {
+		t.resultingFloatIterator = ni
+	}
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingFloats(listoffloats *godog.Table) error {
+	var expected []float64
+	for _, row := range listoffloats.Rows {
+		v, err := strconv.ParseFloat(row.Cells[0].Value, 64)
+		if err != nil {
+			return err
+		}
+		expected = append(expected, v)
+	}
+
+	results, err := ToSlice(t.resultingFloatIterator)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func anActiveContext() {
+	t.ctx = context.Background()
+}
+
+func aCancelledContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	t.ctx = ctx
+}
+
+func aContextThatIsCancelledAfterMilliseconds(ms int) {
+	t.ctx, t.cancel = context.WithTimeout(context.Background(), time.Duration(ms)*time.Millisecond)
+}
+
+func withContextIsCalled() {
+	t.resultingIntIterator = WithContext(t.ctx, t.resultingIntIterator)
+}
+
+func forEachCtxIsCalled() {
+	t.err = ForEachCtx(t.ctx, t.resultingIntIterator, t.counter)
+}
+
+func padToIsCalledWithLengthAndFillValue(length, fill int) {
+	t.resultingIntIterator = PadTo(t.resultingIntIterator, length, fill)
+}
+
+func padOrTruncateIsCalledWithLengthAndFillValue(length, fill int) {
+	t.resultingIntIterator = PadOrTruncate(t.resultingIntIterator, length, fill)
+}
+
+func aTableWithTheFollowingValues(listofints *godog.Table) (err error) {
+	t.table, err = toSliceOfInts(listofints)
+	t.fetch = func(after int, limit int) ([]int, int, bool, error) {
+		atomic.AddInt32(&t.fetches, 1)
+		end := after + limit
+		if end > len(t.table) {
+			end = len(t.table)
+		}
+		return t.table[after:end], end, end < len(t.table), nil
+	}
+	return
+}
+
+func aTableThatFailsToFetchAPage() {
+	t.fetch = func(after int, limit int) ([]int, int, bool, error) {
+		return nil, after, true, errors.New("connection reset")
+	}
+}
+
+func fromKeysetPagesIsCalledWithALimitOf(limit int) {
+	t.resultingIntIterator = FromKeysetPages(t.fetch, limit)
+}
+
+func fromKeysetPagesPrefetchIsCalledWithALimitOf(limit int) {
+	t.resultingIntIterator = FromKeysetPagesPrefetch(t.fetch, limit)
+}
+
+func pagesHaveBeenFetched(expected int) error {
+	if fetches := atomic.LoadInt32(&t.fetches); int(fetches) != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, fetches)
+	}
+	return nil
+}
+
+func throttleIsCalledWithAnIntervalOfMilliseconds(ms int) {
+	t.started = time.Now()
+	t.resultingIntIterator = Throttle(t.resultingIntIterator, time.Duration(ms)*time.Millisecond)
+}
+
+func theIterationTookAtLeastMilliseconds(ms int) error {
+	if elapsed := time.Since(t.started); elapsed < time.Duration(ms)*time.Millisecond {
+		return fmt.Errorf("expected at least: %vms got: %v", ms, elapsed)
+	}
+	return nil
+}
+
+func theIterationTookLessThanMilliseconds(ms int) error {
+	if elapsed := time.Since(t.started); elapsed >= time.Duration(ms)*time.Millisecond {
+		return fmt.Errorf("expected less than: %vms got: %v", ms, elapsed)
+	}
+	return nil
+}
+
+func aChannelThatReceivesTheFollowingBurstsWithAPauseOfMillisecondsBetweenThem(ms int, listofbursts *godog.Table) error {
+	bursts, err := toSliceOfGroups(listofbursts)
+	if err != nil {
+		return err
+	}
+	c := make(chan int)
+	t.channel = c
+	go func() {
+		defer close(c)
+		for i, burst := range bursts {
+			if i > 0 {
+				time.Sleep(time.Duration(ms) * time.Millisecond)
+			}
+			for _, v := range burst {
+				c <- v
+			}
+		}
+	}()
+	return nil
+}
+
+func debounceIsCalledWithAQuietPeriodOfMilliseconds(ms int) {
+	t.resultingIntIterator = Debounce(t.resultingIntIterator, time.Duration(ms)*time.Millisecond)
+}
+
+func withRemainingIsCalled() {
+	t.resultingCountedIterator = WithRemaining(t.resultingIntIterator)
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingCountedValues(listofcounted *godog.Table) error {
+	var expected []string
+	for _, row := range listofcounted.Rows {
+		var cells []string
+		for _, c := range row.Cells {
+			cells = append(cells, c.Value)
+		}
+		expected = append(expected, strings.Join(cells, " "))
+	}
+
+	var results []string
+
+	for v, b := t.resultingCountedIterator.Next(); b; v, b = t.resultingCountedIterator.Next() {
+		if v.Known {
+			results = append(results, fmt.Sprintf("%d %d %d %d", v.Value, v.Index, v.Remaining, v.Total))
+		} else {
+			results = append(results, fmt.Sprintf("%d %d ? ?", v.Value, v.Index))
+		}
+	}
+
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+
+	return nil
+}
+
+func distinctWithLimitIsCalledWithALimitOf(limit int) {
+	t.resultingIntIterator = DistinctWithLimit(t.resultingIntIterator, limit)
+}
+
+func valuesHaveBeenEvicted(expected int) error {
+	if evicted := t.resultingIntIterator.(*DistinctIterator[int]).Evicted(); evicted != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, evicted)
+	}
+	return nil
+}
+
+// concurrencyStats records the maximum amount of operations that ran concurrently.
+type concurrencyStats struct {
+	mu        sync.Mutex
+	running   int
+	inflight  map[int]int
+	maxTotal  int
+	maxPerKey int
+}
+
+func (c *concurrencyStats) begin(key int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running++
+	c.inflight[key]++
+	if c.inflight[key] > c.maxPerKey {
+		c.maxPerKey = c.inflight[key]
+	}
+	if c.running > c.maxTotal {
+		c.maxTotal = c.running
+	}
+}
+
+func (c *concurrencyStats) end(key int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running--
+	c.inflight[key]--
+}
+
+func parallelMapKeyedIsCalledKeyedByOddAndEvenWithPerKeyAndInTotal(perKey, total int) {
+	stats := &concurrencyStats{inflight: map[int]int{}}
+	t.stats = stats
+	t.resultingIntIterator = ParallelMapKeyed(t.resultingIntIterator, func(v int) int {
+		return v % 2
+	}, perKey, total, func(v int) (int, error) {
+		if v < 0 {
+			return 0, errors.New("negative value")
+		}
+		stats.begin(v % 2)
+		defer stats.end(v % 2)
+		time.Sleep(5 * time.Millisecond)
+		return v * 2, nil
+	})
+}
+
+func theFollowingIntegersAreReturnedInAnyOrder(listofints *godog.Table) error {
+	expected, err := toSliceOfInts(listofints)
+	if err != nil {
+		return err
+	}
+	results, err := ToSlice(t.resultingIntIterator)
+	if err != nil {
+		return err
+	}
+	sort.Ints(expected)
+	sort.Ints(results)
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+	return nil
+}
+
+func atMostOperationsPerKeyAndOperationsInTotalRanConcurrently(perKey, total int) error {
+	t.stats.mu.Lock()
+	defer t.stats.mu.Unlock()
+	if t.stats.maxPerKey > perKey || t.stats.maxTotal > total {
+		return fmt.Errorf("expected at most: %v per key and %v in total got: %v per key and %v in total", perKey, total, t.stats.maxPerKey, t.stats.maxTotal)
+	}
+	return nil
+}
+
+func roundRobinIsCalled() {
+	t.resultingIntIterator = RoundRobin(t.resultingIntIterator, t.secondIntIterator)
+}
+
+type sequenceParams struct {
+	start, end int
+}
+
+func aRegistryWithASequenceSourceAFilterStageAndASumSink() error {
+	t.registry = NewRegistry[int]()
+	if err := RegisterSource(t.registry, "sequence", func(p sequenceParams) (Iterable[int], error) {
+		return Sequence(p.start, p.end), nil
+	}); err != nil {
+		return err
+	}
+	if err := RegisterStage(t.registry, "filter", func(iter Iterable[int], p PredicateFunc[int]) (Iterable[int], error) {
+		return Filter(iter, p), nil
+	}); err != nil {
+		return err
+	}
+	return RegisterSink(t.registry, "sum", func(iter Iterable[int], p struct{}) (err error) {
+		t.sum, err = Reduce(iter, 0, func(a, b int) int {
+			return a + b
+		})
+		return
+	})
+}
+
+func theSequenceSourceIsRegisteredAgain() {
+	t.err = RegisterSource(t.registry, "sequence", func(p sequenceParams) (Iterable[int], error) {
+		return nil, nil
+	})
+}
+
+func thePipelineIsRun(pipeline string) {
+	aPredicateThatOnlySelectsOddNumbers()
+	specs := map[string]StepSpec{
+		"sequence(1..10)": {Name: "sequence", Params: sequenceParams{start: 1, end: 10}},
+		"sequence(wrong)": {Name: "sequence", Params: "1..10"},
+		"filter(odd)":     {Name: "filter", Params: t.predicate},
+		"unknown":         {Name: "unknown"},
+		"sum":             {Name: "sum"},
+	}
+	steps := strings.Split(pipeline, " | ")
+	spec := PipelineSpec{Source: specs[steps[0]], Sink: specs[steps[len(steps)-1]]}
+	for _, step := range steps[1 : len(steps)-1] {
+		spec.Stages = append(spec.Stages, specs[step])
+	}
+	t.err = t.registry.Run(spec)
+}
+
+func errUnknownNameIsReturned() error {
+	if !errors.Is(t.err, ErrUnknownName) {
+		return fmt.Errorf("expected: %v got: %v", ErrUnknownName, t.err)
+	}
+	return nil
+}
+
+func errDuplicateNameIsReturned() error {
+	if !errors.Is(t.err, ErrDuplicateName) {
+		return fmt.Errorf("expected: %v got: %v", ErrDuplicateName, t.err)
+	}
+	return nil
+}
+
+func anEmptyIterable() {
+	t.resultingIntIterator = FromSlice([]int{})
+}
+
+func sumIsCalled() {
+	t.sum, t.err = Sum(t.resultingIntIterator)
+}
+
+func productIsCalled() {
+	t.sum, t.err = Product(t.resultingIntIterator)
+}
+
+func setFromIteratorIsCalled() {
+	t.set, t.err = SetFromIterator(t.resultingIntIterator)
+}
+
+func theSetContainsValues(n int) error {
+	if t.set.Len() != n {
+		return fmt.Errorf("expected: %d values got: %d", n, t.set.Len())
+	}
+	return nil
+}
+
+func theSetContains(v int) error {
+	if !t.set.Contains(v) {
+		return fmt.Errorf("expected %d to be in the set", v)
+	}
+	return nil
+}
+
+func theSetDoesNotContain(v int) error {
+	if t.set.Contains(v) {
+		return fmt.Errorf("expected %d not to be in the set", v)
+	}
+	return nil
+}
+
+func bothIterablesAreCollectedIntoSetsAndCombinedWith(operation string) error {
+	first, err := SetFromIterator(t.resultingIntIterator)
+	if err != nil {
+		return err
+	}
+	second, err := SetFromIterator(t.secondIntIterator)
+	if err != nil {
+		return err
+	}
+	switch operation {
+	case "Union":
+		t.set = first.Union(second)
+	case "Intersect":
+		t.set = first.Intersect(second)
+	case "Diff":
+		t.set = first.Diff(second)
+	default:
+		return fmt.Errorf("unknown operation: %s", operation)
+	}
+	return nil
+}
+
+func iterIsCalledOnTheSet() {
+	t.resultingIntIterator = t.set.Iter()
+}
+
+func minIsCalled() {
+	t.sum, t.found, t.err = Min(t.resultingIntIterator)
+}
+
+func maxIsCalled() {
+	t.sum, t.found, t.err = Max(t.resultingIntIterator)
+}
+
+func theValueIsFound(v int) error {
+	if !t.found || t.sum != v {
+		return fmt.Errorf("expected: %d true got: %d %v", v, t.sum, t.found)
+	}
+	return nil
+}
+
+func noValueIsFound() error {
+	if t.found {
+		return fmt.Errorf("expected no value but got: %d", t.sum)
+	}
+	return nil
+}
+
+func formatStage() Stage[int, string] {
+	return MapStage(func(i int) string {
+		return fmt.Sprintf("#%d", i)
+	})
+}
+
+func theFilterStageAndAMapStageThatFormatsTheValuesAreChainedWithChain2() {
+	t.resultingStringIterator = Chain2(FilterStage(t.predicate), formatStage())(t.resultingIntIterator)
+}
+
+func theFilterStageAMapStageThatDoublesTheValuesAndAMapStageThatFormatsTheValuesAreChainedWithChain3() {
+	double := MapStage(func(i int) int {
+		return i * 2
+	})
+	t.resultingStringIterator = Chain3(FilterStage(t.predicate), double, formatStage())(t.resultingIntIterator)
+}
+
+func lastDigit(i int) int {
+	return i % 10
+}
+
+func minByIsCalledWithTheLastDigitAsKey() {
+	t.sum, t.found, t.err = MinBy(t.resultingIntIterator, lastDigit)
+}
+
+func maxByIsCalledWithTheLastDigitAsKey() {
+	t.sum, t.found, t.err = MaxBy(t.resultingIntIterator, lastDigit)
+}
+
+func consumeIsCalled() {
+	t.resultingIntIterator = Consume(t.resultingIntIterator)
+}
+
+func theIterableIsConsumedWithToSlice() {
+	_, t.err = ToSlice(t.resultingIntIterator)
+}
+
+func errConsumedIsReturned() error {
+	if !errors.Is(t.err, ErrConsumed) {
+		return fmt.Errorf("expected: %v got: %v", ErrConsumed, t.err)
+	}
+	return nil
+}
+
+func averageIsCalled() {
+	t.average, t.err = Average(t.resultingIntIterator)
+}
+
+func theReturnedAverageIs(expected float64) error {
+	if t.average != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, t.average)
+	}
+	return nil
+}
+
+func aStreamWithTheDocuments(stream string) {
+	t.stream = stream
+}
+
+func fromDecoderIsCalled() {
+	t.resultingIntIterator = FromDecoder[int](json.NewDecoder(strings.NewReader(t.stream)))
+}
+
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	if c, ok := b.Reader.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func anHTTPResponseWithStatusAndBody(status int, body string) {
+	t.body = &trackingBody{Reader: strings.NewReader(unescapeLineEndings(body))}
+	t.response = &http.Response{StatusCode: status, Status: fmt.Sprintf("%d %s", status, http.StatusText(status)), Body: t.body}
+}
+
+func anHTTPResponseWithStatusAndABodyThatFailsAfter(status int, body string) {
+	anHTTPResponseWithStatusAndBody(status, body)
+	t.body.Reader = io.MultiReader(t.body.Reader, failingReader{})
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func fromHTTPBodyLinesIsCalled() {
+	iter := FromHTTPBodyLines(t.response)
+	t.resultingStringIterator = iter
+	t.bodyIterator = iter
+}
+
+func fromHTTPBodyChunksIsCalledWithASizeOf(size int) {
+	iter := FromHTTPBodyChunks(t.response, size)
+	t.resultingStringIterator = Map[[]byte](iter, func(b []byte) string {
+		return string(b)
+	})
+	t.bodyIterator = iter
+}
+
+func closeIsCalledOnTheBodyIterator() error {
+	return t.bodyIterator.Close()
+}
+
+func theBodyIsClosed() error {
+	if !t.body.closed {
+		return errors.New("expected the body to be closed")
+	}
+	return nil
+}
+
+func nextOfStringIteratorReturnsTrueTimesAndThenReturnsFalse(num int) error {
+	for ; num > 0; num-- {
+		if _, r := t.resultingStringIterator.Next(); r != true {
+			return errors.New("expected: true got: false")
+		}
+	}
+	if _, r := t.resultingStringIterator.Next(); r != false {
+		return errors.New("expected: false got: true")
+	}
+	return nil
+}
+
+func statsIsCalled() {
+	t.statistics, t.err = Stats(t.resultingIntIterator)
+}
+
+func theFollowingStatisticsAreReturned(table *godog.Table) error {
+	var values []float64
+	for _, cell := range table.Rows[1].Cells {
+		v, err := strconv.ParseFloat(cell.Value, 64)
+		if err != nil {
+			return err
+		}
+		values = append(values, v)
+	}
+	expected := Statistics{Count: int(values[0]), Sum: values[1], Min: values[2], Max: values[3], Mean: values[4], Variance: values[5], StdDev: values[6]}
+	if !reflect.DeepEqual(expected, t.statistics) {
+		return fmt.Errorf("expected: %+v got: %+v", expected, t.statistics)
+	}
+	return nil
+}
+
+func parseValues(values string) ([]int, error) {
+	var s []int
+	for _, v := range strings.Split(values, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, i)
+	}
+	return s, nil
+}
+
+func anIterableWithTheValues(values string) error {
+	s, err := parseValues(values)
+	if err != nil {
+		return err
+	}
+	t.resultingIntIterator = FromSlice(s)
+	return nil
+}
+
+func aPredicateThatOnlySelectsEvenNumbers() {
+	t.predicate = func(i int) bool {
+		return i%2 == 0
+	}
+}
+
+func anyIsCalled() {
+	t.answer, t.err = Any(t.resultingIntIterator, t.predicate)
+}
+
+func allIsCalled() {
+	t.answer, t.err = All(t.resultingIntIterator, t.predicate)
+}
+
+func noneIsCalled() {
+	t.answer, t.err = None(t.resultingIntIterator, t.predicate)
+}
+
+func theReturnedAnswerIs(expected string) error {
+	if strconv.FormatBool(t.answer) != expected {
+		return fmt.Errorf("expected: %s got: %v", expected, t.answer)
+	}
+	return nil
+}
+
+func drainWithinIsCalledWithADeadlineOfMillisecondsAndAClosureThatTakesMilliseconds(deadline, duration int) {
+	t.processed, t.err = DrainWithin(t.resultingIntIterator, func(int) {
+		time.Sleep(time.Duration(duration) * time.Millisecond)
+	}, time.Duration(deadline)*time.Millisecond)
+}
+
+func valuesAreProcessed(n int) error {
+	if t.processed != n {
+		return fmt.Errorf("expected: %d got: %d", n, t.processed)
+	}
+	return nil
+}
+
+func aDeadlineErrorWithRemainingValuesIsReturned(n int) error {
+	var de *DrainDeadlineError
+	if !errors.As(t.err, &de) || !errors.Is(t.err, context.DeadlineExceeded) {
+		return fmt.Errorf("expected a DrainDeadlineError got: %v", t.err)
+	}
+	if !de.RemainingKnown || de.Remaining != n {
+		return fmt.Errorf("expected: %d remaining values got: %+v", n, de)
+	}
+	return nil
+}
+
+func findIsCalled() {
+	t.sum, t.found, t.err = Find(t.resultingIntIterator, t.predicate)
+}
+
+func findIndexIsCalled() {
+	t.index, t.err = FindIndex(t.resultingIntIterator, t.predicate)
+}
+
+func theReturnedIndexIs(expected int) error {
+	if t.index != expected {
+		return fmt.Errorf("expected: %d got: %d", expected, t.index)
+	}
+	return nil
+}
+
+func newChannelFromIsCalledWithABufferOf(buffer int) error {
+	t.valueChannel, t.errorChannel = NewChannelFrom(t.resultingIntIterator, buffer)
+	if cap(t.valueChannel) != buffer {
+		return fmt.Errorf("expected a buffer of: %d got: %d", buffer, cap(t.valueChannel))
+	}
+	return nil
+}
+
+func theFollowingValuesAreReceivedFromTheValueChannel(listofints *godog.Table) error {
+	expected, err := toSliceOfInts(listofints)
+	if err != nil {
+		return err
+	}
+	var received []int
+	for v := range t.valueChannel {
+		received = append(received, v)
+	}
+	if !reflect.DeepEqual(expected, received) {
+		return fmt.Errorf("expected: %v got: %v", expected, received)
+	}
+	return nil
+}
+
+func noValuesAreReceivedFromTheValueChannel() error {
+	for v := range t.valueChannel {
+		return fmt.Errorf("expected no values got: %v", v)
+	}
+	return nil
+}
+
+func theErrorChannelDeliversNoError() error {
+	if err := <-t.errorChannel; err != nil {
+		return fmt.Errorf("expected nil got: %v", err)
+	}
+	return nil
+}
+
+func theErrorChannelDeliversAnError() error {
+	if err := <-t.errorChannel; err == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func firstIsCalled() {
+	t.sum, t.found, t.err = First(t.resultingIntIterator)
+}
+
+func lastIsCalled() {
+	t.sum, t.found, t.err = Last(t.resultingIntIterator)
+}
+
+func nthIsCalledWith(n int) {
+	t.sum, t.found, t.err = Nth(t.resultingIntIterator, n)
+}
+
+func theTextTemplate(text string) (err error) {
+	t.template, err = template.New("test").Parse(text)
+	return
+}
+
+func executeTemplatePerElementIsCalled() {
+	t.err = ExecuteTemplatePerElement(t.resultingIntIterator, t.template, &t.output)
+}
+
+func theRenderedOutputIs(expected string) error {
+	if t.output.String() != expected {
+		return fmt.Errorf("expected: %q got: %q", expected, t.output.String())
+	}
+	return nil
+}
+
+func containsIsCalledWith(v int) {
+	t.answer, t.err = Contains(t.resultingIntIterator, v)
+}
+
+func indexOfIsCalledWith(v int) {
+	t.index, t.err = IndexOf(t.resultingIntIterator, v)
+}
+
+func chunkByWeightIsCalledWithTheValueAsWeightAndAMaximumWeightOf(maxWeight int) {
+	t.resultingGroupIterator = ChunkByWeight(t.resultingIntIterator, func(v int) int {
+		return v
+	}, maxWeight)
+}
+
+func toMapIsCalledWithTheValueAsKeyAndTheSquareAsValue() {
+	t.intMap, t.err = ToMap(t.resultingIntIterator, func(v int) int {
+		return v
+	}, func(v int) int {
+		return v * v
+	})
+}
+
+func toMapKeyedIsCalledWithTheLastDigitAsKey() {
+	t.intMap, t.err = ToMapKeyed(t.resultingIntIterator, lastDigit)
+}
+
+func parsePairs(pairs string) ([]Pair[int, int], error) {
+	var s []Pair[int, int]
+	if pairs == "" {
+		return s, nil
+	}
+	for _, kv := range strings.Split(pairs, ",") {
+		parts := strings.Split(kv, ":")
+		k, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, Pair[int, int]{Key: k, Value: v})
+	}
+	return s, nil
+}
+
+func parseMap(pairs string) (map[int]int, error) {
+	s, err := parsePairs(pairs)
+	if err != nil {
+		return nil, err
+	}
+	m := map[int]int{}
+	for _, p := range s {
+		m[p.Key] = p.Value
+	}
+	return m, nil
+}
+
+func theReturnedMapIs(expected string) error {
+	m, err := parseMap(expected)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(m, t.intMap) {
+		return fmt.Errorf("expected: %v got: %v", m, t.intMap)
+	}
+	return nil
+}
+
+func aCancellableContext() {
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+}
+
+func sequenceCtxIsCalledWithAnd(start, end int) {
+	t.resultingIntIterator = SequenceCtx(t.ctx, start, end)
+}
+
+func generateCtxIsCalledWithARepeatCountOf(r int) {
+	t.resultingIntIterator = GenerateCtx(t.ctx, 0, uint64(r), func(p int, c, r uint64) int {
+		return p + 1
+	})
+}
+
+func valuesAreTakenAndTheContextIsCancelled(n int) error {
+	for ; n > 0; n-- {
+		if _, r := t.resultingIntIterator.Next(); r != true {
+			return errors.New("expected: true got: false")
+		}
+	}
+	t.cancel()
+	return nil
+}
+
+func theErrorOfTheContextIsReturned() error {
+	if err := t.resultingIntIterator.Error(); !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("expected: %v got: %v", context.Canceled, err)
+	}
+	return nil
+}
+
+func toSetIsCalled() {
+	t.set, t.err = ToSet(t.resultingIntIterator)
+}
+
+func aIteratorThatReturnsValues(name string, n int) error {
+	values, _ := ToSlice[int](Sequence(1, n))
+	switch name {
+	case "FromSlice":
+		t.resultingIntIterator = FromSlice(values)
+	case "FromReverseSlice":
+		t.resultingIntIterator = FromReverseSlice(values)
+	case "FromChannel":
+		c := make(chan int, n)
+		for _, v := range values {
+			c <- v
+		}
+		close(c)
+		t.resultingIntIterator = FromChannel(c)
+	case "Map":
+		t.resultingIntIterator = Map[int](FromSlice(values), func(v int) int {
+			return v
+		})
+	case "Filter":
+		t.resultingIntIterator = Filter[int](FromSlice(append(values, 0)), func(v int) bool {
+			return v != 0
+		})
+	case "Take":
+		t.resultingIntIterator = Take[int](Sequence(1, n+1), n)
+	case "Sequence":
+		t.resultingIntIterator = Sequence(1, n)
+	case "WithContext":
+		t.resultingIntIterator = WithContext[int](context.Background(), FromSlice(values))
+	case "Fuse":
+		t.resultingIntIterator = Fuse[int](FromSlice(values))
+	default:
+		return fmt.Errorf("unknown iterator: %s", name)
+	}
+	return nil
+}
+
+func nextKeepsReturningFalse() error {
+	for i := 0; i < 3; i++ {
+		if _, r := t.resultingIntIterator.Next(); r != false {
+			return errors.New("expected: false got: true")
+		}
+	}
+	return nil
+}
+
+func fuseIsCalled() {
+	t.resultingIntIterator = Fuse(t.resultingIntIterator)
+}
+
+func theStateIs(expected string) error {
+	if s := State(t.resultingIntIterator); s.String() != expected {
+		return fmt.Errorf("expected: %s got: %v", expected, s)
+	}
+	return nil
+}
+
+func nextErrReturns(expected int) error {
+	v, err := NextErr(t.resultingIntIterator)
+	if err != nil || v != expected {
+		return fmt.Errorf("expected: %d <nil> got: %d %v", expected, v, err)
+	}
+	return nil
+}
+
+func nextErrReturnsErrExhausted() error {
+	if _, err := NextErr(t.resultingIntIterator); !errors.Is(err, ErrExhausted) {
+		return fmt.Errorf("expected: %v got: %v", ErrExhausted, err)
+	}
+	return nil
+}
+
+func nextErrReturnsAnError() error {
+	if _, err := NextErr(t.resultingIntIterator); err == nil || errors.Is(err, ErrExhausted) {
+		return fmt.Errorf("expected the error of the iterable got: %v", err)
+	}
+	return nil
+}
+
+func anIterableOfStrings(values string) {
+	t.resultingStringIterator = FromSlice(strings.Split(values, ","))
+}
+
+func anIterableOfStringsInAnErrorState() {
+	t.resultingStringIterator = &ErrorIterator[string]{}
+}
+
+func joinStringIsCalledWithTheSeparator(sep string) {
+	t.str, t.err = JoinString(t.resultingStringIterator, sep)
+}
+
+func theReturnedStringIs(expected string) error {
+	if t.str != expected {
+		return fmt.Errorf("expected: %q got: %q", expected, t.str)
+	}
+	return nil
+}
+
+func frequenciesIsCalled() {
+	t.intMap, t.err = Frequencies(t.resultingIntIterator)
+}
+
+func countByIsCalledWithTheLastDigitAsKey() {
+	t.intMap, t.err = CountBy(t.resultingIntIterator, lastDigit)
+}
+
+func aSecondIterableWithTheValues(values string) error {
+	s, err := parseValues(values)
+	if err != nil {
+		return err
+	}
+	t.secondIntIterator = FromSlice(s)
+	return nil
+}
+
+func equalIsCalled() {
+	t.answer, t.err = Equal(t.resultingIntIterator, t.secondIntIterator)
+}
+
+func equalByIsCalledWithAComparatorOfTheLastDigit() {
+	t.answer, t.err = EqualBy(t.resultingIntIterator, t.secondIntIterator, func(a, b int) bool {
+		return lastDigit(a) == lastDigit(b)
+	})
+}
+
+func compareIsCalled() {
+	t.comparison, t.err = Compare(t.resultingIntIterator, t.secondIntIterator)
+}
+
+func theReturnedComparisonIs(expected int) error {
+	if t.comparison != expected {
+		return fmt.Errorf("expected: %d got: %d", expected, t.comparison)
+	}
+	return nil
+}
+
+func isSortedIsCalled() {
+	t.answer, t.err = IsSorted(t.resultingIntIterator, intLess)
+}
+
+func reduceWhileIsCalledWithAReducerThatSumsUntilTheSumExceeds(limit int) {
+	t.sum, t.err = ReduceWhile(t.resultingIntIterator, 0, func(sum, v int) (int, bool) {
+		sum += v
+		return sum, sum <= limit
+	})
+}
+
+func tryFoldIsCalledWithAReducerThatSumsAndRejectsNegativeValues() {
+	t.sum, t.err = TryFold(t.resultingIntIterator, 0, func(sum, v int) (int, error) {
+		if v < 0 {
+			return 0, fmt.Errorf("negative value: %d", v)
+		}
+		return sum + v, nil
+	})
+}
+
+func anErrorForTheValueAtIndexIsReturned(index int) error {
+	var ie *IndexError
+	if !errors.As(t.err, &ie) || ie.Index != index {
+		return fmt.Errorf("expected an error for the value at index %d got: %v", index, t.err)
+	}
+	return nil
+}
+
+func forEachIndexedIsCalled() {
+	t.intMap = map[int]int{}
+	t.err = ForEachIndexed(t.resultingIntIterator, func(i int, v int) {
+		t.intMap[i] = v
+	})
+}
+
+func aCollectorThatAcceptsAtMostValues(n int) {
+	t.collector = CollectorFunc[int](func(v int) error {
+		if len(t.collected) == n {
+			return errors.New("collector is full")
+		}
+		t.collected = append(t.collected, v)
+		return nil
+	})
+}
+
+func collectIntoIsCalled() {
+	t.err = CollectInto(t.resultingIntIterator, t.collector)
+}
+
+func theCollectorContains(values string) error {
+	expected, err := parseValues(values)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected, t.collected) {
+		return fmt.Errorf("expected: %v got: %v", expected, t.collected)
+	}
+	return nil
+}
+
+func minMaxIsCalled() {
+	t.sum, t.maximum, t.found, t.err = MinMax(t.resultingIntIterator)
+}
+
+func theReturnedRangeIsTo(minimum, maximum int) error {
+	if !t.found || t.sum != minimum || t.maximum != maximum {
+		return fmt.Errorf("expected: %d to %d got: %d to %d (%v)", minimum, maximum, t.sum, t.maximum, t.found)
+	}
+	return nil
+}
+
+func parseFloats(values string) ([]float64, error) {
+	var s []float64
+	for _, v := range strings.Split(values, ",") {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, f)
+	}
+	return s, nil
+}
+
+func anIterableWithTheValuesToInAShuffledOrder(start, end int) {
+	values, _ := ToSlice[int](Sequence(start, end))
+	rand.New(rand.NewSource(1)).Shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+	t.resultingIntIterator = FromSlice(values)
+}
+
+func quantilesIsCalledWith(qs string) error {
+	q, err := parseFloats(qs)
+	if err != nil {
+		return err
+	}
+	t.quantiles, t.err = Quantiles(t.resultingIntIterator, q...)
+	return nil
+}
+
+func quantilesApproxIsCalledWith(qs string) error {
+	q, err := parseFloats(qs)
+	if err != nil {
+		return err
+	}
+	t.quantiles, t.err = QuantilesApprox(t.resultingIntIterator, q...)
+	return nil
+}
+
+func theReturnedQuantilesAre(values string) error {
+	expected, err := parseFloats(values)
+	if err != nil {
+		return err
+	}
+	if fmt.Sprint(expected) != fmt.Sprint(t.quantiles) {
+		return fmt.Errorf("expected: %v got: %v", expected, t.quantiles)
+	}
+	return nil
+}
+
+func theReturnedQuantilesAreWithinPercentOf(percent int, values string) error {
+	expected, err := parseFloats(values)
+	if err != nil {
+		return err
+	}
+	if len(expected) != len(t.quantiles) {
+		return fmt.Errorf("expected: %v got: %v", expected, t.quantiles)
+	}
+	for i, e := range expected {
+		if math.Abs(t.quantiles[i]-e) > e*float64(percent)/100 {
+			return fmt.Errorf("expected: %v got: %v", expected, t.quantiles)
+		}
+	}
+	return nil
+}
+
+func histogramIsCalledWithTheBuckets(buckets string) error {
+	b, err := parseValues(buckets)
+	if err != nil {
+		return err
+	}
+	t.histogram, t.err = Histogram(t.resultingIntIterator, b)
+	return nil
+}
+
+func histogramIsCalledWithoutBuckets() {
+	t.histogram, t.err = Histogram(t.resultingIntIterator, nil)
+}
+
+func theReturnedCountsAre(counts string) error {
+	expected, err := parseValues(counts)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected, t.histogram.Counts) {
+		return fmt.Errorf("expected: %v got: %v", expected, t.histogram.Counts)
+	}
+	return nil
+}
+
+func groupReduceIsCalledWithTheLastDigitAsKey() {
+	t.intMap, t.err = GroupReduce(t.resultingIntIterator, lastDigit, 0, t.reducer)
+}
+
+func aMap(pairs string) (err error) {
+	t.intMap, err = parseMap(pairs)
+	return
+}
+
+func fromMapKeysIsCalled() {
+	t.resultingIntIterator = FromMapKeys(t.intMap)
+}
+
+func fromMapValuesIsCalled() {
+	t.resultingIntIterator = FromMapValues(t.intMap)
+}
+
+func fromMapKeysSortedIsCalled() {
+	t.resultingIntIterator = FromMapKeysSorted(t.intMap)
+}
+
+func fromMapValuesSortedIsCalled() {
+	t.resultingIntIterator = FromMapValuesSorted(t.intMap)
+}
+
+func fromMapSortedIsCalled() {
+	t.resultingPairIterator = FromMapSorted(t.intMap)
+}
+
+func fromMapIsCalled() {
+	t.resultingPairIterator = FromMap(t.intMap)
+}
+
+func theFollowingPairsAreReturned(pairs string) error {
+	expected, err := parsePairs(pairs)
+	if err != nil {
+		return err
+	}
+	result, err := ToSlice(t.resultingPairIterator)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected, result) {
+		return fmt.Errorf("expected: %v got: %v", expected, result)
+	}
+	return nil
+}
+
+func theFollowingPairsAreReturnedInAnyOrder(pairs string) error {
+	result, err := ToSlice(t.resultingPairIterator)
+	if err != nil {
+		return err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	t.resultingPairIterator = FromSlice(result)
+	return theFollowingPairsAreReturned(pairs)
+}
+
+func theString(s string) {
+	t.str = s
+}
+
+func fromStringRunesIsCalled() {
+	t.resultingStringIterator = Map[rune](FromStringRunes(t.str), func(r rune) string {
+		return string(r)
+	})
+}
+
+func fromStringBytesIsCalled() {
+	t.resultingIntIterator = Map[byte](FromStringBytes(t.str), func(b byte) int {
+		return int(b)
+	})
+}
+
+func aReaderWithTheContents(contents string) {
+	t.reader = strings.NewReader(unescapeLineEndings(contents))
+}
+
+func aReaderWithTheContentsThatFailsAfterwards(contents string) {
+	t.reader = io.MultiReader(strings.NewReader(unescapeLineEndings(contents)), failingReader{})
+}
+
+func unescapeLineEndings(s string) string {
+	return strings.NewReplacer(`\r`, "\r", `\n`, "\n").Replace(s)
+}
+
+func fromReaderIsCalledWithAChunkSizeOf(size int) {
+	t.resultingStringIterator = Map[[]byte](FromReader(t.reader, size), func(b []byte) string {
+		return string(b)
+	})
+}
+
+func linesIsCalled() {
+	t.resultingStringIterator = Lines(t.reader)
+}
+
+func linesIsCalledWithASplitFunctionForWords() {
+	t.resultingStringIterator = Lines(t.reader, WithSplitFunc(bufio.ScanWords))
+}
+
+func linesIsCalledWithAMaximumTokenSizeOf(size int) {
+	t.resultingStringIterator = Lines(t.reader, WithMaxTokenSize(size))
+}
+
+func aScannerThatSplitsTheReaderIntoWords() {
+	// A small buffer that is filled one byte at a time makes the scanner reuse its buffer while scanning.
+	t.scanner = bufio.NewScanner(iotest.OneByteReader(t.reader))
+	t.scanner.Buffer(make([]byte, 6), 6)
+	t.scanner.Split(bufio.ScanWords)
+}
+
+func fromScannerIsCalled() {
+	t.resultingStringIterator = FromScanner(t.scanner)
+}
+
+func fromScannerBytesIsCalledAndAllTokensAreCollected() error {
+	tokens, err := ToSlice[[]byte](FromScannerBytes(t.scanner))
+	if err != nil {
+		return err
+	}
+	t.resultingStringIterator = Map[[]byte](FromSlice(tokens), func(b []byte) string {
+		return string(b)
+	})
+	return nil
+}
+
+func theCollectedTokensAre(expected string) error {
+	tokens, err := ToSlice(t.resultingStringIterator)
+	if err != nil {
+		return err
+	}
+	if strings.Join(tokens, ",") != expected {
+		return fmt.Errorf("expected: %s got: %v", expected, tokens)
+	}
+	return nil
+}
+
+func fromCSVIsCalled() {
+	t.resultingRecordIterator = FromCSV(csv.NewReader(t.reader))
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnTheFollowingRecords(table *godog.Table) error {
+	var expected [][]string
+	for _, row := range table.Rows {
+		var record []string
+		for _, cell := range row.Cells {
+			record = append(record, cell.Value)
+		}
+		expected = append(expected, record)
+	}
+	var results [][]string
+	for v, b := t.resultingRecordIterator.Next(); b; v, b = t.resultingRecordIterator.Next() {
+		results = append(results, v)
+	}
+	if !reflect.DeepEqual(expected, results) {
+		return fmt.Errorf("expected: %v got: %v", expected, results)
+	}
+	return nil
+}
+
+func errorOfRecordIteratorReturnsNil() error {
+	if err := t.resultingRecordIterator.Error(); err != nil {
+		return fmt.Errorf("expected nil but got: %v", err)
+	}
+	return nil
+}
+
+func errorOfRecordIteratorReturnsAnError() error {
+	if t.resultingRecordIterator.Error() == nil {
+		return errors.New("expected an error but got nil")
+	}
+	return nil
+}
+
+func theRecordsWithAnIdAreWrittenWithToCSV() {
+	t.output.Reset()
+	withID := Filter(t.resultingRecordIterator, func(record []string) bool {
+		_, err := strconv.Atoi(record[0])
+		return err == nil
+	})
+	t.err = ToCSV(withID, csv.NewWriter(&t.output))
+}
+
+func theWrittenCSVIs(expected string) error {
+	if t.output.String() != unescapeLineEndings(expected) {
+		return fmt.Errorf("expected: %q got: %q", unescapeLineEndings(expected), t.output.String())
+	}
+	return nil
+}
+
+func fromJSONArrayIsCalled() {
+	t.resultingIntIterator = FromJSONArray[int](json.NewDecoder(t.reader))
+}
+
+func fromJSONLinesIsCalled() {
+	t.resultingIntIterator = FromJSONLines[int](t.reader)
+}
+
+// fakeDriverName is the name of a database/sql driver whose queries return the comma separated values of the data
+// source name as a single column. The data source name can end with the value fail to report an iteration error.
+const fakeDriverName = "iterator-fake"
+
+func init() {
+	sql.Register(fakeDriverName, fakeDriver{})
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	return fakeConn{dsn: dsn}, nil
+}
+
+type fakeConn struct {
+	dsn string
+}
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) {
+	return fakeStmt(c), nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	dsn string
+}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return 0
+}
+
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	var values []string
+	if s.dsn != "" {
+		values = strings.Split(s.dsn, ",")
+	}
+	return &fakeRows{values: values}, nil
+}
+
+type fakeRows struct {
+	values []string
+}
+
+func (*fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	t.rowsClosed = true
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	if v == "fail" {
+		return errors.New("connection lost")
+	}
+	dest[0] = v
+	return nil
+}
+
+func aQueryReturningTheRows(values string) error {
+	db, err := sql.Open(fakeDriverName, values)
+	if err != nil {
+		return err
+	}
+	t.db = db
+	return nil
+}
+
+func aQueryReturningTheRowsThatFailsAfterwards(values string) error {
+	return aQueryReturningTheRows(values + ",fail")
+}
+
+func scanInt(rows *sql.Rows) (v int, err error) {
+	err = rows.Scan(&v)
+	return v, err
+}
+
+func fromRowsIsCalledWith(scan func(*sql.Rows) (int, error)) error {
+	rows, err := t.db.Query("SELECT value")
+	if err != nil {
+		return err
+	}
+	t.rowsIterator = FromRows(rows, scan)
+	t.resultingIntIterator = t.rowsIterator
+	return nil
+}
+
+func fromRowsIsCalled() error {
+	return fromRowsIsCalledWith(scanInt)
+}
+
+func fromRowsIsCalledWithAScanFunctionThatFailsOnTheValue(value int) error {
+	return fromRowsIsCalledWith(func(rows *sql.Rows) (int, error) {
+		v, err := scanInt(rows)
+		if err == nil && v == value {
+			err = fmt.Errorf("unexpected value %d", v)
+		}
+		return v, err
+	})
+}
+
+func nextIsCalledOnceAndTheRowsIteratorIsClosed() error {
+	if _, ok := t.rowsIterator.Next(); !ok {
+		return errors.New("expected a value")
+	}
+	return t.rowsIterator.Close()
+}
+
+func theRowsAreClosed() error {
+	if !t.rowsClosed {
+		return errors.New("expected the rows to be closed")
+	}
+	return nil
+}
+
+func fibonacciIsCalled() {
+	t.resultingIntIterator = Map[uint64](Fibonacci(), func(v uint64) int {
+		return int(v)
+	})
+}
+
+func theLastGeneratedFibonacciNumberIsAfterNumbers(number string, count int) error {
+	expected, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return err
+	}
+	iter := Fibonacci()
+	var last uint64
+	n := 0
+	for v, ok := iter.Next(); ok; v, ok = iter.Next() {
+		last = v
+		n++
+	}
+	if n != count || last != expected {
+		return fmt.Errorf("expected %d after %d numbers, got %d after %d numbers", expected, count, last, n)
+	}
+	return nil
+}
+
+func primesIsCalled() {
+	t.resultingIntIterator = Primes()
+}
+
+func takeWhileIsCalled() {
+	t.sourceIterator = t.resultingIntIterator
+	t.resultingIntIterator = TakeWhile(t.resultingIntIterator, t.predicate)
+}
+
+func takeWhileIsCalledWithAPredicateThatSelectsValuesBelow(limit int) {
+	t.predicate = func(v int) bool {
+		return v < limit
+	}
+	takeWhileIsCalled()
+}
+
+func theSourceIterableReturnsTheValueNext(expected int) error {
+	if v, ok := t.sourceIterator.Next(); !ok || v != expected {
+		return fmt.Errorf("expected %d, got %d, %v", expected, v, ok)
+	}
+	return nil
+}
+
+func randomNIsCalledWithTheSeedTheRangeToAndValues(seed, min, max, n int) {
+	t.randomSettings = [3]int{min, max, n}
+	t.resultingIntIterator = RandomN(rand.NewSource(int64(seed)), min, max, n)
+}
+
+func allGeneratedValuesAreWithinTheRangeTo(count, min, max int) error {
+	values, err := ToSlice(t.resultingIntIterator)
+	if err != nil {
+		return err
+	}
+	if len(values) != count {
+		return fmt.Errorf("expected %d values, got %d", count, len(values))
+	}
+	for _, v := range values {
+		if v < min || v >= max {
+			return fmt.Errorf("value %d is not within [%d, %d)", v, min, max)
+		}
+	}
+	t.slice = values
+	return nil
+}
+
+func allValuesWithinTheRangeToAreGenerated(min, max int) error {
+	seen := map[int]bool{}
+	for _, v := range t.slice {
+		seen[v] = true
+	}
+	for v := min; v < max; v++ {
+		if !seen[v] {
+			return fmt.Errorf("value %d was not generated", v)
+		}
+	}
+	return nil
+}
+
+func randomValuesWithSeed(seed int) ([]int, []int, error) {
+	values, err := ToSlice(t.resultingIntIterator)
+	if err != nil {
+		return nil, nil, err
+	}
+	other, err := ToSlice[int](RandomN(rand.NewSource(int64(seed)), t.randomSettings[0], t.randomSettings[1], t.randomSettings[2]))
+	return values, other, err
+}
+
+func theValuesAreEqualToTheValuesOfRandomNCalledWithTheSeed(seed int) error {
+	values, other, err := randomValuesWithSeed(seed)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(values, other) {
+		return fmt.Errorf("expected %v to equal %v", values, other)
+	}
+	return nil
+}
+
+func theValuesDifferFromTheValuesOfRandomNCalledWithTheSeed(seed int) error {
+	values, other, err := randomValuesWithSeed(seed)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(values, other) {
+		return fmt.Errorf("expected %v to differ from %v", values, other)
+	}
+	return nil
+}
+
+func randomNIsCalledWithTheSeedForInt8ValuesFromToAndValues(seed, min, max, n int) {
+	iter := RandomN(rand.NewSource(int64(seed)), int8(min), int8(max), n)
+	t.resultingIntIterator = Map[int8](iter, func(v int8) int {
+		return int(v)
+	})
+}
+
+func allInt8ValuesFromToAreGenerated(min, max int) error {
+	values, err := ToSlice(t.resultingIntIterator)
+	if err != nil {
+		return err
+	}
+	t.slice = values
+	if err := allValuesWithinTheRangeToAreGenerated(min, max+1); err != nil {
+		return err
+	}
+	for _, v := range values {
+		if v == max+1 {
+			return fmt.Errorf("value %d is outside of the range", v)
+		}
+	}
+	return nil
+}
+
+func randomNIsCalledWithTheSeedForFloat64ValuesFromToAndValues(seed, min, max, n int) {
+	t.resultingFloatIterator = RandomN(rand.NewSource(int64(seed)), float64(min), float64(max), n)
+	t.randomSettings = [3]int{min, max, n}
+}
+
+func allFloat64ValuesAreAtLeastAndLessThan(min, max int) error {
+	values, err := ToSlice(t.resultingFloatIterator)
+	if err != nil {
+		return err
+	}
+	if len(values) != t.randomSettings[2] {
+		return fmt.Errorf("expected %d values, got %d", t.randomSettings[2], len(values))
+	}
+	for _, v := range values {
+		if v < float64(min) || v >= float64(max) {
+			return fmt.Errorf("value %v is not within [%d, %d)", v, min, max)
+		}
+	}
+	return nil
+}
+
+func emptyIsCalled() {
+	t.resultingIntIterator = Empty[int]()
+}
+
+func onceIsCalledWith(v int) {
+	t.resultingIntIterator = Once(v)
+}
+
+func countDown(from, failAt int) func() (int, bool, error) {
+	return func() (int, bool, error) {
+		t.calls++
+		v := from - t.calls + 1
+		if v == failAt {
+			return 0, false, fmt.Errorf("failed at %d", v)
+		}
+		return v, v > 0, nil
+	}
+}
+
+func fromFuncIsCalledWithAFunctionThatCountsDownFrom(from int) {
+	f := countDown(from, -1)
+	t.resultingIntIterator = FromFunc(func() (int, bool) {
+		v, ok, _ := f()
+		return v, ok
+	})
+}
+
+func fromFuncErrIsCalledWithAFunctionThatCountsDownFrom(from int) {
+	t.resultingIntIterator = FromFuncErr(countDown(from, -1))
+}
+
+func fromFuncErrIsCalledWithAFunctionThatCountsDownFromAndFailsAt(from, failAt int) {
+	t.resultingIntIterator = FromFuncErr(countDown(from, failAt))
+}
+
+func theFunctionWasCalledTimes(expected int) error {
+	if t.calls != expected {
+		return fmt.Errorf("expected %d calls, got %d", expected, t.calls)
+	}
+	return nil
+}
+
+func sequenceFromIsCalledWith(start int) {
+	t.resultingIntIterator = SequenceFrom(start)
+}
+
+func linspaceIsCalledWithAnd(start, end float64, n int) {
+	t.resultingFloatIterator = Linspace(start, end, n)
+}
+
+func floatStepSequenceIsCalledWithAnd(start, end, step float64) {
+	t.resultingFloatIterator = FloatStepSequence(start, end, step)
+}
+
+func theLastFloatValueIsAfterValues(expected float64, count int) error {
+	values, err := ToSlice(t.resultingFloatIterator)
+	if err != nil {
+		return err
+	}
+	if len(values) != count || values[len(values)-1] != expected {
+		return fmt.Errorf("expected %v after %d values, got %v", expected, count, values)
+	}
+	return nil
+}
+
+func noValuesAreReceivedFromTheFloatIterator() error {
+	if v, ok := t.resultingFloatIterator.Next(); ok {
+		return fmt.Errorf("expected no values, got %v", v)
+	}
+	return nil
+}
+
+func callingNextUntilFalseIsReturnedShouldReturnApproximatelyTheFollowingFloats(listoffloats *godog.Table) error {
+	results, err := ToSlice(t.resultingFloatIterator)
+	if err != nil {
+		return err
+	}
+	if len(results) != len(listoffloats.Rows) {
+		return fmt.Errorf("expected %d values, got: %v", len(listoffloats.Rows), results)
+	}
+	for i, row := range listoffloats.Rows {
+		v, err := strconv.ParseFloat(row.Cells[0].Value, 64)
+		if err != nil {
+			return err
+		}
+		if math.Abs(results[i]-v) > 1e-12 {
+			return fmt.Errorf("expected %v at index %d, got: %v", v, i, results)
+		}
+	}
+	return nil
+}
+
+func ticksIsCalledWithMilliseconds(ms int) {
+	t.tickIterator = Ticks(time.Duration(ms) * time.Millisecond)
+	t.ticks = t.tickIterator
+}
+
+func ticksAreReturnedInIncreasingOrder(n int) error {
+	defer t.tickIterator.Stop()
+	var previous time.Time
+	for i := 0; i < n; i++ {
+		v, ok := t.ticks.Next()
+		if !ok {
+			return fmt.Errorf("expected tick %d", i+1)
+		}
+		if !v.After(previous) {
+			return fmt.Errorf("expected %v to be after %v", v, previous)
+		}
+		previous = v
+	}
+	return nil
+}
+
+func theTickIteratorIsStopped() {
+	t.tickIterator.Stop()
+}
+
+func theTickIteratorIsStoppedAfterMilliseconds(ms int) {
+	time.AfterFunc(time.Duration(ms)*time.Millisecond, t.tickIterator.Stop)
+}
+
+func withContextIsCalledOnTheTickIterator() {
+	t.ticks = WithContext[time.Time](t.ctx, t.tickIterator)
+}
+
+func noTickIsReturned() error {
+	defer t.tickIterator.Stop()
+	if v, ok := t.ticks.Next(); ok {
+		return fmt.Errorf("expected no tick, got %v", v)
+	}
+	return nil
+}
+
+func errorOfTickIteratorReturnsNil() error {
+	return t.ticks.Error()
+}
+
+func errorOfTickIteratorReturnsTheErrorOfTheContext() error {
+	if err := t.ticks.Error(); !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("expected: %v got: %v", context.Canceled, err)
+	}
+	return nil
+}
+
+// unreadableDirFS is a file system that fails to read the contents of dir.
+type unreadableDirFS struct {
+	fstest.MapFS
+	dir string
+}
+
+func (u unreadableDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name == u.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrPermission}
+	}
+	return u.MapFS.ReadDir(name)
+}
+
+func mapFS(files string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for _, name := range strings.Split(files, ",") {
+		fsys[name] = &fstest.MapFile{}
+	}
+	return fsys
+}
+
+func aFileSystemWithTheFiles(files string) {
+	t.fsys = mapFS(files)
 }
 
-var t testFixture
+func aFileSystemWithTheFilesWhereDirectoryCannotBeRead(files, dir string) {
+	t.fsys = unreadableDirFS{MapFS: mapFS(files), dir: dir}
+}
 
-func toSliceOfInts(table *godog.Table) (result []int, err error) {
-	var value int
-	for _, row := range table.Rows {
-		value, err = strconv.Atoi(row.Cells[0].Value)
-		if err != nil {
-			return
-		}
-		result = append(result, value)
+func walkDirIsCalledWithTheRoot(root string) {
+	t.walkIterator = WalkDir(t.fsys, root)
+}
+
+func walkDirIsCalledWithTheRootSkippingDirectoriesNamed(root, name string) {
+	t.walkIterator = WalkDir(t.fsys, root, WithSkipDir(func(e DirEntryPath) bool {
+		return e.Entry.Name() == name
+	}))
+}
+
+func walkedPaths() (string, error) {
+	paths, err := ToSlice[string](Map[DirEntryPath](t.walkIterator, func(e DirEntryPath) string {
+		return e.Path
+	}))
+	t.walkedPaths = paths
+	return strings.Join(paths, ","), err
+}
+
+func theWalkedPathsAre(expected string) error {
+	paths, _ := walkedPaths()
+	if paths != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, paths)
 	}
-	return
+	return nil
 }
 
-func toSliceOfStrings(table *godog.Table) (result []string) {
-	var value string
-	for _, row := range table.Rows {
-		value = row.Cells[0].Value
-		result = append(result, value)
+func theWalkedPathsAreInTheSameOrderAsFsWalkDir() error {
+	var expected []string
+	err := fs.WalkDir(t.fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		expected = append(expected, path)
+		return err
+	})
+	if err != nil {
+		return err
 	}
-	return
+	if !reflect.DeepEqual(expected, t.walkedPaths) {
+		return fmt.Errorf("expected: %v got: %v", expected, t.walkedPaths)
+	}
+	return nil
 }
 
-func nextReturnsTrueTimesAndThenReturnsFalse(num int) error {
-	for ; num > 0; num-- {
+func errorOfWalkIteratorReturnsNil() error {
+	return t.walkIterator.Error()
+}
 
-		if _, r := t.resultingIntIterator.Next(); r != true {
-			return errors.New("expected: true got: false")
-		}
+func errorOfWalkIteratorReturnsAnError() error {
+	if t.walkIterator.Error() == nil {
+		return errors.New("expected an error")
 	}
-	if _, r := t.resultingIntIterator.Next(); r != false {
-		return errors.New("expected: false got: true")
+	return nil
+}
+
+func fromRegexpIsCalledWithThePatternOn(pattern, text string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
 	}
+	t.regexp = re
+	t.text = unescapeLineEndings(text)
+	t.resultingStringIterator = FromRegexp(re, t.text)
 	return nil
 }
 
-func aSliceIteratorIsReturnedWithIdxContaining(arg1 int) error {
-	si := t.resultingIntIterator.(*SliceIterator[int])
-	if arg1 != si.idx {
-		return fmt.Errorf("expected: %v got: %v", arg1, si.idx)
+func fromRegexpSubmatchIsCalledWithThePatternOn(pattern, text string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
 	}
+	t.regexp = re
+	t.text = unescapeLineEndings(text)
+	t.submatches = FromRegexpSubmatch(re, t.text)
 	return nil
 }
 
-func aSliceIteratorIsReturnedWithReverseContainingFalse() error {
-	si := t.resultingIntIterator.(*SliceIterator[int])
-	if false != si.reverse {
-		return fmt.Errorf("expected: %v got: %v", false, si.reverse)
+func theMatchesAre(expected string) error {
+	matches, err := ToSlice(t.resultingStringIterator)
+	if err != nil {
+		return err
+	}
+	if got := strings.Join(matches, ","); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
 	}
 	return nil
 }
 
-func aSliceIteratorIsReturnedWithReverseContainingTrue() error {
-	si := t.resultingIntIterator.(*SliceIterator[int])
-	if true != si.reverse {
-		return fmt.Errorf("expected: %v got: %v", true, si.reverse)
+func theMatchesAreTheSameAsTheMatchesOfFindAllString() error {
+	matches, err := ToSlice(t.resultingStringIterator)
+	if err != nil {
+		return err
+	}
+	expected := t.regexp.FindAllString(t.text, -1)
+	if len(matches) != len(expected) || (len(matches) > 0 && !reflect.DeepEqual(matches, expected)) {
+		return fmt.Errorf("expected: %q got: %q", expected, matches)
 	}
 	return nil
 }
 
-func aSliceIteratorIsReturnedWithValuesContaining(listofints *godog.Table) error {
-	si := t.resultingIntIterator.(*SliceIterator[int])
-	s, err := toSliceOfInts(listofints)
+func theSubmatchesAre(expected string) error {
+	var got []string
+	err := ForEach(t.submatches, func(match []string) {
+		got = append(got, strings.Join(match, "/"))
+	})
 	if err != nil {
 		return err
 	}
-	if !reflect.DeepEqual(s, si.values) {
-		return fmt.Errorf("expected: %v got: %v", s, si.values)
+	t.submatches = FromRegexpSubmatch(t.regexp, t.text)
+	if strings.Join(got, ",") != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, strings.Join(got, ","))
 	}
 	return nil
 }
 
-func aSliceWithTheFollowingValues(listofints *godog.Table) (err error) {
-	t.slice, err = toSliceOfInts(listofints)
-	return
+func theSubmatchesAreTheSameAsTheMatchesOfFindAllStringSubmatch() error {
+	matches, err := ToSlice(t.submatches)
+	if err != nil {
+		return err
+	}
+	expected := t.regexp.FindAllStringSubmatch(t.text, -1)
+	if !reflect.DeepEqual(matches, expected) {
+		return fmt.Errorf("expected: %q got: %q", expected, matches)
+	}
+	return nil
 }
 
-func fromSliceIsCalled() {
-	t.resultingIntIterator = FromSlice(t.slice)
+func splitStringIsCalledOnWithTheSeparator(text, sep string) {
+	t.text = unescapeLineEndings(text)
+	t.separator = sep
+	t.resultingStringIterator = SplitString(t.text, sep)
 }
 
-func fromReverseSliceIsCalled() {
-	t.resultingIntIterator = FromReverseSlice(t.slice)
+func fieldsStringIsCalledOn(text string) {
+	t.text = strings.ReplaceAll(unescapeLineEndings(text), `\t`, "\t")
+	t.resultingStringIterator = FieldsString(t.text)
 }
 
-func aPredicateThatOnlySelectsOddNumbers() {
-	t.predicate = func(a int) bool {
-		result := (a % 2) != 0
-		return result
+func theSubstringsAre(expected string) error {
+	substrings, err := ToSlice(t.resultingStringIterator)
+	if err != nil {
+		return err
 	}
+	if got := strings.Join(substrings, "/"); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
+	}
+	return nil
 }
 
-func anIterableWithTheFollowingValues(listofints *godog.Table) error {
-	s, err := toSliceOfInts(listofints)
+func theSubstringsAreTheSameAsTheSubstringsOfStringsSplit() error {
+	substrings, err := ToSlice(t.resultingStringIterator)
+	if err != nil {
+		return err
+	}
+	expected := strings.Split(t.text, t.separator)
+	if len(substrings) != len(expected) || (len(expected) > 0 && !reflect.DeepEqual(substrings, expected)) {
+		return fmt.Errorf("expected: %q got: %q", expected, substrings)
+	}
+	return nil
+}
+
+func aListWithTheValues(values string) error {
+	s, err := parseValues(values)
+	if err != nil {
+		return err
+	}
+	t.list = list.New()
+	for _, v := range s {
+		t.list.PushBack(v)
+	}
+	return nil
+}
+
+func anEmptyList() {
+	t.list = list.New()
+}
+
+func aNilList() {
+	t.list = nil
+}
+
+func aListWithTheValuesAndAStringAtIndex(values string, idx int) error {
+	if err := aListWithTheValues(values); err != nil {
+		return err
+	}
+	e := t.list.Front()
+	for i := 0; i < idx; i++ {
+		e = e.Next()
+	}
+	e.Value = "not an int"
+	return nil
+}
+
+func fromListIsCalled() {
+	t.resultingIntIterator = FromList[int](t.list)
+}
+
+func aRingWithTheValues(values string) error {
+	s, err := parseValues(values)
+	if err != nil {
+		return err
+	}
+	t.ring = ring.New(len(s))
+	for _, v := range s {
+		t.ring.Value = v
+		t.ring = t.ring.Next()
+	}
+	return nil
+}
+
+func aRingWithTheValuesAndAStringAtIndex(values string, idx int) error {
+	if err := aRingWithTheValues(values); err != nil {
+		return err
+	}
+	t.ring.Move(idx).Value = "not an int"
+	return nil
+}
+
+func fromRingIsCalledWithLaps(laps int) {
+	t.resultingIntIterator = FromRing[int](t.ring, laps)
+}
+
+func aHeapWithTheValues(values string) error {
+	s, err := parseValues(values)
+	if err != nil {
+		return err
+	}
+	t.heap = &boundedHeap[int]{
+		values: s,
+		less: func(a, b int) bool {
+			return a < b
+		},
+	}
+	return nil
+}
+
+func fromHeapIsCalled() {
+	t.resultingIntIterator = FromHeap[int](t.heap)
+}
+
+func toHeapIsCalled() {
+	t.err = ToHeap(t.resultingIntIterator, t.heap)
+}
+
+func theHeapContainsValues(expected int) error {
+	if t.heap.Len() != expected {
+		return fmt.Errorf("expected %d values, got %d", expected, t.heap.Len())
+	}
+	return nil
+}
+
+func unfoldIsCalledWithTheSeedAndAClosureThatDoublesTheStateBelow(seed, limit int) {
+	t.resultingIntIterator = Unfold(seed, func(state int) (int, int, bool) {
+		t.calls++
+		return state, state * 2, state < limit
+	})
+}
+
+func anAPIWithThePages(pages string) (err error) {
+	t.pages, err = parseBatches(pages)
+	t.failingPage = -1
+	return err
+}
+
+func anAPIWithThePagesThatFailsAtPage(pages string, page int) error {
+	if err := anAPIWithThePages(pages); err != nil {
+		return err
+	}
+	t.failingPage = page - 1
+	return nil
+}
+
+func fromPagesIsCalled() {
+	t.resultingIntIterator = FromPages(0, func(cursor int) ([]int, int, bool, error) {
+		t.cursors = append(t.cursors, strconv.Itoa(cursor))
+		if cursor == t.failingPage {
+			return nil, 0, false, errors.New("service unavailable")
+		}
+		return t.pages[cursor], cursor + 1, cursor == len(t.pages)-1, nil
+	})
+}
+
+func thePagesWereFetchedWithTheCursors(expected string) error {
+	if got := strings.Join(t.cursors, ","); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
+	}
+	return nil
+}
+
+func parseBatches(batches string) ([][]int, error) {
+	var result [][]int
+	for _, batch := range strings.Split(batches, ";") {
+		var values []int
+		if batch != "" {
+			var err error
+			if values, err = parseValues(batch); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
+
+func anIterableWithTheBatches(batches string) error {
+	b, err := parseBatches(batches)
+	t.batches = FromSlice(b)
+	return err
+}
+
+func anIterableWithTheBatchesThatReusesItsBuffer(batches string) error {
+	b, err := parseBatches(batches)
+	buffer := make([]int, 0, 8)
+	idx := 0
+	t.batches = FromFunc(func() ([]int, bool) {
+		if idx >= len(b) {
+			return nil, false
+		}
+		buffer = append(buffer[:0], b[idx]...)
+		idx++
+		return buffer, true
+	})
+	return err
+}
+
+func anIterableOfBatchesInAnErrorState() {
+	t.batches = &ErrorIterator[[]int]{}
+}
+
+func fromBatchesIsCalled() {
+	t.resultingIntIterator = FromBatches(t.batches)
+}
+
+func replayableIsCalled() {
+	src := t.resultingIntIterator
+	t.replayIterator = Replayable[int](FromFuncErr(func() (int, bool, error) {
+		v, ok := src.Next()
+		if ok {
+			t.pulled++
+		}
+		return v, ok, src.Error()
+	}))
+	t.sourceIterator = src
+	t.resultingIntIterator = t.replayIterator
+}
+
+func takeIsCalledOnTheReplayableIteratorWith(n int) error {
+	values, err := ToSlice[int](Take[int](t.replayIterator, n))
+	if len(values) != n {
+		return fmt.Errorf("expected %d values, got %v", n, values)
+	}
+	return err
+}
+
+func theReplayableIteratorIsRestarted() {
+	t.replayIterator.Restart()
+}
+
+func theSourceIterableWasConsumedOnce() error {
+	values, err := ToSlice(t.sourceIterator)
+	if err != nil {
+		return err
+	}
+	if len(values) != 0 || t.pulled != 3 {
+		return fmt.Errorf("expected 3 pulled values and no remaining values, got %d and %v", t.pulled, values)
+	}
+	return nil
+}
+
+func identity(v int) int {
+	return v
+}
+
+func toMapMergeIsCalledWithTheLastDigitAsKeyAndTheSumAsMerge() {
+	t.intMap, t.err = ToMapMerge(t.resultingIntIterator, lastDigit, identity, func(old, new int) int {
+		return old + new
+	})
+}
+
+func toMapMergeIsCalledWithTheLastDigitAsKeyKeepingTheFirstValue() {
+	t.intMap, t.err = ToMapMerge(t.resultingIntIterator, lastDigit, identity, func(old, _ int) int {
+		return old
+	})
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func anIterableWithTheValuesThatFailsAfterwards(values string) error {
+	s, err := parseValues(values)
+	if err != nil {
+		return err
+	}
+	src := FromSlice(s)
+	t.resultingIntIterator = FromFuncErr(func() (int, bool, error) {
+		if v, ok := src.Next(); ok {
+			return v, true, nil
+		}
+		return 0, false, errors.New("connection lost")
+	})
+	return nil
+}
+
+func toJSONArrayIsCalled() {
+	t.err = ToJSONArray(t.resultingIntIterator, &t.output)
+}
+
+func toJSONArrayIsCalledWithAFailingWriter() {
+	t.err = ToJSONArray(t.resultingIntIterator, failingWriter{})
+}
+
+func toJSONLinesIsCalled() {
+	t.err = ToJSONLines(t.resultingIntIterator, &t.output)
+}
+
+func fromJSONLinesIsCalledOnTheWrittenOutput() {
+	t.resultingIntIterator = FromJSONLines[int](&t.output)
+}
+
+func theWrittenOutputIs(expected string) error {
+	expected = unescapeLineEndings(expected)
+	if t.output.String() != expected {
+		return fmt.Errorf("expected: %q got: %q", expected, t.output.String())
+	}
+	return nil
+}
+
+func channelIsCalledWithABufferOf(buffer int) {
+	t.receiveChannel = Channel(t.resultingIntIterator, buffer)
+}
+
+func channelCtxIsCalledWithABufferOf(buffer int) {
+	t.receiveChannel = ChannelCtx(t.ctx, t.resultingIntIterator, buffer)
+}
+
+func anInfiniteIterable() {
+	t.resultingIntIterator = SequenceFrom(1)
+}
+
+func theChannelReceivesAndIsClosed(values string) error {
+	var received []string
+	for v := range t.receiveChannel {
+		received = append(received, strconv.Itoa(v))
+	}
+	if got := strings.Join(received, ","); got != values {
+		return fmt.Errorf("expected: %v got: %v", values, got)
+	}
+	return nil
+}
+
+func theIterableReportsNoError() error {
+	return t.resultingIntIterator.Error()
+}
+
+func theIterableReportsAnError() error {
+	if t.resultingIntIterator.Error() == nil {
+		return errors.New("expected an error")
+	}
+	return nil
+}
+
+func valuesAreReceivedAndTheContextIsCancelled(n int) error {
+	for i := 0; i < n; i++ {
+		if _, ok := <-t.receiveChannel; !ok {
+			return fmt.Errorf("expected value %d", i+1)
+		}
+	}
+	t.cancel()
+	return nil
+}
+
+func theReceivedChannelIsClosedByTheGoroutine() error {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-t.receiveChannel:
+			if !ok {
+				return nil
+			}
+		case <-timeout:
+			return errors.New("expected the channel to be closed")
+		}
+	}
+}
+
+func aBufferWithTheValuesAndACapacityOf(values string, capacity int) error {
+	s, err := parseValues(values)
 	if err != nil {
 		return err
 	}
-	t.resultingIntIterator = FromSlice(s)
+	t.buffer = append(make([]int, 0, capacity), s...)
 	return nil
 }
 
-func filterIsCalled() {
-	t.resultingIntIterator = Filter(t.resultingIntIterator, t.predicate)
-}
-
-func aMapFunctionThatMultiplesTheValuesAndConvertsTheIntToAStringPrefixedWithTest() {
-	t.mapper = func(i int) string {
-		return "test" + strconv.Itoa(i*2)
-	}
+func appendSliceIsCalled() {
+	t.slice, t.err = AppendSlice(t.resultingIntIterator, t.buffer)
 }
 
-func mapIsCalled() {
-	t.resultingStringIterator = Map(t.resultingIntIterator, t.mapper)
+func appendSliceIsCalledWithTheBufferResetToZeroLength() {
+	t.slice, t.err = AppendSlice(t.resultingIntIterator, t.buffer[:0])
 }
 
-func aSliceIsReturnedWithTheFollowingValues(listofints *godog.Table) error {
-	s, err := toSliceOfInts(listofints)
-	if err != nil {
-		return err
+func theResultingSliceIs(expected string) error {
+	s := make([]string, len(t.slice))
+	for i, v := range t.slice {
+		s[i] = strconv.Itoa(v)
 	}
-	if !reflect.DeepEqual(s, t.resultingSlice) {
-		return fmt.Errorf("expected: %v got: %v", s, t.resultingSlice)
+	if got := strings.Join(s, ","); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
 	}
 	return nil
 }
 
-func toSliceIsCalled() (err error) {
-	t.resultingSlice, err = ToSlice(t.resultingIntIterator)
-	return
+func theResultingSliceReusesTheBuffer() error {
+	if &t.slice[0] != &t.buffer[:1][0] {
+		return errors.New("expected the buffer to be reused")
+	}
+	return nil
 }
 
-func aReduceFunctionThatSumsAllValues() {
-	t.reducer = func(a, b int) int {
-		return a + b
+func theResultingSliceHasACapacityOf(expected int) error {
+	if cap(t.slice) != expected {
+		return fmt.Errorf("expected a capacity of %d, got %d", expected, cap(t.slice))
 	}
+	return nil
 }
 
-func initialValueOf(init int) {
-	t.initialReduceValue = init
+func toSortedSliceIsCalled() {
+	t.slice, t.err = ToSortedSlice(t.resultingIntIterator)
 }
 
-func reduceIsCalled() (err error) {
-	t.sum, err = Reduce(t.resultingIntIterator, t.initialReduceValue, t.reducer)
-	return
+func toSortedSliceFuncIsCalledWithTheLastDigitAsOrder() {
+	t.slice, t.err = ToSortedSliceFunc(t.resultingIntIterator, func(a, b int) bool {
+		return lastDigit(a) < lastDigit(b)
+	})
 }
 
-func theReturnedSumIs(expected int) error {
-	if t.sum != expected {
-		return fmt.Errorf("expected: %v got: %v", expected, t.sum)
+// batchingSink is a Sink that writes the accepted values in batches of size values.
+type batchingSink struct {
+	size      int
+	reject    int
+	failFlush bool
+	buffered  []string
+	written   []string
+}
+
+func (s *batchingSink) Accept(v int) error {
+	if v == s.reject {
+		return fmt.Errorf("value %d is rejected", v)
+	}
+	s.buffered = append(s.buffered, strconv.Itoa(v))
+	if len(s.buffered) == s.size {
+		return s.Flush()
 	}
 	return nil
 }
 
-func aForeachFunctionThatSumsAndCountsTheCalls() {
-	t.counter = func(i int) {
-		t.count++
-		t.sum += i
+func (s *batchingSink) Flush() error {
+	if s.failFlush {
+		return errors.New("connection lost")
+	}
+	if len(s.buffered) > 0 {
+		s.written = append(s.written, strings.Join(s.buffered, ","))
+		s.buffered = nil
 	}
+	return nil
 }
 
-func foreachIsCalled() error {
-	return ForEach(t.resultingIntIterator, t.counter)
+func aSinkThatFlushesEveryValues(size int) {
+	t.sink = &batchingSink{size: size, reject: -1}
 }
 
-func theReturnedCountIs(expected int) error {
-	if t.count != expected {
-		return fmt.Errorf("expected: %v got: %v", expected, t.sum)
-	}
-	return nil
+func aSinkThatFlushesEveryValuesAndRejectsTheValue(size, reject int) {
+	t.sink = &batchingSink{size: size, reject: reject}
 }
 
-func callingNextUntilFalseIsReturnedShouldReturnTheFollowingStrings(listofints *godog.Table) error {
-	expected := toSliceOfStrings(listofints)
-
-	var results []string
+func aSinkThatFlushesEveryValuesAndFailsToFlush(size int) {
+	t.sink = &batchingSink{size: size, reject: -1, failFlush: true}
+}
 
-	for v, b := t.resultingStringIterator.Next(); b; v, b = t.resultingStringIterator.Next() {
-		results = append(results, v)
-	}
+func drainIsCalled() {
+	t.err = Drain[int](t.resultingIntIterator, t.sink)
+}
 
-	if !reflect.DeepEqual(expected, results) {
-		return fmt.Errorf("expected: %v got: %v", expected, results)
+func theSinkWroteTheBatches(expected string) error {
+	if got := strings.Join(t.sink.written, ";"); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
 	}
-
 	return nil
 }
 
-func callingNextUntilFalseIsReturnedShouldReturnTheFollowingIntegers(listofints *godog.Table) error {
-	expected, err := toSliceOfInts(listofints)
+func mapErrIsCalledWithStrconvAtoi() {
+	t.resultingIntIterator = MapErr(t.resultingStringIterator, strconv.Atoi)
+}
 
+func theRemainingStringsAre(expected string) error {
+	remaining, err := ToSlice(t.resultingStringIterator)
 	if err != nil {
 		return err
 	}
+	if got := strings.Join(remaining, ","); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
+	}
+	return nil
+}
 
-	var results []int
+func filterErrIsCalledWithAPredicateThatSelectsEvenNumbersAndFailsOn(fail int) {
+	t.sourceIterator = t.resultingIntIterator
+	t.resultingIntIterator = FilterErr(t.resultingIntIterator, func(v int) (bool, error) {
+		if v == fail {
+			return false, fmt.Errorf("unexpected value %d", v)
+		}
+		return v%2 == 0, nil
+	})
+}
 
-	for v, b := t.resultingIntIterator.Next(); b; v, b = t.resultingIntIterator.Next() {
-		results = append(results, v)
-	}
+func forEachErrIsCalledWithAClosureThatFailsOn(fail int) {
+	t.sourceIterator = t.resultingIntIterator
+	t.err = ForEachErr(t.resultingIntIterator, func(v int) error {
+		if v == fail {
+			return fmt.Errorf("unexpected value %d", v)
+		}
+		t.received = append(t.received, strconv.Itoa(v))
+		return nil
+	})
+}
 
-	if !reflect.DeepEqual(expected, results) {
-		return fmt.Errorf("expected: %v got: %v", expected, results)
+func theClosureReceived(expected string) error {
+	if got := strings.Join(t.received, ","); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
 	}
-
 	return nil
 }
 
-func aGeneratorFuncThatReturnsTheCountAndRepeatConcatenatedWithAComma() {
-	t.generator = func(p string, c, r uint64) string {
-		return fmt.Sprintf("%d,%d", c, r)
+func anErrorIsReturnedThatIsNotAnIndexError() error {
+	var ie *IndexError
+	if t.err == nil || errors.As(t.err, &ie) {
+		return fmt.Errorf("expected an error that is not an IndexError, got: %v", t.err)
 	}
+	return nil
 }
 
-func aRepeatValueOf(r int) {
-	t.repeat = uint64(r)
+func tryForEachIsCalledWithAClosureThatStopsAtAndFailsOn(stop, fail int) {
+	t.sourceIterator = t.resultingIntIterator
+	t.err = TryForEach(t.resultingIntIterator, func(i int, v int) error {
+		switch v {
+		case stop:
+			return fmt.Errorf("value %d: %w", v, ErrStop)
+		case fail:
+			return fmt.Errorf("unexpected value %d", v)
+		}
+		t.received = append(t.received, fmt.Sprintf("%d:%d", i, v))
+		return nil
+	})
 }
 
-func generateIsCalled() {
-	t.resultingStringIterator = Generate("", t.repeat, t.generator)
+func mapIsCalledWithAFunctionThatDoublesTheValues() {
+	t.resultingIntIterator = Map(t.resultingIntIterator, func(v int) int {
+		return v * 2
+	})
 }
 
-func aStartValueOf(s int) {
-	t.start = s
+func filterIsCalledWithAPredicateThatSelectsValuesBelow(limit int) {
+	t.resultingIntIterator = Filter(t.resultingIntIterator, func(v int) bool {
+		return v < limit
+	})
 }
 
-func anEndValueOf(e int) {
-	t.end = e
+func errorOfIntIteratorReturnsTheMessage(expected string) error {
+	err := t.resultingIntIterator.Error()
+	if err == nil || err.Error() != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, err)
+	}
+	return nil
 }
 
-func anStepValueOf(s int) {
-	t.step = s
+func theUnwrappedErrorOfTheIntIteratorIs(expected string) error {
+	err := errors.Unwrap(t.resultingIntIterator.Error())
+	if err == nil || err.Error() != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, err)
+	}
+	return nil
 }
 
-func stepSequenceIsCalled() {
-	t.resultingIntIterator = StepSequence(t.start, t.end, t.step)
+func errorOfIntIteratorReturnsAStageErrorForTheStageAndElement(stage string, index int) error {
+	var se *StageError
+	if err := t.resultingIntIterator.Error(); !errors.As(err, &se) || se.Stage != stage || se.Index != index {
+		return fmt.Errorf("expected a StageError for the %s stage and element %d, got: %v", stage, index, err)
+	}
+	return nil
 }
 
-func sequenceIsCalled() {
-	t.resultingIntIterator = Sequence(t.start, t.end)
+func onErrorIsCalledWithAHandlerThatResumes() {
+	t.resultingIntIterator = OnError(t.resultingIntIterator, recordError(true))
 }
 
-func valuesStringToIntSlice(in string) (result []int, err error) {
-	for _, s := range strings.Split(in, ",") {
-		i, err2 := strconv.Atoi(s)
-		if err2 != nil {
-			err = err2
-			return
+func onErrorIsCalledWithAHandlerThatStops() {
+	t.resultingIntIterator = OnError(t.resultingIntIterator, recordError(false))
+}
+
+// recordError returns an ErrorHandlerFunc that counts the calls, records the element index of the innermost
+// StageError and returns resume.
+func recordError(resume bool) ErrorHandlerFunc {
+	return func(err error) bool {
+		t.calls++
+		index := -1
+		var se *StageError
+		for errors.As(err, &se) {
+			index = se.Index
+			err = se.Err
 		}
-		result = append(result, i)
+		if index >= 0 {
+			t.received = append(t.received, strconv.Itoa(index))
+		}
+		return resume
 	}
-	return
 }
 
-func callingNextUntilFalseIsReturnedShouldReturnTheFollowingValues(values string) error {
-	expected, err := valuesStringToIntSlice(values)
-	if err != nil {
-		return err
+func theHandlerReceivedErrorsForTheElements(expected string) error {
+	if got := strings.Join(t.received, ","); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
 	}
-	results, err := ToSlice(t.resultingIntIterator)
-	if err != nil {
-		return err
+	return nil
+}
+
+func mapIsCalledWithAFunctionThatPanicsOn(value int) {
+	t.resultingIntIterator = Map(t.resultingIntIterator, func(v int) int {
+		if v == value {
+			panic(fmt.Errorf("bad value %d", v))
+		}
+		return v
+	})
+}
+
+func safeIsCalled() {
+	t.resultingIntIterator = Safe(t.resultingIntIterator)
+}
+
+func theStackTraceOfThePanicErrorContains(expected string) error {
+	var pe *PanicError
+	if err := t.resultingIntIterator.Error(); !errors.As(err, &pe) {
+		return fmt.Errorf("expected a PanicError, got: %v", err)
 	}
-	if !reflect.DeepEqual(expected, results) {
-		return fmt.Errorf("expected: %v got: %v", expected, results)
+	if !strings.Contains(string(pe.Stack), expected) {
+		return fmt.Errorf("expected the stack trace to contain %v, got: %s", expected, pe.Stack)
 	}
 	return nil
 }
 
-type ErrorIterator[T any] struct{}
+// yamlManifest is a document of the YAML streams in yamldocs.feature.
+type yamlManifest struct {
+	Kind string `yaml:"kind"`
+	Name string `yaml:"name"`
+}
 
-func (e *ErrorIterator[T]) Next() (T, bool) {
-	var t T
-	return t, false
+func fromYAMLDocsIsCalled() {
+	docs := FromYAMLDocs[yamlManifest](strings.NewReader(unescapeLineEndings(t.stream)))
+	t.resultingStringIterator = Map[yamlManifest](docs, func(m yamlManifest) string {
+		return m.Kind + "/" + m.Name
+	})
 }
 
-func (e *ErrorIterator[T]) Error() error {
-	return errors.New("iterator not implemented")
+func nextCtxOfTheIntIteratorIsInterruptedByTheContext() error {
+	defer t.cancel()
+	if v, ok := t.resultingIntIterator.(CtxIterable[int]).NextCtx(t.ctx); ok {
+		return fmt.Errorf("expected NextCtx to be interrupted, got %v", v)
+	}
+	return nil
 }
 
-func anIterableInAnErrorState() {
-	t.resultingIntIterator = &ErrorIterator[int]{}
+func theValueIsSentOnTheChannelInTheBackground(v int) {
+	c := t.channel
+	go func() {
+		c <- v
+	}()
 }
 
-func errorOfStringIteratorReturnsAnError() error {
-	if t.resultingStringIterator.Error() == nil {
-		return errors.New("expected an error but got nil")
+func nextCtxOfTheTickIteratorIsCalledWithTheContext() error {
+	if v, ok := t.tickIterator.NextCtx(t.ctx); ok {
+		return fmt.Errorf("expected no tick, got %v", v)
 	}
 	return nil
 }
 
-func errorOfStringIteratorReturnsNil() error {
-	if t.resultingStringIterator.Error() != nil {
-		return errors.New("expected nil but got an error")
+func mapErrIsCalledWithAFunctionThatDoublesTheValues() {
+	t.resultingIntIterator = MapErr(t.resultingIntIterator, func(v int) (int, error) {
+		return v * 2, nil
+	})
+}
+
+func aTableThatBlocksWhileFetchingAPage() {
+	release := make(chan struct{})
+	t.release = release
+	t.fetch = func(after int, limit int) ([]int, int, bool, error) {
+		<-release
+		return nil, after, false, nil
+	}
+}
+
+func theBlockedFetchIsReleased() {
+	close(t.release)
+}
+
+func anHTTPResponseWithStatusAndABodyThatBlocksAfter(status int, body string) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = io.WriteString(pw, unescapeLineEndings(body))
+	}()
+	anHTTPResponseWithStatusAndBody(status, "")
+	t.body.Reader = pr
+}
+
+func withContextIsCalledOnTheStringIterator() {
+	t.resultingStringIterator = WithContext(t.ctx, t.resultingStringIterator)
+}
+
+func nextOfIntIteratorIsCalledAgain() error {
+	if v, b := t.resultingIntIterator.Next(); b {
+		return fmt.Errorf("expected false, got %v", v)
 	}
 	return nil
 }
 
-func errorOfIntIteratorReturnsAnError() error {
-	if t.resultingIntIterator.Error() == nil {
-		return errors.New("expected an error but got nil")
+func movingAverageIsCalledWithAWindowOfOnTheIntIterator(window int) {
+	t.resultingFloatIterator = MovingAverage(t.resultingIntIterator, window)
+}
+
+func nextOfFloatIteratorReturnsTrueTimesAndThenReturnsFalse(n int) error {
+	for i := 0; i < n; i++ {
+		if _, b := t.resultingFloatIterator.Next(); !b {
+			return fmt.Errorf("expected true at call %d, got false", i+1)
+		}
+	}
+	if v, b := t.resultingFloatIterator.Next(); b {
+		return fmt.Errorf("expected false, got %v", v)
 	}
 	return nil
 }
 
-func errorOfIntIteratorReturnsNil() error {
-	if t.resultingIntIterator.Error() != nil {
-		return errors.New("expected nil but got an error")
+func errorOfFloatIteratorReturns(expected string) error {
+	err := t.resultingFloatIterator.Error()
+	if err == nil || err.Error() != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, err)
 	}
 	return nil
 }
 
-func aClosedChannelWithTheFollowingValues(listofints *godog.Table) {
-	t.channel = make(chan int)
-	go func() {
-		values, err := toSliceOfInts(listofints)
-		if err != nil {
-			panic(err)
-		}
-		for _, v := range values {
-			t.channel <- v
-		}
-		close(t.channel)
-	}()
+// strictIterator is an Iterable over a slice that panics when Next is called after it returned false, to check that
+// a function does not rely on the behaviour of an exhausted Iterable.
+type strictIterator struct {
+	values []int
+	done   bool
 }
 
-func fromChannelIsCalled() {
-	t.resultingIntIterator = FromChannel(t.channel)
+func (si *strictIterator) Next() (int, bool) {
+	if si.done {
+		panic("Next called after it returned false")
+	}
+	if len(si.values) == 0 {
+		si.done = true
+		return 0, false
+	}
+	v := si.values[0]
+	si.values = si.values[1:]
+	return v, true
 }
 
-func theChannelIsClosed() {
-	close(t.channel)
+func (si *strictIterator) Error() error {
+	return nil
 }
 
-func theFollowingValuesAreReceivedOnTheChannel(listofints *godog.Table) error {
-	var results []int
-	expected, err := toSliceOfInts(listofints)
+func anIterableWithTheValuesThatPanicsWhenNextIsCalledAfterItReturnedFalse(values string) error {
+	var s []int
+	if values != "" {
+		var err error
+		if s, err = parseValues(values); err != nil {
+			return err
+		}
+	}
+	t.resultingIntIterator = &strictIterator{values: s}
+	return nil
+}
+
+func splitStringIsCalledOnTheEscapedStringWithAnEmptySeparator(escaped string) error {
+	text, err := strconv.Unquote(`"` + escaped + `"`)
 	if err != nil {
 		return err
 	}
-	for v := range t.channel {
-		results = append(results, v)
+	t.text = text
+	t.resultingStringIterator = SplitString(text, "")
+	return nil
+}
+
+func theSubstringsFormattedWithQAre(expected string) error {
+	substrings, err := ToSlice(t.resultingStringIterator)
+	if err != nil {
+		return err
 	}
-	if !reflect.DeepEqual(expected, results) {
-		return fmt.Errorf("expected: %v got: %v", expected, results)
+	if got := fmt.Sprintf("%q", substrings); got != expected {
+		return fmt.Errorf("expected: %v got: %v", expected, got)
 	}
 	return nil
 }
 
-func toChannelIsCalled() {
-	go func() {
-		defer close(t.channel)
-		err := ToChannel(t.resultingIntIterator, t.channel)
-		if err != nil {
-			panic(err)
-		}
-	}()
+// cancellingReader is an io.Reader that cancels the context of the fixture before it reads.
+type cancellingReader struct {
+	io.Reader
+	cancel context.CancelFunc
 }
 
-func aChannel() {
-	t.channel = make(chan int)
+func (r cancellingReader) Read(p []byte) (int, error) {
+	r.cancel()
+	return r.Reader.Read(p)
+}
+
+func anHTTPResponseWithStatusAndABodyThatCancelsTheContextWhenItIsRead(status int, body string) {
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+	anHTTPResponseWithStatusAndBody(status, body)
+	t.body.Reader = cancellingReader{Reader: t.body.Reader, cancel: t.cancel}
 }
 
 func InitializeScenario(ctx *godog.ScenarioContext) {
@@ -748,6 +5940,366 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the following values are received on the channel$`, theFollowingValuesAreReceivedOnTheChannel)
 	ctx.Step(`^ToChannel is called$`, toChannelIsCalled)
 	ctx.Step(`^a channel$`, aChannel)
+	ctx.Step(`^a predicate that only selects zero$`, aPredicateThatOnlySelectsZero)
+	ctx.Step(`^Split is called$`, splitIsCalled)
+	ctx.Step(`^calling Next\(\) until false is returned should return the following groups:$`, callingNextUntilFalseIsReturnedShouldReturnTheFollowingGroups)
+	ctx.Step(`^Next\(\) of group iterator returns false$`, nextOfGroupIteratorReturnsFalse)
+	ctx.Step(`^Error\(\) of group iterator returns an error$`, errorOfGroupIteratorReturnsAnError)
+	ctx.Step(`^Error\(\) of group iterator returns nil$`, errorOfGroupIteratorReturnsNil)
+	ctx.Step(`^ToSliceMax is called with a maximum of (\d+)$`, toSliceMaxIsCalledWithAMaximumOf)
+	ctx.Step(`^no error is returned$`, noErrorIsReturned)
+	ctx.Step(`^an error is returned$`, anErrorIsReturned)
+	ctx.Step(`^ErrTooMany is returned$`, errTooManyIsReturned)
+	ctx.Step(`^TopK is called with k of (\d+)$`, topKIsCalledWithKOf)
+	ctx.Step(`^BottomK is called with k of (\d+)$`, bottomKIsCalledWithKOf)
+	ctx.Step(`^a second Iterable with the following values:$`, aSecondIterableWithTheFollowingValues)
+	ctx.Step(`^a second Iterable in an error state$`, aSecondIterableInAnErrorState)
+	ctx.Step(`^DiffSorted is called$`, diffSortedIsCalled)
+	ctx.Step(`^calling Next\(\) until false is returned should return the following changes:$`, callingNextUntilFalseIsReturnedShouldReturnTheFollowingChanges)
+	ctx.Step(`^Next\(\) returns no change and Error\(\) of change iterator returns an error$`, nextReturnsNoChangeAndErrorOfChangeIteratorReturnsAnError)
+	ctx.Step(`^Tag is called with the index as metadata$`, tagIsCalledWithTheIndexAsMetadata)
+	ctx.Step(`^MapTagged is called with a map function that doubles the values$`, mapTaggedIsCalledWithAMapFunctionThatDoublesTheValues)
+	ctx.Step(`^Untag is called$`, untagIsCalled)
+	ctx.Step(`^calling Next\(\) until false is returned should return the following tagged values:$`, callingNextUntilFalseIsReturnedShouldReturnTheFollowingTaggedValues)
+	ctx.Step(`^SampleReservoir is called with k of (\d+)$`, sampleReservoirIsCalledWithKOf)
+	ctx.Step(`^the returned slice contains (\d+) distinct values between (-?\d+) and (-?\d+)$`, theReturnedSliceContainsDistinctValuesBetweenAnd)
+	ctx.Step(`^PartitionConsistent is called with (\d+) partitions$`, partitionConsistentIsCalledWithPartitions)
+	ctx.Step(`^every value is assigned to the same partition as equal values, within (\d+) partitions$`, everyValueIsAssignedToTheSamePartitionAsEqualValuesWithinPartitions)
+	ctx.Step(`^Error\(\) of partitioned iterator returns an error$`, errorOfPartitionedIteratorReturnsAnError)
+	ctx.Step(`^FanOutConsistent is called with (\d+) channels$`, fanOutConsistentIsCalledWithChannels)
+	ctx.Step(`^FanOutConsistent is called without channels$`, fanOutConsistentIsCalledWithoutChannels)
+	ctx.Step(`^ErrNoChannels is returned$`, errNoChannelsIsReturned)
+	ctx.Step(`^(\d+) values are received on the channels and equal values are received on the same channel$`, valuesAreReceivedOnTheChannelsAndEqualValuesAreReceivedOnTheSameChannel)
+	ctx.Step(`^Sample is called with a probability of (\d+(?:\.\d+)?)$`, sampleIsCalledWithAProbabilityOf)
+	ctx.Step(`^MovingAggregate is called with a sum over a window of (\d+)$`, movingAggregateIsCalledWithASumOverAWindowOf)
+	ctx.Step(`^Take is called with (\d+)$`, takeIsCalledWith)
+	ctx.Step(`^IDs is called with a monotonic generator with prefix "([^"]*)"$`, iDsIsCalledWithAMonotonicGeneratorWithPrefix)
+	ctx.Step(`^IDs is called with a ULID generator$`, iDsIsCalledWithAULIDGenerator)
+	ctx.Step(`^IDs is called with a ULID generator whose random bits are all set$`, iDsIsCalledWithAULIDGeneratorWhoseRandomBitsAreAllSet)
+	ctx.Step(`^the identifiers stop with ErrULIDOverflow$`, theIdentifiersStopWithErrULIDOverflow)
+	ctx.Step(`^Take is called on the identifiers with (\d+)$`, takeIsCalledOnTheIdentifiersWith)
+	ctx.Step(`^the identifiers are unique, sorted and (\d+) characters long$`, theIdentifiersAreUniqueSortedAndCharactersLong)
+	ctx.Step(`^Delta is called$`, deltaIsCalled)
+	ctx.Step(`^Rank is called$`, rankIsCalled)
+	ctx.Step(`^DenseRank is called$`, denseRankIsCalled)
+	ctx.Step(`^calling Next\(\) until false is returned should return the following ranked values:$`, callingNextUntilFalseIsReturnedShouldReturnTheFollowingRankedValues)
+	ctx.Step(`^Next\(\) returns no ranked value and Error\(\) of ranked iterator returns an error$`, nextReturnsNoRankedValueAndErrorOfRankedIteratorReturnsAnError)
+	ctx.Step(`^NormalizeMinMax is called$`, normalizeMinMaxIsCalled)
+	ctx.Step(`^calling Next\(\) until false is returned should return the following floats:$`, callingNextUntilFalseIsReturnedShouldReturnTheFollowingFloats)
+	ctx.Step(`^an active context$`, anActiveContext)
+	ctx.Step(`^a cancelled context$`, aCancelledContext)
+	ctx.Step(`^a context that is cancelled after (\d+) milliseconds$`, aContextThatIsCancelledAfterMilliseconds)
+	ctx.Step(`^WithContext is called$`, withContextIsCalled)
+	ctx.Step(`^ForEachCtx is called$`, forEachCtxIsCalled)
+	ctx.Step(`^PadTo is called with length (\d+) and fill value (-?\d+)$`, padToIsCalledWithLengthAndFillValue)
+	ctx.Step(`^PadOrTruncate is called with length (\d+) and fill value (-?\d+)$`, padOrTruncateIsCalledWithLengthAndFillValue)
+	ctx.Step(`^a table with the following values:$`, aTableWithTheFollowingValues)
+	ctx.Step(`^a table that fails to fetch a page$`, aTableThatFailsToFetchAPage)
+	ctx.Step(`^FromKeysetPages is called with a limit of (\d+)$`, fromKeysetPagesIsCalledWithALimitOf)
+	ctx.Step(`^FromKeysetPagesPrefetch is called with a limit of (\d+)$`, fromKeysetPagesPrefetchIsCalledWithALimitOf)
+	ctx.Step(`^(\d+) pages have been fetched$`, pagesHaveBeenFetched)
+	ctx.Step(`^Throttle is called with an interval of (\d+) milliseconds$`, throttleIsCalledWithAnIntervalOfMilliseconds)
+	ctx.Step(`^the iteration took at least (\d+) milliseconds$`, theIterationTookAtLeastMilliseconds)
+	ctx.Step(`^the iteration took less than (\d+) milliseconds$`, theIterationTookLessThanMilliseconds)
+	ctx.Step(`^a channel that receives the following bursts with a pause of (\d+) milliseconds between them:$`, aChannelThatReceivesTheFollowingBurstsWithAPauseOfMillisecondsBetweenThem)
+	ctx.Step(`^Debounce is called with a quiet period of (\d+) milliseconds$`, debounceIsCalledWithAQuietPeriodOfMilliseconds)
+	ctx.Step(`^WithRemaining is called$`, withRemainingIsCalled)
+	ctx.Step(`^calling Next\(\) until false is returned should return the following counted values:$`, callingNextUntilFalseIsReturnedShouldReturnTheFollowingCountedValues)
+	ctx.Step(`^DistinctWithLimit is called with a limit of (\d+)$`, distinctWithLimitIsCalledWithALimitOf)
+	ctx.Step(`^(\d+) values have been evicted$`, valuesHaveBeenEvicted)
+	ctx.Step(`^ParallelMapKeyed is called keyed by odd and even with (\d+) per key and (\d+) in total$`, parallelMapKeyedIsCalledKeyedByOddAndEvenWithPerKeyAndInTotal)
+	ctx.Step(`^the following integers are returned in any order:$`, theFollowingIntegersAreReturnedInAnyOrder)
+	ctx.Step(`^at most (\d+) operations per key and (\d+) operations in total ran concurrently$`, atMostOperationsPerKeyAndOperationsInTotalRanConcurrently)
+	ctx.Step(`^RoundRobin is called$`, roundRobinIsCalled)
+	ctx.Step(`^a registry with a sequence source, a filter stage and a sum sink$`, aRegistryWithASequenceSourceAFilterStageAndASumSink)
+	ctx.Step(`^the sequence source is registered again$`, theSequenceSourceIsRegisteredAgain)
+	ctx.Step(`^the pipeline "([^"]*)" is run$`, thePipelineIsRun)
+	ctx.Step(`^ErrUnknownName is returned$`, errUnknownNameIsReturned)
+	ctx.Step(`^ErrDuplicateName is returned$`, errDuplicateNameIsReturned)
+	ctx.Step(`^an empty Iterable$`, anEmptyIterable)
+	ctx.Step(`^Sum is called$`, sumIsCalled)
+	ctx.Step(`^Product is called$`, productIsCalled)
+	ctx.Step(`^SetFromIterator is called$`, setFromIteratorIsCalled)
+	ctx.Step(`^the set contains (\d+) values$`, theSetContainsValues)
+	ctx.Step(`^the set contains (\d+)$`, theSetContains)
+	ctx.Step(`^the set does not contain (\d+)$`, theSetDoesNotContain)
+	ctx.Step(`^both Iterables are collected into Sets and combined with (\w+)$`, bothIterablesAreCollectedIntoSetsAndCombinedWith)
+	ctx.Step(`^Iter is called on the Set$`, iterIsCalledOnTheSet)
+	ctx.Step(`^Min is called$`, minIsCalled)
+	ctx.Step(`^Max is called$`, maxIsCalled)
+	ctx.Step(`^the value (-?\d+) is found$`, theValueIsFound)
+	ctx.Step(`^no value is found$`, noValueIsFound)
+	ctx.Step(`^the filter Stage and a map Stage that formats the values are chained with Chain2$`, theFilterStageAndAMapStageThatFormatsTheValuesAreChainedWithChain2)
+	ctx.Step(`^the filter Stage, a map Stage that doubles the values and a map Stage that formats the values are chained with Chain3$`, theFilterStageAMapStageThatDoublesTheValuesAndAMapStageThatFormatsTheValuesAreChainedWithChain3)
+	ctx.Step(`^MinBy is called with the last digit as key$`, minByIsCalledWithTheLastDigitAsKey)
+	ctx.Step(`^MaxBy is called with the last digit as key$`, maxByIsCalledWithTheLastDigitAsKey)
+	ctx.Step(`^Consume is called$`, consumeIsCalled)
+	ctx.Step(`^the Iterable is consumed with ToSlice$`, theIterableIsConsumedWithToSlice)
+	ctx.Step(`^ErrConsumed is returned$`, errConsumedIsReturned)
+	ctx.Step(`^Average is called$`, averageIsCalled)
+	ctx.Step(`^the returned average is (-?\d+(?:\.\d+)?)$`, theReturnedAverageIs)
+	ctx.Step(`^a stream with the documents "([^"]*)"$`, aStreamWithTheDocuments)
+	ctx.Step(`^FromDecoder is called$`, fromDecoderIsCalled)
+	ctx.Step(`^an HTTP response with status (\d+) and body "([^"]*)"$`, anHTTPResponseWithStatusAndBody)
+	ctx.Step(`^an HTTP response with status (\d+) and a body that fails after "([^"]*)"$`, anHTTPResponseWithStatusAndABodyThatFailsAfter)
+	ctx.Step(`^FromHTTPBodyLines is called$`, fromHTTPBodyLinesIsCalled)
+	ctx.Step(`^FromHTTPBodyChunks is called with a size of (\d+)$`, fromHTTPBodyChunksIsCalledWithASizeOf)
+	ctx.Step(`^Close is called on the body iterator$`, closeIsCalledOnTheBodyIterator)
+	ctx.Step(`^the body is closed$`, theBodyIsClosed)
+	ctx.Step(`^Next\(\) of string iterator returns true (\d+) times and then returns false$`, nextOfStringIteratorReturnsTrueTimesAndThenReturnsFalse)
+	ctx.Step(`^Stats is called$`, statsIsCalled)
+	ctx.Step(`^the following statistics are returned:$`, theFollowingStatisticsAreReturned)
+	ctx.Step(`^an Iterable with the values "([^"]*)"$`, anIterableWithTheValues)
+	ctx.Step(`^a predicate that only selects even numbers$`, aPredicateThatOnlySelectsEvenNumbers)
+	ctx.Step(`^Any is called$`, anyIsCalled)
+	ctx.Step(`^All is called$`, allIsCalled)
+	ctx.Step(`^None is called$`, noneIsCalled)
+	ctx.Step(`^the returned answer is (true|false)$`, theReturnedAnswerIs)
+	ctx.Step(`^DrainWithin is called with a deadline of (\d+) milliseconds and a closure that takes (\d+) milliseconds$`, drainWithinIsCalledWithADeadlineOfMillisecondsAndAClosureThatTakesMilliseconds)
+	ctx.Step(`^(\d+) values are processed$`, valuesAreProcessed)
+	ctx.Step(`^a deadline error with (\d+) remaining values is returned$`, aDeadlineErrorWithRemainingValuesIsReturned)
+	ctx.Step(`^Find is called$`, findIsCalled)
+	ctx.Step(`^FindIndex is called$`, findIndexIsCalled)
+	ctx.Step(`^the returned index is (-?\d+)$`, theReturnedIndexIs)
+	ctx.Step(`^NewChannelFrom is called with a buffer of (\d+)$`, newChannelFromIsCalledWithABufferOf)
+	ctx.Step(`^the following values are received from the value channel:$`, theFollowingValuesAreReceivedFromTheValueChannel)
+	ctx.Step(`^no values are received from the value channel$`, noValuesAreReceivedFromTheValueChannel)
+	ctx.Step(`^the error channel delivers no error$`, theErrorChannelDeliversNoError)
+	ctx.Step(`^the error channel delivers an error$`, theErrorChannelDeliversAnError)
+	ctx.Step(`^First is called$`, firstIsCalled)
+	ctx.Step(`^Last is called$`, lastIsCalled)
+	ctx.Step(`^Nth is called with (-?\d+)$`, nthIsCalledWith)
+	ctx.Step(`^the text template "([^"]*)"$`, theTextTemplate)
+	ctx.Step(`^ExecuteTemplatePerElement is called$`, executeTemplatePerElementIsCalled)
+	ctx.Step(`^the rendered output is "([^"]*)"$`, theRenderedOutputIs)
+	ctx.Step(`^Contains is called with (-?\d+)$`, containsIsCalledWith)
+	ctx.Step(`^IndexOf is called with (-?\d+)$`, indexOfIsCalledWith)
+	ctx.Step(`^ChunkByWeight is called with the value as weight and a maximum weight of (\d+)$`, chunkByWeightIsCalledWithTheValueAsWeightAndAMaximumWeightOf)
+	ctx.Step(`^ToMap is called with the value as key and the square as value$`, toMapIsCalledWithTheValueAsKeyAndTheSquareAsValue)
+	ctx.Step(`^ToMapKeyed is called with the last digit as key$`, toMapKeyedIsCalledWithTheLastDigitAsKey)
+	ctx.Step(`^the returned map is "([^"]*)"$`, theReturnedMapIs)
+	ctx.Step(`^a cancellable context$`, aCancellableContext)
+	ctx.Step(`^SequenceCtx is called with (-?\d+) and (-?\d+)$`, sequenceCtxIsCalledWithAnd)
+	ctx.Step(`^GenerateCtx is called with a repeat count of (\d+)$`, generateCtxIsCalledWithARepeatCountOf)
+	ctx.Step(`^(\d+) values are taken and the context is cancelled$`, valuesAreTakenAndTheContextIsCancelled)
+	ctx.Step(`^the error of the context is returned$`, theErrorOfTheContextIsReturned)
+	ctx.Step(`^ToSet is called$`, toSetIsCalled)
+	ctx.Step(`^a (\w+) iterator that returns (\d+) values$`, aIteratorThatReturnsValues)
+	ctx.Step(`^Next\(\) keeps returning false$`, nextKeepsReturningFalse)
+	ctx.Step(`^Fuse is called$`, fuseIsCalled)
+	ctx.Step(`^the state is (\w+)$`, theStateIs)
+	ctx.Step(`^NextErr returns (-?\d+)$`, nextErrReturns)
+	ctx.Step(`^NextErr returns ErrExhausted$`, nextErrReturnsErrExhausted)
+	ctx.Step(`^NextErr returns an error$`, nextErrReturnsAnError)
+	ctx.Step(`^an Iterable of strings "([^"]*)"$`, anIterableOfStrings)
+	ctx.Step(`^an Iterable of strings in an error state$`, anIterableOfStringsInAnErrorState)
+	ctx.Step(`^JoinString is called with the separator "([^"]*)"$`, joinStringIsCalledWithTheSeparator)
+	ctx.Step(`^the returned string is "([^"]*)"$`, theReturnedStringIs)
+	ctx.Step(`^Frequencies is called$`, frequenciesIsCalled)
+	ctx.Step(`^CountBy is called with the last digit as key$`, countByIsCalledWithTheLastDigitAsKey)
+	ctx.Step(`^a second Iterable with the values "([^"]*)"$`, aSecondIterableWithTheValues)
+	ctx.Step(`^Equal is called$`, equalIsCalled)
+	ctx.Step(`^EqualBy is called with a comparator of the last digit$`, equalByIsCalledWithAComparatorOfTheLastDigit)
+	ctx.Step(`^Compare is called$`, compareIsCalled)
+	ctx.Step(`^the returned comparison is (-?\d+)$`, theReturnedComparisonIs)
+	ctx.Step(`^IsSorted is called$`, isSortedIsCalled)
+	ctx.Step(`^ReduceWhile is called with a reducer that sums until the sum exceeds (\d+)$`, reduceWhileIsCalledWithAReducerThatSumsUntilTheSumExceeds)
+	ctx.Step(`^TryFold is called with a reducer that sums and rejects negative values$`, tryFoldIsCalledWithAReducerThatSumsAndRejectsNegativeValues)
+	ctx.Step(`^an error for the value at index (\d+) is returned$`, anErrorForTheValueAtIndexIsReturned)
+	ctx.Step(`^ForEachIndexed is called$`, forEachIndexedIsCalled)
+	ctx.Step(`^the following index and value pairs are received "([^"]*)"$`, theReturnedMapIs)
+	ctx.Step(`^a Collector that accepts at most (\d+) values$`, aCollectorThatAcceptsAtMostValues)
+	ctx.Step(`^CollectInto is called$`, collectIntoIsCalled)
+	ctx.Step(`^the Collector contains "([^"]*)"$`, theCollectorContains)
+	ctx.Step(`^MinMax is called$`, minMaxIsCalled)
+	ctx.Step(`^the returned range is (-?\d+) to (-?\d+)$`, theReturnedRangeIsTo)
+	ctx.Step(`^an Iterable with the values (\d+) to (\d+) in a shuffled order$`, anIterableWithTheValuesToInAShuffledOrder)
+	ctx.Step(`^Quantiles is called with "([^"]*)"$`, quantilesIsCalledWith)
+	ctx.Step(`^QuantilesApprox is called with "([^"]*)"$`, quantilesApproxIsCalledWith)
+	ctx.Step(`^the returned quantiles are "([^"]*)"$`, theReturnedQuantilesAre)
+	ctx.Step(`^the returned quantiles are within (\d+) percent of "([^"]*)"$`, theReturnedQuantilesAreWithinPercentOf)
+	ctx.Step(`^Histogram is called with the buckets "([^"]*)"$`, histogramIsCalledWithTheBuckets)
+	ctx.Step(`^Histogram is called without buckets$`, histogramIsCalledWithoutBuckets)
+	ctx.Step(`^the returned counts are "([^"]*)"$`, theReturnedCountsAre)
+	ctx.Step(`^GroupReduce is called with the last digit as key$`, groupReduceIsCalledWithTheLastDigitAsKey)
+	ctx.Step(`^a map "([^"]*)"$`, aMap)
+	ctx.Step(`^FromMapKeys is called$`, fromMapKeysIsCalled)
+	ctx.Step(`^FromMapValues is called$`, fromMapValuesIsCalled)
+	ctx.Step(`^FromMapKeysSorted is called$`, fromMapKeysSortedIsCalled)
+	ctx.Step(`^FromMapValuesSorted is called$`, fromMapValuesSortedIsCalled)
+	ctx.Step(`^FromMapSorted is called$`, fromMapSortedIsCalled)
+	ctx.Step(`^FromMap is called$`, fromMapIsCalled)
+	ctx.Step(`^the following pairs are returned "([^"]*)"$`, theFollowingPairsAreReturned)
+	ctx.Step(`^the following pairs are returned in any order "([^"]*)"$`, theFollowingPairsAreReturnedInAnyOrder)
+	ctx.Step(`^the string "([^"]*)"$`, theString)
+	ctx.Step(`^FromStringRunes is called$`, fromStringRunesIsCalled)
+	ctx.Step(`^FromStringBytes is called$`, fromStringBytesIsCalled)
+	ctx.Step(`^a reader with the contents "([^"]*)"$`, aReaderWithTheContents)
+	ctx.Step(`^a reader with the contents "([^"]*)" that fails afterwards$`, aReaderWithTheContentsThatFailsAfterwards)
+	ctx.Step(`^FromReader is called with a chunk size of (\d+)$`, fromReaderIsCalledWithAChunkSizeOf)
+	ctx.Step(`^Lines is called$`, linesIsCalled)
+	ctx.Step(`^Lines is called with a split function for words$`, linesIsCalledWithASplitFunctionForWords)
+	ctx.Step(`^Lines is called with a maximum token size of (\d+)$`, linesIsCalledWithAMaximumTokenSizeOf)
+	ctx.Step(`^a scanner that splits the reader into words$`, aScannerThatSplitsTheReaderIntoWords)
+	ctx.Step(`^FromScanner is called$`, fromScannerIsCalled)
+	ctx.Step(`^FromScannerBytes is called and all tokens are collected$`, fromScannerBytesIsCalledAndAllTokensAreCollected)
+	ctx.Step(`^the collected tokens are "([^"]*)"$`, theCollectedTokensAre)
+	ctx.Step(`^FromCSV is called$`, fromCSVIsCalled)
+	ctx.Step(`^calling Next\(\) until false is returned should return the following records:$`, callingNextUntilFalseIsReturnedShouldReturnTheFollowingRecords)
+	ctx.Step(`^Error\(\) of record iterator returns nil$`, errorOfRecordIteratorReturnsNil)
+	ctx.Step(`^Error\(\) of record iterator returns an error$`, errorOfRecordIteratorReturnsAnError)
+	ctx.Step(`^the records with an id are written with ToCSV$`, theRecordsWithAnIdAreWrittenWithToCSV)
+	ctx.Step(`^the written CSV is "([^"]*)"$`, theWrittenCSVIs)
+	ctx.Step(`^FromJSONArray is called$`, fromJSONArrayIsCalled)
+	ctx.Step(`^FromJSONLines is called$`, fromJSONLinesIsCalled)
+	ctx.Step(`^a query returning the rows "([^"]*)"$`, aQueryReturningTheRows)
+	ctx.Step(`^a query returning the rows "([^"]*)" that fails afterwards$`, aQueryReturningTheRowsThatFailsAfterwards)
+	ctx.Step(`^FromRows is called$`, fromRowsIsCalled)
+	ctx.Step(`^FromRows is called with a scan function that fails on the value (\d+)$`, fromRowsIsCalledWithAScanFunctionThatFailsOnTheValue)
+	ctx.Step(`^Next\(\) is called once and the rows iterator is closed$`, nextIsCalledOnceAndTheRowsIteratorIsClosed)
+	ctx.Step(`^the rows are closed$`, theRowsAreClosed)
+	ctx.Step(`^Fibonacci is called$`, fibonacciIsCalled)
+	ctx.Step(`^the last generated Fibonacci number is (\d+) after (\d+) numbers$`, theLastGeneratedFibonacciNumberIsAfterNumbers)
+	ctx.Step(`^Primes is called$`, primesIsCalled)
+	ctx.Step(`^TakeWhile is called$`, takeWhileIsCalled)
+	ctx.Step(`^TakeWhile is called with a predicate that selects values below (\d+)$`, takeWhileIsCalledWithAPredicateThatSelectsValuesBelow)
+	ctx.Step(`^the source Iterable returns the value (\d+) next$`, theSourceIterableReturnsTheValueNext)
+	ctx.Step(`^RandomN is called with the seed (\d+), the range (-?\d+) to (-?\d+) and (\d+) values$`, randomNIsCalledWithTheSeedTheRangeToAndValues)
+	ctx.Step(`^all (\d+) generated values are within the range (-?\d+) to (-?\d+)$`, allGeneratedValuesAreWithinTheRangeTo)
+	ctx.Step(`^all values within the range (-?\d+) to (-?\d+) are generated$`, allValuesWithinTheRangeToAreGenerated)
+	ctx.Step(`^the values are equal to the values of RandomN called with the seed (\d+)$`, theValuesAreEqualToTheValuesOfRandomNCalledWithTheSeed)
+	ctx.Step(`^the values differ from the values of RandomN called with the seed (\d+)$`, theValuesDifferFromTheValuesOfRandomNCalledWithTheSeed)
+	ctx.Step(`^RandomN is called with the seed (\d+) for int8 values from (-?\d+) to (-?\d+) and (\d+) values$`, randomNIsCalledWithTheSeedForInt8ValuesFromToAndValues)
+	ctx.Step(`^all int8 values from (-?\d+) to (-?\d+) are generated$`, allInt8ValuesFromToAreGenerated)
+	ctx.Step(`^RandomN is called with the seed (\d+) for float64 values from (-?\d+) to (-?\d+) and (\d+) values$`, randomNIsCalledWithTheSeedForFloat64ValuesFromToAndValues)
+	ctx.Step(`^all float64 values are at least (-?\d+) and less than (-?\d+)$`, allFloat64ValuesAreAtLeastAndLessThan)
+	ctx.Step(`^Empty is called$`, emptyIsCalled)
+	ctx.Step(`^Once is called with (\d+)$`, onceIsCalledWith)
+	ctx.Step(`^FromFunc is called with a function that counts down from (\d+)$`, fromFuncIsCalledWithAFunctionThatCountsDownFrom)
+	ctx.Step(`^FromFuncErr is called with a function that counts down from (\d+)$`, fromFuncErrIsCalledWithAFunctionThatCountsDownFrom)
+	ctx.Step(`^FromFuncErr is called with a function that counts down from (\d+) and fails at (\d+)$`, fromFuncErrIsCalledWithAFunctionThatCountsDownFromAndFailsAt)
+	ctx.Step(`^the function was called (\d+) times$`, theFunctionWasCalledTimes)
+	ctx.Step(`^SequenceFrom is called with (-?\d+)$`, sequenceFromIsCalledWith)
+	ctx.Step(`^Linspace is called with (-?[\d.]+), (-?[\d.]+) and (\d+)$`, linspaceIsCalledWithAnd)
+	ctx.Step(`^FloatStepSequence is called with (-?[\d.]+), (-?[\d.]+) and (-?[\d.]+)$`, floatStepSequenceIsCalledWithAnd)
+	ctx.Step(`^the last float value is (-?[\d.]+) after (\d+) values$`, theLastFloatValueIsAfterValues)
+	ctx.Step(`^no values are received from the float iterator$`, noValuesAreReceivedFromTheFloatIterator)
+	ctx.Step(`^calling Next\(\) until false is returned should return approximately the following floats:$`, callingNextUntilFalseIsReturnedShouldReturnApproximatelyTheFollowingFloats)
+	ctx.Step(`^Ticks is called with (\d+) milliseconds$`, ticksIsCalledWithMilliseconds)
+	ctx.Step(`^(\d+) ticks are returned in increasing order$`, ticksAreReturnedInIncreasingOrder)
+	ctx.Step(`^the tick iterator is stopped$`, theTickIteratorIsStopped)
+	ctx.Step(`^the tick iterator is stopped after (\d+) milliseconds$`, theTickIteratorIsStoppedAfterMilliseconds)
+	ctx.Step(`^WithContext is called on the tick iterator$`, withContextIsCalledOnTheTickIterator)
+	ctx.Step(`^no tick is returned$`, noTickIsReturned)
+	ctx.Step(`^Error\(\) of tick iterator returns nil$`, errorOfTickIteratorReturnsNil)
+	ctx.Step(`^Error\(\) of tick iterator returns the error of the context$`, errorOfTickIteratorReturnsTheErrorOfTheContext)
+	ctx.Step(`^a file system with the files "([^"]*)"$`, aFileSystemWithTheFiles)
+	ctx.Step(`^a file system with the files "([^"]*)" where directory "([^"]*)" cannot be read$`, aFileSystemWithTheFilesWhereDirectoryCannotBeRead)
+	ctx.Step(`^WalkDir is called with the root "([^"]*)"$`, walkDirIsCalledWithTheRoot)
+	ctx.Step(`^WalkDir is called with the root "([^"]*)" skipping directories named "([^"]*)"$`, walkDirIsCalledWithTheRootSkippingDirectoriesNamed)
+	ctx.Step(`^the walked paths are "([^"]*)"$`, theWalkedPathsAre)
+	ctx.Step(`^the walked paths are in the same order as fs\.WalkDir$`, theWalkedPathsAreInTheSameOrderAsFsWalkDir)
+	ctx.Step(`^Error\(\) of walk iterator returns nil$`, errorOfWalkIteratorReturnsNil)
+	ctx.Step(`^Error\(\) of walk iterator returns an error$`, errorOfWalkIteratorReturnsAnError)
+	ctx.Step(`^FromRegexp is called with the pattern "([^"]*)" on "([^"]*)"$`, fromRegexpIsCalledWithThePatternOn)
+	ctx.Step(`^FromRegexpSubmatch is called with the pattern "([^"]*)" on "([^"]*)"$`, fromRegexpSubmatchIsCalledWithThePatternOn)
+	ctx.Step(`^the matches are "([^"]*)"$`, theMatchesAre)
+	ctx.Step(`^the matches are the same as the matches of FindAllString$`, theMatchesAreTheSameAsTheMatchesOfFindAllString)
+	ctx.Step(`^the submatches are "([^"]*)"$`, theSubmatchesAre)
+	ctx.Step(`^the submatches are the same as the matches of FindAllStringSubmatch$`, theSubmatchesAreTheSameAsTheMatchesOfFindAllStringSubmatch)
+	ctx.Step(`^SplitString is called on "([^"]*)" with the separator "([^"]*)"$`, splitStringIsCalledOnWithTheSeparator)
+	ctx.Step(`^FieldsString is called on "([^"]*)"$`, fieldsStringIsCalledOn)
+	ctx.Step(`^the substrings are "([^"]*)"$`, theSubstringsAre)
+	ctx.Step(`^the substrings are the same as the substrings of strings\.Split$`, theSubstringsAreTheSameAsTheSubstringsOfStringsSplit)
+	ctx.Step(`^a list with the values "([^"]*)"$`, aListWithTheValues)
+	ctx.Step(`^a list with the values "([^"]*)" and a string at index (\d+)$`, aListWithTheValuesAndAStringAtIndex)
+	ctx.Step(`^an empty list$`, anEmptyList)
+	ctx.Step(`^a nil list$`, aNilList)
+	ctx.Step(`^FromList is called$`, fromListIsCalled)
+	ctx.Step(`^a ring with the values "([^"]*)"$`, aRingWithTheValues)
+	ctx.Step(`^a ring with the values "([^"]*)" and a string at index (\d+)$`, aRingWithTheValuesAndAStringAtIndex)
+	ctx.Step(`^FromRing is called with (\d+) laps$`, fromRingIsCalledWithLaps)
+	ctx.Step(`^a heap with the values "([^"]*)"$`, aHeapWithTheValues)
+	ctx.Step(`^FromHeap is called$`, fromHeapIsCalled)
+	ctx.Step(`^ToHeap is called$`, toHeapIsCalled)
+	ctx.Step(`^the heap contains (\d+) values$`, theHeapContainsValues)
+	ctx.Step(`^Unfold is called with the seed (\d+) and a closure that doubles the state below (\d+)$`, unfoldIsCalledWithTheSeedAndAClosureThatDoublesTheStateBelow)
+	ctx.Step(`^an API with the pages "([^"]*)"$`, anAPIWithThePages)
+	ctx.Step(`^an API with the pages "([^"]*)" that fails at page (\d+)$`, anAPIWithThePagesThatFailsAtPage)
+	ctx.Step(`^FromPages is called$`, fromPagesIsCalled)
+	ctx.Step(`^the pages were fetched with the cursors "([^"]*)"$`, thePagesWereFetchedWithTheCursors)
+	ctx.Step(`^an Iterable with the batches "([^"]*)"$`, anIterableWithTheBatches)
+	ctx.Step(`^an Iterable with the batches "([^"]*)" that reuses its buffer$`, anIterableWithTheBatchesThatReusesItsBuffer)
+	ctx.Step(`^an Iterable of batches in an error state$`, anIterableOfBatchesInAnErrorState)
+	ctx.Step(`^FromBatches is called$`, fromBatchesIsCalled)
+	ctx.Step(`^Replayable is called$`, replayableIsCalled)
+	ctx.Step(`^Take is called on the replayable iterator with (\d+)$`, takeIsCalledOnTheReplayableIteratorWith)
+	ctx.Step(`^the replayable iterator is restarted$`, theReplayableIteratorIsRestarted)
+	ctx.Step(`^the source Iterable was consumed once$`, theSourceIterableWasConsumedOnce)
+	ctx.Step(`^ToMapMerge is called with the last digit as key and the sum as merge$`, toMapMergeIsCalledWithTheLastDigitAsKeyAndTheSumAsMerge)
+	ctx.Step(`^ToMapMerge is called with the last digit as key keeping the first value$`, toMapMergeIsCalledWithTheLastDigitAsKeyKeepingTheFirstValue)
+	ctx.Step(`^an Iterable with the values "([^"]*)" that fails afterwards$`, anIterableWithTheValuesThatFailsAfterwards)
+	ctx.Step(`^ToJSONArray is called$`, toJSONArrayIsCalled)
+	ctx.Step(`^ToJSONArray is called with a failing writer$`, toJSONArrayIsCalledWithAFailingWriter)
+	ctx.Step(`^ToJSONLines is called$`, toJSONLinesIsCalled)
+	ctx.Step(`^FromJSONLines is called on the written output$`, fromJSONLinesIsCalledOnTheWrittenOutput)
+	ctx.Step(`^the written output is "([^"]*)"$`, theWrittenOutputIs)
+	ctx.Step(`^the channel is closed by the goroutine$`, theReceivedChannelIsClosedByTheGoroutine)
+	ctx.Step(`^Channel is called with a buffer of (\d+)$`, channelIsCalledWithABufferOf)
+	ctx.Step(`^ChannelCtx is called with a buffer of (\d+)$`, channelCtxIsCalledWithABufferOf)
+	ctx.Step(`^an infinite Iterable$`, anInfiniteIterable)
+	ctx.Step(`^the channel receives "([^"]*)" and is closed$`, theChannelReceivesAndIsClosed)
+	ctx.Step(`^the Iterable reports no error$`, theIterableReportsNoError)
+	ctx.Step(`^the Iterable reports an error$`, theIterableReportsAnError)
+	ctx.Step(`^(\d+) values are received and the context is cancelled$`, valuesAreReceivedAndTheContextIsCancelled)
+	ctx.Step(`^a buffer with the values "([^"]*)" and a capacity of (\d+)$`, aBufferWithTheValuesAndACapacityOf)
+	ctx.Step(`^AppendSlice is called$`, appendSliceIsCalled)
+	ctx.Step(`^AppendSlice is called with the buffer reset to zero length$`, appendSliceIsCalledWithTheBufferResetToZeroLength)
+	ctx.Step(`^the resulting slice is "([^"]*)"$`, theResultingSliceIs)
+	ctx.Step(`^the resulting slice reuses the buffer$`, theResultingSliceReusesTheBuffer)
+	ctx.Step(`^the resulting slice has a capacity of (\d+)$`, theResultingSliceHasACapacityOf)
+	ctx.Step(`^ToSortedSlice is called$`, toSortedSliceIsCalled)
+	ctx.Step(`^ToSortedSliceFunc is called with the last digit as order$`, toSortedSliceFuncIsCalledWithTheLastDigitAsOrder)
+	ctx.Step(`^a sink that flushes every (\d+) values$`, aSinkThatFlushesEveryValues)
+	ctx.Step(`^a sink that flushes every (\d+) values and rejects the value (\d+)$`, aSinkThatFlushesEveryValuesAndRejectsTheValue)
+	ctx.Step(`^a sink that flushes every (\d+) values and fails to flush$`, aSinkThatFlushesEveryValuesAndFailsToFlush)
+	ctx.Step(`^Drain is called$`, drainIsCalled)
+	ctx.Step(`^the sink wrote the batches "([^"]*)"$`, theSinkWroteTheBatches)
+	ctx.Step(`^MapErr is called with strconv\.Atoi$`, mapErrIsCalledWithStrconvAtoi)
+	ctx.Step(`^the remaining strings are "([^"]*)"$`, theRemainingStringsAre)
+	ctx.Step(`^FilterErr is called with a predicate that selects even numbers and fails on (\d+)$`, filterErrIsCalledWithAPredicateThatSelectsEvenNumbersAndFailsOn)
+	ctx.Step(`^ForEachErr is called with a closure that fails on (\d+)$`, forEachErrIsCalledWithAClosureThatFailsOn)
+	ctx.Step(`^the closure received "([^"]*)"$`, theClosureReceived)
+	ctx.Step(`^an error is returned that is not an IndexError$`, anErrorIsReturnedThatIsNotAnIndexError)
+	ctx.Step(`^TryForEach is called with a closure that stops at (\d+) and fails on (\d+)$`, tryForEachIsCalledWithAClosureThatStopsAtAndFailsOn)
+	ctx.Step(`^Map is called with a function that doubles the values$`, mapIsCalledWithAFunctionThatDoublesTheValues)
+	ctx.Step(`^Filter is called with a predicate that selects values below (\d+)$`, filterIsCalledWithAPredicateThatSelectsValuesBelow)
+	ctx.Step(`^Error\(\) of int iterator returns "([^"]*)"$`, errorOfIntIteratorReturnsTheMessage)
+	ctx.Step(`^the unwrapped error of the int iterator is "([^"]*)"$`, theUnwrappedErrorOfTheIntIteratorIs)
+	ctx.Step(`^Error\(\) of int iterator returns a StageError for the (\w+) stage and element (\d+)$`, errorOfIntIteratorReturnsAStageErrorForTheStageAndElement)
+	ctx.Step(`^OnError is called with a handler that resumes$`, onErrorIsCalledWithAHandlerThatResumes)
+	ctx.Step(`^OnError is called with a handler that stops$`, onErrorIsCalledWithAHandlerThatStops)
+	ctx.Step(`^the handler received errors for the elements "([^"]*)"$`, theHandlerReceivedErrorsForTheElements)
+	ctx.Step(`^Map is called with a function that panics on (\d+)$`, mapIsCalledWithAFunctionThatPanicsOn)
+	ctx.Step(`^Safe is called$`, safeIsCalled)
+	ctx.Step(`^the stack trace of the PanicError contains "([^"]*)"$`, theStackTraceOfThePanicErrorContains)
+	ctx.Step(`^FromYAMLDocs is called$`, fromYAMLDocsIsCalled)
+	ctx.Step(`^NextCtx of the int iterator is interrupted by the context$`, nextCtxOfTheIntIteratorIsInterruptedByTheContext)
+	ctx.Step(`^the value (\d+) is sent on the channel in the background$`, theValueIsSentOnTheChannelInTheBackground)
+	ctx.Step(`^NextCtx of the tick iterator is called with the context$`, nextCtxOfTheTickIteratorIsCalledWithTheContext)
+	ctx.Step(`^MapErr is called with a function that doubles the values$`, mapErrIsCalledWithAFunctionThatDoublesTheValues)
+	ctx.Step(`^a table that blocks while fetching a page$`, aTableThatBlocksWhileFetchingAPage)
+	ctx.Step(`^the blocked fetch is released$`, theBlockedFetchIsReleased)
+	ctx.Step(`^an HTTP response with status (\d+) and a body that blocks after "([^"]*)"$`, anHTTPResponseWithStatusAndABodyThatBlocksAfter)
+	ctx.Step(`^WithContext is called on the string iterator$`, withContextIsCalledOnTheStringIterator)
+	ctx.Step(`^Next\(\) of int iterator is called again$`, nextOfIntIteratorIsCalledAgain)
+	ctx.Step(`^MovingAverage is called with a window of (\d+) on the int iterator$`, movingAverageIsCalledWithAWindowOfOnTheIntIterator)
+	ctx.Step(`^Next\(\) of float iterator returns true (\d+) times and then returns false$`, nextOfFloatIteratorReturnsTrueTimesAndThenReturnsFalse)
+	ctx.Step(`^Error\(\) of float iterator returns "([^"]*)"$`, errorOfFloatIteratorReturns)
+	ctx.Step(`^an Iterable with the values "([^"]*)" that panics when Next is called after it returned false$`, anIterableWithTheValuesThatPanicsWhenNextIsCalledAfterItReturnedFalse)
+	ctx.Step("^SplitString is called on the escaped string `([^`]*)` with an empty separator$", splitStringIsCalledOnTheEscapedStringWithAnEmptySeparator)
+	ctx.Step("^the substrings formatted with %q are `([^`]*)`$", theSubstringsFormattedWithQAre)
+	ctx.Step(`^an HTTP response with status (\d+) and a body "([^"]*)" that cancels the context when it is read$`, anHTTPResponseWithStatusAndABodyThatCancelsTheContextWhenItIsRead)
 
 }
 
@@ -896,6 +6448,31 @@ func BenchmarkFilterMapReduce(b *testing.B) {
 	}
 }
 
+func BenchmarkFilterMapJoinString(b *testing.B) {
+
+	var s []int
+
+	for n := 0; n < 1000; n++ {
+		s = append(s, n)
+	}
+
+	odd := func(v int) bool {
+		return (v % 2) != 0
+	}
+
+	benchFunc := func() string {
+		si := FromSlice(s)
+		fi := Filter[int](si, odd)
+		mi := Map[int, string](fi, strconv.Itoa)
+		str, _ := JoinString(mi, ", ")
+		return str
+	}
+
+	for n := 0; n < b.N; n++ {
+		benchFunc()
+	}
+}
+
 func BenchmarkFilterMapReduceInIdiomaticGo(b *testing.B) {
 
 	var s []int
@@ -0,0 +1,128 @@
+// Package itertest contains helpers to test pipelines built with the iterator package.
+package itertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/crosscode-nl/iterator"
+)
+
+// UpdateEnv is the environment variable that makes Golden write the golden files instead of comparing them when it is
+// set to a true value, like 1 or true, for example ITERTEST_UPDATE=1 go test ./...
+const UpdateEnv = "ITERTEST_UPDATE"
+
+// goldenConfig contains the settings of Golden.
+type goldenConfig struct {
+	// update is set when the golden file needs to be written instead of compared.
+	update bool
+}
+
+// GoldenOption configures Golden.
+type GoldenOption func(*goldenConfig)
+
+// WithUpdate makes Golden write the golden file when update is true and compare it when update is false, regardless
+// of UpdateEnv. This lets a test package update golden files with a flag of its own, like
+// itertest.WithUpdate(*update).
+func WithUpdate(update bool) GoldenOption {
+	return func(c *goldenConfig) {
+		c.update = update
+	}
+}
+
+// Codec is the interface that needs to be provided to Golden to serialize each value.
+type Codec[T any] interface {
+	// Encode returns the serialized value. The serialized value should not contain new lines, because
+	// Golden writes each value on its own line.
+	Encode(v T) ([]byte, error)
+}
+
+// FmtCodec is a Codec that serializes values with the default format of the fmt package.
+type FmtCodec[T any] struct{}
+
+// Encode returns the value formatted with fmt.Sprint.
+func (FmtCodec[T]) Encode(v T) ([]byte, error) {
+	return []byte(fmt.Sprint(v)), nil
+}
+
+// JSONCodec is a Codec that serializes values as JSON.
+type JSONCodec[T any] struct{}
+
+// Encode returns the value marshalled with json.Marshal.
+func (JSONCodec[T]) Encode(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Golden drains the Iterable, serializes each value with the Codec on its own line and compares the result with the
+// golden file at goldenPath. The test fails when the output differs from the golden file. When UpdateEnv is set to a
+// true value, or WithUpdate(true) is provided, the golden file is written instead, creating the directory when needed.
+func Golden[T any](t testing.TB, iter iterator.Iterable[T], goldenPath string, codec Codec[T], opts ...GoldenOption) {
+	t.Helper()
+
+	var config goldenConfig
+	config.update, _ = strconv.ParseBool(os.Getenv(UpdateEnv))
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var got bytes.Buffer
+	for v, b := iter.Next(); b; v, b = iter.Next() {
+		line, err := codec.Encode(v)
+		if err != nil {
+			t.Fatalf("itertest: encoding %v: %v", v, err)
+			return
+		}
+		got.Write(line)
+		got.WriteByte('\n')
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatalf("itertest: iterating: %v", err)
+		return
+	}
+
+	if config.update {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+			t.Fatalf("itertest: creating directory for %s: %v", goldenPath, err)
+			return
+		}
+		if err := os.WriteFile(goldenPath, got.Bytes(), 0o644); err != nil {
+			t.Fatalf("itertest: writing %s: %v", goldenPath, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("itertest: reading %s (set %s=1 to create it): %v", goldenPath, UpdateEnv, err)
+		return
+	}
+	if !bytes.Equal(want, got.Bytes()) {
+		t.Errorf("itertest: output differs from %s (set %s=1 to update it)\n%s", goldenPath, UpdateEnv,
+			diff(string(want), got.String()))
+	}
+}
+
+// diff returns a description of the first line that differs between want and got.
+func diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %q\n  got:  %q", i+1, w, g)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package itertest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crosscode-nl/iterator"
+)
+
+// recorder is a testing.TB that records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestGoldenMatches(t *testing.T) {
+	odd := iterator.Filter[int](iterator.Sequence(1, 10), func(v int) bool {
+		return v%2 != 0
+	})
+	Golden[int](t, odd, filepath.Join("testdata", "odd.golden"), FmtCodec[int]{})
+}
+
+func TestGoldenJSON(t *testing.T) {
+	type point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	points := iterator.Map[int](iterator.Sequence(1, 3), func(v int) point {
+		return point{X: v, Y: v * v}
+	})
+	Golden[point](t, points, filepath.Join("testdata", "points.golden"), JSONCodec[point]{})
+}
+
+func TestGoldenDiffers(t *testing.T) {
+	r := &recorder{TB: t}
+	Golden[int](r, iterator.Sequence(1, 4), filepath.Join("testdata", "odd.golden"), FmtCodec[int]{}, WithUpdate(false))
+	if !r.failed {
+		t.Fatal("expected a failure but got none")
+	}
+	if !strings.Contains(r.message, "line 2") {
+		t.Fatalf("expected the first differing line in: %s", r.message)
+	}
+}
+
+func TestGoldenMissingFile(t *testing.T) {
+	r := &recorder{TB: t}
+	Golden[int](r, iterator.Sequence(1, 4), filepath.Join("testdata", "missing.golden"), FmtCodec[int]{}, WithUpdate(false))
+	if !r.failed {
+		t.Fatal("expected a failure but got none")
+	}
+}
+
+func TestGoldenWithUpdate(t *testing.T) {
+	goldenPath := filepath.Join(t.TempDir(), "testdata", "sequence.golden")
+	Golden[int](t, iterator.Sequence(1, 3), goldenPath, FmtCodec[int]{}, WithUpdate(true))
+	got, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "1\n2\n3\n" {
+		t.Fatalf("expected the golden file to be written, got: %q", got)
+	}
+}
+
+func TestGoldenUpdateEnv(t *testing.T) {
+	t.Setenv(UpdateEnv, "1")
+	goldenPath := filepath.Join(t.TempDir(), "sequence.golden")
+	Golden[int](t, iterator.Sequence(1, 2), goldenPath, FmtCodec[int]{})
+	if _, err := os.Stat(goldenPath); err != nil {
+		t.Fatalf("expected the golden file to be written: %v", err)
+	}
+
+	r := &recorder{TB: t}
+	Golden[int](r, iterator.Sequence(1, 3), goldenPath, FmtCodec[int]{}, WithUpdate(false))
+	if !r.failed {
+		t.Fatal("expected WithUpdate(false) to compare the golden file")
+	}
+}
@@ -0,0 +1,99 @@
+//go:build go1.23
+
+package iterator
+
+import "iter"
+
+// Range-over-func adapters
+// The adapters convert between the iterators of this package and the iter.Seq and iter.Seq2 range-over-func
+// iterators of the standard library.
+
+// SeqIterator is a generic struct implementing an iterator that pulls the values of an iter.Seq or iter.Seq2.
+// The sequence runs until it is exhausted or Stop is called.
+type SeqIterator[T any] struct {
+	// next pulls the next value of the sequence.
+	next func() (T, bool)
+	// stop stops the sequence.
+	stop func()
+	// done is set when the sequence is exhausted or stopped.
+	done bool
+}
+
+// Next returns the first or next value of T and true if a value is available.
+// The sequence is stopped when no more values are available.
+// If no more values are available then a zero value of T and false is returned.
+func (si *SeqIterator[T]) Next() (T, bool) {
+	var zero T
+	if si.done {
+		return zero, false
+	}
+	v, ok := si.next()
+	if !ok {
+		si.Stop()
+		return zero, false
+	}
+	return v, true
+}
+
+// Error returns nil after Next returned false when the iteration has completed successfully, otherwise
+// an error is returned. The SeqIterator never returns an error.
+func (si *SeqIterator[T]) Error() error {
+	return nil
+}
+
+// Stop stops the sequence when it was not already stopped. Stop must be called when the iteration is stopped before
+// it was exhausted, so the resources of the sequence are released.
+func (si *SeqIterator[T]) Stop() {
+	if !si.done {
+		si.done = true
+		si.stop()
+	}
+}
+
+// FromSeq creates a SeqIterator that pulls the values of seq with iter.Pull, so any iter.Seq, like the ones returned
+// by slices.Values and maps.Keys, can be used as an Iterable.
+func FromSeq[T any](seq iter.Seq[T]) *SeqIterator[T] {
+	next, stop := iter.Pull(seq)
+	return &SeqIterator[T]{
+		next: next,
+		stop: stop,
+	}
+}
+
+// FromSeq2 works like FromSeq, but pulls the key and value pairs of an iter.Seq2, like the ones returned by maps.All
+// and slices.All, with iter.Pull2.
+func FromSeq2[K any, V any](seq iter.Seq2[K, V]) *SeqIterator[Pair[K, V]] {
+	next, stop := iter.Pull2(seq)
+	return &SeqIterator[Pair[K, V]]{
+		next: func() (Pair[K, V], bool) {
+			k, v, ok := next()
+			return Pair[K, V]{Key: k, Value: v}, ok
+		},
+		stop: stop,
+	}
+}
+
+// ToSeq returns an iter.Seq that yields the values of the Iterable, so they can be consumed with a range loop or
+// passed to functions of the standard library like slices.Collect. An iter.Seq cannot report an error, so Error of
+// the Iterable must be called after the range loop completes to check whether the iteration ended because of an
+// error. The Iterable is consumed, so the iter.Seq can only be ranged over once.
+func ToSeq[T any](it Iterable[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, ok := it.Next(); ok; v, ok = it.Next() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToSeq2 works like ToSeq, but returns an iter.Seq2 that yields the key and value of each Pair of the Iterable.
+func ToSeq2[K any, V any](it Iterable[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p, ok := it.Next(); ok; p, ok = it.Next() {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}
+}
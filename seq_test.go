@@ -0,0 +1,127 @@
+//go:build go1.23
+
+package iterator
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func ExampleFromSeq() {
+	prices := map[string]int{"apple": 3, "pear": 4, "melon": 12}
+
+	// Error is ignored. Errors can only occur in Iterators which can have an error state. For example a custom iterator
+	// that reads data from the database, but the connection is terminated while the iteration was not completed.
+	cheap, _ := ToSlice[string](Filter[string](FromSeq(slices.Values(slices.Sorted(maps.Keys(prices)))), func(name string) bool {
+		return prices[name] < 10
+	}))
+	fmt.Println(cheap)
+
+	// Output:
+	// [apple pear]
+}
+
+func ExampleToSeq() {
+	scores := Filter[int](FromSlice([]int{7, 3, 9, 5}), func(v int) bool {
+		return v > 4
+	})
+	for v := range ToSeq[int](scores) {
+		fmt.Println(v)
+	}
+	// The iter.Seq cannot report errors, so the error is checked after the range loop.
+	fmt.Println(scores.Error())
+
+	// Output:
+	// 7
+	// 9
+	// 5
+	// <nil>
+}
+
+func TestFromSeqReturnsAllValues(tt *testing.T) {
+	values, err := ToSlice[int](FromSeq(slices.Values([]int{1, 2, 3})))
+	if err != nil {
+		tt.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		tt.Errorf("expected [1 2 3], got %v", values)
+	}
+}
+
+func TestFromSeqStopsTheSequence(tt *testing.T) {
+	stopped := false
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	si := FromSeq(seq)
+	values, _ := ToSlice[int](Take[int](si, 2))
+	if !reflect.DeepEqual(values, []int{0, 1}) {
+		tt.Errorf("expected [0 1], got %v", values)
+	}
+	si.Stop()
+	si.Stop()
+	if !stopped {
+		tt.Error("expected the sequence to be stopped")
+	}
+	if v, ok := si.Next(); ok {
+		tt.Errorf("expected no value after Stop, got %v", v)
+	}
+}
+
+func TestFromSeq2ReturnsPairs(tt *testing.T) {
+	pairs, err := ToSlice[Pair[int, string]](FromSeq2(slices.All([]string{"a", "b"})))
+	if err != nil {
+		tt.Fatal(err)
+	}
+	expected := []Pair[int, string]{{Key: 0, Value: "a"}, {Key: 1, Value: "b"}}
+	if !reflect.DeepEqual(pairs, expected) {
+		tt.Errorf("expected %v, got %v", expected, pairs)
+	}
+	if _, ok := FromSeq2(slices.All([]string{})).Next(); ok {
+		tt.Error("expected no pairs for an empty sequence")
+	}
+}
+
+func TestToSeqYieldsAllValues(tt *testing.T) {
+	values := slices.Collect(ToSeq[int](FromSlice([]int{1, 2, 3})))
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		tt.Errorf("expected [1 2 3], got %v", values)
+	}
+}
+
+func TestToSeqStopsWhenTheLoopBreaks(tt *testing.T) {
+	it := FromSlice([]int{1, 2, 3})
+	for v := range ToSeq[int](it) {
+		if v == 2 {
+			break
+		}
+	}
+	if v, ok := it.Next(); !ok || v != 3 {
+		tt.Errorf("expected the remaining value 3, got %v, %v", v, ok)
+	}
+}
+
+func TestToSeqLeavesTheErrorOnTheIterable(tt *testing.T) {
+	it := &ErrorIterator[int]{}
+	for v := range ToSeq[int](it) {
+		tt.Errorf("expected no values, got %v", v)
+	}
+	if it.Error() == nil {
+		tt.Error("expected an error")
+	}
+}
+
+func TestToSeq2YieldsKeysAndValues(tt *testing.T) {
+	m := maps.Collect(ToSeq2[string, int](FromSlice([]Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}})))
+	if !reflect.DeepEqual(m, map[string]int{"a": 1, "b": 2}) {
+		tt.Errorf("expected map[a:1 b:2], got %v", m)
+	}
+}